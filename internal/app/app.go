@@ -5,17 +5,27 @@ import (
 	"go-clean-api/pkg"
 	"go-clean-api/pkg/adapters/db"
 	"go-clean-api/pkg/adapters/repositories/gorm_mysql"
+	"go-clean-api/pkg/adapters/repositories/memory"
+	"go-clean-api/pkg/domain/repositories"
+	"go-clean-api/pkg/domain/services"
 	"go-clean-api/pkg/domain/usecases"
 	"go-clean-api/pkg/infrastructure/auth"
 	"go-clean-api/pkg/infrastructure/logger"
+	"go-clean-api/pkg/infrastructure/publishers"
+	"go-clean-api/pkg/infrastructure/webhooks"
 )
 
 // Dependencies holds all wired dependencies for the application.
 type Dependencies struct {
-	Config      pkg.Config
-	DB          db.DB
-	Logger      logger.CustomLogger
-	UserUseCase usecases.User
+	Config             pkg.Config
+	DB                 db.DB
+	Logger             logger.CustomLogger
+	UserUseCase        usecases.User
+	AuditLogUseCase    usecases.AuditLog
+	OutboxUseCase      usecases.Outbox
+	WebhookUseCase     usecases.Webhook
+	APIKeyUseCase      usecases.APIKey
+	IdempotencyUseCase usecases.Idempotency
 }
 
 // NewDependencies creates and wires all application dependencies.
@@ -26,13 +36,73 @@ func NewDependencies(config pkg.Config, database db.DB, l logger.CustomLogger) (
 	}
 
 	userRepo := gorm_mysql.NewUser(gormDB)
+	unitOfWork := gorm_mysql.NewUnitOfWork(gormDB)
 	tokenGen := auth.NewJWTTokenGenerator(config.JWT)
-	userUseCase := usecases.NewUser(userRepo, tokenGen)
+	passwordPolicy, err := auth.NewPasswordPolicy(config.Password)
+	if err != nil {
+		return nil, err
+	}
+	emailPolicy, err := auth.NewEmailPolicy(config.Email)
+	if err != nil {
+		return nil, err
+	}
+	userUseCase := usecases.NewUser(userRepo, unitOfWork, tokenGen, auth.NewPasswordHasher(config.Password), passwordPolicy, emailPolicy)
+	auditLogUseCase := usecases.NewAuditLog(unitOfWork)
+	apiKeyUseCase := usecases.NewAPIKey(gorm_mysql.NewAPIKey(gormDB), userRepo)
+	webhookUseCase := usecases.NewWebhook(
+		gorm_mysql.NewWebhook(gormDB),
+		webhooks.NewHTTPSender(config.Webhooks.Timeout, config.RequestID.Header),
+		usecases.WebhookConfig{
+			MaxAttempts:          config.Webhooks.MaxAttempts,
+			RetryDelay:           config.Webhooks.RetryDelay,
+			DisableAfterFailures: config.Webhooks.DisableAfterFailures,
+		},
+	)
+	idempotencyUseCase := usecases.NewIdempotency(
+		newIdempotencyKeyRepository(config.Idempotency, gormDB),
+		usecases.IdempotencyConfig{
+			TTL:         config.Idempotency.TTL,
+			LockTimeout: config.Idempotency.LockTimeout,
+		},
+	)
+	outboxUseCase := usecases.NewOutbox(unitOfWork, newEventPublisher(config.Outbox, l, webhookUseCase), config.Outbox.MaxAttempts, config.Outbox.LeaseDuration)
 
 	return &Dependencies{
-		Config:      config,
-		DB:          database,
-		Logger:      l,
-		UserUseCase: userUseCase,
+		Config:             config,
+		DB:                 database,
+		Logger:             l,
+		UserUseCase:        userUseCase,
+		AuditLogUseCase:    auditLogUseCase,
+		OutboxUseCase:      outboxUseCase,
+		WebhookUseCase:     webhookUseCase,
+		APIKeyUseCase:      apiKeyUseCase,
+		IdempotencyUseCase: idempotencyUseCase,
 	}, nil
 }
+
+// newEventPublisher creates the publisher of the domain events from the configuration.
+func newEventPublisher(config pkg.ConfigOutbox, l logger.CustomLogger, webhookUseCase usecases.Webhook) services.EventPublisher {
+	list := make([]services.EventPublisher, 0, len(config.Publishers))
+	for _, name := range config.Publishers {
+		switch name {
+		case "log":
+			list = append(list, publishers.NewLogPublisher(l))
+		case "file":
+			list = append(list, publishers.NewFilePublisher(config.FilePath))
+		case "webhook":
+			list = append(list, publishers.NewWebhookPublisher(config.WebhookURL, config.WebhookTimeout))
+		case "webhooks":
+			list = append(list, publishers.NewRegisteredWebhooksPublisher(webhookUseCase))
+		}
+	}
+
+	return publishers.NewMultiPublisher(list...)
+}
+
+// newIdempotencyKeyRepository creates the store of the idempotency keys from the configuration.
+func newIdempotencyKeyRepository(config pkg.ConfigIdempotency, gormDB *db.GormMySQL) repositories.IdempotencyKey {
+	if config.Store == "memory" {
+		return memory.NewIdempotencyKey()
+	}
+	return gorm_mysql.NewIdempotencyKey(gormDB)
+}
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS `audit_logs`
+(
+    `id`           varchar(36)  NOT NULL,
+    `actor_id`     varchar(36)  DEFAULT NULL,
+    `action`       varchar(63)  NOT NULL,
+    `target_type`  varchar(63)  NOT NULL,
+    `target_id`    varchar(36)  NOT NULL,
+    `before_state` json         DEFAULT NULL,
+    `after_state`  json         DEFAULT NULL,
+    `ip`           varchar(45)  NOT NULL,
+    `request_id`   varchar(63)  NOT NULL,
+    `created_at`   datetime(3)  NOT NULL,
+    PRIMARY KEY (`id`),
+    KEY `idx_audit_logs_actor_id` (`actor_id`),
+    KEY `idx_audit_logs_action` (`action`),
+    KEY `idx_audit_logs_target` (`target_type`, `target_id`),
+    KEY `idx_audit_logs_created_at` (`created_at`)
+) ENGINE = InnoDB
+  DEFAULT 
+    CHARSET = utf8mb4
+    COLLATE = utf8mb4_general_ci;
\ No newline at end of file
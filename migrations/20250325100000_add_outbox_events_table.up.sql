@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS `outbox_events`
+(
+    `id`              varchar(36)  NOT NULL,
+    `name`            varchar(127) NOT NULL,
+    `aggregate_id`    varchar(36)  NOT NULL,
+    `payload`         json         NOT NULL,
+    `occurred_at`     datetime(3)  NOT NULL,
+    `attempts`        int unsigned NOT NULL DEFAULT 0,
+    `last_error`      text         DEFAULT NULL,
+    `next_attempt_at` datetime(3)  NOT NULL,
+    `dispatched_at`   datetime(3)  DEFAULT NULL,
+    `failed_at`       datetime(3)  DEFAULT NULL,
+    PRIMARY KEY (`id`),
+    KEY `idx_outbox_events_pending` (`dispatched_at`, `failed_at`, `next_attempt_at`),
+    KEY `idx_outbox_events_aggregate_id` (`aggregate_id`)
+) ENGINE = InnoDB
+  DEFAULT 
+    CHARSET = utf8mb4
+    COLLATE = utf8mb4_general_ci;
\ No newline at end of file
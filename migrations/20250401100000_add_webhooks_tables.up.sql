@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS `webhooks`
+(
+    `id`                   varchar(36)   NOT NULL,
+    `url`                  varchar(2048) NOT NULL,
+    `secret`               varchar(255)  NOT NULL,
+    `events`               json          NOT NULL,
+    `enabled`              tinyint(1)    NOT NULL DEFAULT 1,
+    `consecutive_failures` int unsigned  NOT NULL DEFAULT 0,
+    `created_at`           datetime      NOT NULL,
+    `updated_at`           datetime      NOT NULL,
+    `disabled_at`          datetime      DEFAULT NULL,
+    PRIMARY KEY (`id`),
+    KEY `idx_webhooks_enabled` (`enabled`)
+) ENGINE = InnoDB
+  DEFAULT 
+    CHARSET = utf8mb4
+    COLLATE = utf8mb4_general_ci;
+
+CREATE TABLE IF NOT EXISTS `webhook_deliveries`
+(
+    `id`          varchar(36)  NOT NULL,
+    `webhook_id`  varchar(36)  NOT NULL,
+    `event_id`    varchar(36)  NOT NULL,
+    `event_name`  varchar(127) NOT NULL,
+    `payload`     json         NOT NULL,
+    `attempt`     int unsigned NOT NULL,
+    `status_code` int unsigned NOT NULL DEFAULT 0,
+    `success`     tinyint(1)   NOT NULL,
+    `error`       text         DEFAULT NULL,
+    `duration_ms` int unsigned NOT NULL DEFAULT 0,
+    `created_at`  datetime(3)  NOT NULL,
+    PRIMARY KEY (`id`),
+    KEY `idx_webhook_deliveries_webhook_id_created_at` (`webhook_id`, `created_at`),
+    CONSTRAINT `fk_webhook_deliveries_webhook_id` FOREIGN KEY (`webhook_id`) REFERENCES `webhooks` (`id`) ON DELETE CASCADE
+) ENGINE = InnoDB
+  DEFAULT 
+    CHARSET = utf8mb4
+    COLLATE = utf8mb4_general_ci;
\ No newline at end of file
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS `api_keys`
+(
+    `id`           varchar(36)  NOT NULL,
+    `user_id`      varchar(36)  NOT NULL,
+    `name`         varchar(127) NOT NULL,
+    `prefix`       varchar(16)  NOT NULL,
+    `hash`         char(64)     NOT NULL,
+    `scopes`       json         NOT NULL,
+    `expires_at`   datetime(3)  DEFAULT NULL,
+    `last_used_at` datetime(3)  DEFAULT NULL,
+    `created_at`   datetime(3)  NOT NULL,
+    `revoked_at`   datetime(3)  DEFAULT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `prefix` (`prefix`),
+    KEY `idx_api_keys_user_id` (`user_id`),
+    CONSTRAINT `fk_api_keys_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE = InnoDB
+  DEFAULT 
+    CHARSET = utf8mb4
+    COLLATE = utf8mb4_general_ci;
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS `idempotency_keys`
+(
+    `key`              char(64)    NOT NULL,
+    `fingerprint`      char(64)    NOT NULL,
+    `status`           varchar(16) NOT NULL,
+    `response_status`  int         NOT NULL DEFAULT 0,
+    `response_headers` json        DEFAULT NULL,
+    `response_body`    longblob    DEFAULT NULL,
+    `created_at`       datetime(3) NOT NULL,
+    `expires_at`       datetime(3) NOT NULL,
+    PRIMARY KEY (`key`),
+    KEY `idx_idempotency_keys_expires_at` (`expires_at`)
+) ENGINE = InnoDB
+  DEFAULT 
+    CHARSET = utf8mb4
+    COLLATE = utf8mb4_general_ci;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE `users` DROP COLUMN `version`;
\ No newline at end of file
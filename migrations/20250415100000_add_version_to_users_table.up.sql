@@ -0,0 +1 @@
+ALTER TABLE `users` ADD COLUMN `version` int unsigned NOT NULL DEFAULT 1 AFTER `updated_at`;
\ No newline at end of file
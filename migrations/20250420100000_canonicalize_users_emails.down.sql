@@ -0,0 +1,2 @@
+-- The emails cannot be restored as they were before their canonicalization.
+SELECT 1;
@@ -0,0 +1,13 @@
+-- Existing emails are stored like the new ones (see vo.CanonicalEmail): trimmed and in lower case.
+-- The case insensitive unique key of `email` already forbids emails which only differ by their case, so no other
+-- column is needed. The internationalized domains are converted to punycode by the application only.
+--
+-- Emails which only differ by surrounding spaces would collide once trimmed: they are left unchanged to be merged
+-- by hand. They are listed by:
+--   SELECT LOWER(TRIM(`email`)), COUNT(*) FROM `users` GROUP BY LOWER(TRIM(`email`)) HAVING COUNT(*) > 1;
+UPDATE `users` u
+    INNER JOIN (SELECT LOWER(TRIM(`email`)) AS `email`
+                FROM `users`
+                GROUP BY LOWER(TRIM(`email`))
+                HAVING COUNT(*) = 1) c ON c.`email` = LOWER(TRIM(u.`email`))
+SET u.`email` = c.`email`;
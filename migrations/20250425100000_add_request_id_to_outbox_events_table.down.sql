@@ -0,0 +1,3 @@
+ALTER TABLE `outbox_events` DROP COLUMN `request_id`;
+
+ALTER TABLE `audit_logs` MODIFY COLUMN `request_id` varchar(63) NOT NULL;
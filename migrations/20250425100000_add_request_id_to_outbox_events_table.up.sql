@@ -0,0 +1,4 @@
+-- The request IDs accepted from the trusted proxies can have up to 128 characters.
+ALTER TABLE `audit_logs` MODIFY COLUMN `request_id` varchar(128) NOT NULL;
+
+ALTER TABLE `outbox_events` ADD COLUMN `request_id` varchar(128) NOT NULL DEFAULT '' AFTER `payload`;
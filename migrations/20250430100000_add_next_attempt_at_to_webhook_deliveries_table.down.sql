@@ -0,0 +1,3 @@
+ALTER TABLE `webhook_deliveries` DROP KEY `idx_webhook_deliveries_webhook_id_event_id`;
+
+ALTER TABLE `webhook_deliveries` DROP COLUMN `next_attempt_at`;
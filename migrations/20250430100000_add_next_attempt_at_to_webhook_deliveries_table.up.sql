@@ -0,0 +1,4 @@
+-- Date of the next automatic attempt of a failed delivery (NULL if the delivery succeeded or will not be retried).
+ALTER TABLE `webhook_deliveries` ADD COLUMN `next_attempt_at` datetime(3) DEFAULT NULL AFTER `duration_ms`;
+
+ALTER TABLE `webhook_deliveries` ADD KEY `idx_webhook_deliveries_webhook_id_event_id` (`webhook_id`, `event_id`, `created_at`);
@@ -0,0 +1,16 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// mysqlErrDuplicateEntry is the MySQL error number of a unique constraint violation
+const mysqlErrDuplicateEntry uint16 = 1062
+
+// IsDuplicateEntryError returns true if the error is a MySQL unique constraint violation.
+func IsDuplicateEntryError(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry
+}
@@ -0,0 +1,17 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsDuplicateEntryError(t *testing.T) {
+	assert.True(t, IsDuplicateEntryError(&mysql.MySQLError{Number: 1062}))
+	assert.True(t, IsDuplicateEntryError(fmt.Errorf("[repository %w]", &mysql.MySQLError{Number: 1062})))
+	assert.False(t, IsDuplicateEntryError(&mysql.MySQLError{Number: 1213}))
+	assert.False(t, IsDuplicateEntryError(errors.New("other error")))
+}
@@ -0,0 +1,157 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// GormZapLogger is a GORM logger writing the SQL queries as zap JSON logs
+// readable by the logs command.
+// The queries are logged with the fields sql, rows (-1 if unknown), duration, slow and caller
+// (the caller of GORM in the application).
+// Its level can be changed at runtime to follow the application log level.
+type GormZapLogger struct {
+	inner         *zap.Logger
+	level         *atomic.Int32
+	configured    logger.LogLevel
+	slowThreshold time.Duration
+}
+
+// NewGormZapLogger creates a GORM logger writing in output.
+// The errors are logged for the Error level, with the slow queries for the Warn level
+// and with all the queries for the Info level.
+// Record not found errors are not logged.
+// The options customize the zap logger (redaction of the sensitive data for example).
+func NewGormZapLogger(output io.Writer, level logger.LogLevel, slowThreshold time.Duration, options ...zap.Option) *GormZapLogger {
+	encoder := zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+		MessageKey:     "message",
+		LevelKey:       "level",
+		EncodeLevel:    zapcore.CapitalLevelEncoder,
+		TimeKey:        "time",
+		EncodeTime:     zapcore.RFC3339TimeEncoder,
+		EncodeDuration: zapcore.StringDurationEncoder,
+	})
+	core := zapcore.NewCore(encoder, zapcore.Lock(zapcore.AddSync(output)), zapcore.DebugLevel)
+
+	return &GormZapLogger{
+		inner:         zap.New(core, options...),
+		level:         newGormLevel(level),
+		configured:    level,
+		slowThreshold: slowThreshold,
+	}
+}
+
+// LogMode returns a copy of the logger with another level.
+// The level of the copy does not follow the runtime level changes.
+func (l *GormZapLogger) LogMode(level logger.LogLevel) logger.Interface {
+	newLogger := *l
+	newLogger.level = newGormLevel(level)
+	return &newLogger
+}
+
+// SetLogLevel changes the level from the application log level:
+// all the queries are logged in debug and info, the slow queries in warn and only the errors above.
+func (l *GormZapLogger) SetLogLevel(level zapcore.Level) {
+	switch {
+	case level <= zapcore.InfoLevel:
+		l.level.Store(int32(logger.Info))
+	case level == zapcore.WarnLevel:
+		l.level.Store(int32(logger.Warn))
+	default:
+		l.level.Store(int32(logger.Error))
+	}
+}
+
+// ResetLogLevel restores the configured level (GORM_LOG_LEVEL)
+func (l *GormZapLogger) ResetLogLevel() {
+	l.level.Store(int32(l.configured))
+}
+
+// logLevel returns the current level
+func (l *GormZapLogger) logLevel() logger.LogLevel {
+	return logger.LogLevel(l.level.Load())
+}
+
+func newGormLevel(level logger.LogLevel) *atomic.Int32 {
+	l := &atomic.Int32{}
+	l.Store(int32(level))
+	return l
+}
+
+func (l *GormZapLogger) Info(_ context.Context, msg string, data ...any) {
+	if l.logLevel() >= logger.Info {
+		l.inner.Info(fmt.Sprintf(msg, data...), gormCaller())
+	}
+}
+
+func (l *GormZapLogger) Warn(_ context.Context, msg string, data ...any) {
+	if l.logLevel() >= logger.Warn {
+		l.inner.Warn(fmt.Sprintf(msg, data...), gormCaller())
+	}
+}
+
+func (l *GormZapLogger) Error(_ context.Context, msg string, data ...any) {
+	if l.logLevel() >= logger.Error {
+		l.inner.Error(fmt.Sprintf(msg, data...), gormCaller())
+	}
+}
+
+// Trace logs a query according to the level
+func (l *GormZapLogger) Trace(_ context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	level := l.logLevel()
+	if level <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	slow := l.slowThreshold > 0 && elapsed > l.slowThreshold
+
+	switch {
+	case err != nil && level >= logger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		l.inner.Error("sql error", append(gormQueryFields(fc, elapsed, slow), zap.Error(err))...)
+	case slow && level >= logger.Warn:
+		l.inner.Warn("slow sql", gormQueryFields(fc, elapsed, slow)...)
+	case level >= logger.Info:
+		l.inner.Info("sql", gormQueryFields(fc, elapsed, slow)...)
+	}
+}
+
+// gormQueryFields returns the fields of a query
+func gormQueryFields(fc func() (string, int64), elapsed time.Duration, slow bool) []zap.Field {
+	sql, rows := fc()
+
+	return []zap.Field{
+		zap.String("sql", sql),
+		zap.Int64("rows", rows),
+		zap.Duration("duration", elapsed),
+		zap.Bool("slow", slow),
+		gormCaller(),
+	}
+}
+
+// gormCaller returns the field of the first caller outside GORM and this logger
+func gormCaller() zap.Field {
+	pcs := [16]uintptr{}
+	n := runtime.Callers(2, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.Contains(frame.File, "gorm.io/") && !strings.HasSuffix(frame.File, "/gorm_logger.go") {
+			return zap.String("caller", zapcore.NewEntryCaller(frame.PC, frame.File, frame.Line, true).TrimmedPath())
+		}
+		if !more {
+			return zap.Skip()
+		}
+	}
+}
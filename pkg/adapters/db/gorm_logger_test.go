@@ -0,0 +1,101 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestGormZapLoggerTrace(t *testing.T) {
+	query := func() (string, int64) { return "SELECT * FROM `users` WHERE id = 1", 1 }
+	fast := time.Now()
+	slow := time.Now().Add(-time.Second)
+
+	tests := []struct {
+		name          string
+		level         logger.LogLevel
+		begin         time.Time
+		err           error
+		wantedLevel   string
+		wantedMessage string
+	}{
+		{name: "Query in info", level: logger.Info, begin: fast, wantedLevel: "INFO", wantedMessage: "sql"},
+		{name: "Query in warn", level: logger.Warn, begin: fast},
+		{name: "Slow query in warn", level: logger.Warn, begin: slow, wantedLevel: "WARN", wantedMessage: "slow sql"},
+		{name: "Slow query in error", level: logger.Error, begin: slow},
+		{name: "Error in error", level: logger.Error, begin: fast, err: errors.New("deadlock"), wantedLevel: "ERROR", wantedMessage: "sql error"},
+		{name: "Record not found", level: logger.Info, begin: fast, err: gorm.ErrRecordNotFound, wantedLevel: "INFO", wantedMessage: "sql"},
+		{name: "Silent", level: logger.Silent, begin: slow, err: errors.New("deadlock")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			l := NewGormZapLogger(&buf, logger.Info, 200*time.Millisecond).LogMode(tt.level)
+
+			l.Trace(context.Background(), tt.begin, query, tt.err)
+
+			if tt.wantedLevel == "" {
+				assert.Equal(t, "", buf.String())
+				return
+			}
+
+			var entry map[string]any
+			assert.Nil(t, json.Unmarshal(buf.Bytes(), &entry))
+			assert.Equal(t, tt.wantedLevel, entry["level"])
+			assert.Equal(t, tt.wantedMessage, entry["message"])
+			assert.Equal(t, "SELECT * FROM `users` WHERE id = 1", entry["sql"])
+			assert.Equal(t, 1.0, entry["rows"])
+			assert.Equal(t, tt.begin == slow, entry["slow"])
+			assert.NotEmpty(t, entry["time"])
+			_, err := time.ParseDuration(entry["duration"].(string))
+			assert.Nil(t, err)
+			assert.True(t, strings.HasPrefix(entry["caller"].(string), "db/gorm_logger_test.go:"), entry["caller"])
+			if tt.wantedLevel == "ERROR" {
+				assert.Equal(t, "deadlock", entry["error"])
+			}
+		})
+	}
+}
+
+func TestGormZapLoggerMessages(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewGormZapLogger(&buf, logger.Warn, 0)
+
+	l.Info(context.Background(), "ignored %d", 1)
+	l.Warn(context.Background(), "warning %d", 2)
+
+	var entry map[string]any
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "WARN", entry["level"])
+	assert.Equal(t, "warning 2", entry["message"])
+}
+
+func TestGormZapLoggerRuntimeLevel(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewGormZapLogger(&buf, logger.Error, 0)
+	query := func() (string, int64) { return "SELECT 1", 1 }
+
+	l.Trace(context.Background(), time.Now(), query, nil)
+	assert.Equal(t, "", buf.String())
+
+	l.SetLogLevel(zapcore.DebugLevel)
+	assert.Equal(t, logger.Info, l.logLevel())
+	l.Trace(context.Background(), time.Now(), query, nil)
+	assert.Contains(t, buf.String(), `"sql":"SELECT 1"`)
+
+	l.SetLogLevel(zapcore.WarnLevel)
+	assert.Equal(t, logger.Warn, l.logLevel())
+
+	l.ResetLogLevel()
+	assert.Equal(t, logger.Error, l.logLevel())
+}
@@ -2,11 +2,12 @@ package db
 
 import (
 	"go-clean-api/pkg"
+	"go-clean-api/pkg/logfile"
 	"io"
-	"log"
 	"os"
-	"path"
 
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -16,9 +17,12 @@ import (
 type GormMySQL struct {
 	DB     *gorm.DB
 	config *pkg.Config
+	logger *GormZapLogger
 }
 
-func NewGormMySQL(config *pkg.Config) (*GormMySQL, error) {
+// NewGormMySQL opens a MySQL database connection with GORM.
+// The log options customize the zap logger of the queries.
+func NewGormMySQL(config *pkg.Config, logOptions ...zap.Option) (*GormMySQL, error) {
 	dsn, err := config.Database.DSN()
 	if err != nil {
 		return nil, err
@@ -31,23 +35,13 @@ func NewGormMySQL(config *pkg.Config) (*GormMySQL, error) {
 	// GORM logger configuration
 	env := config.AppEnv
 	level := getGormLogLevel(config.Gorm.LogLevel, env)
-	output, err := getGormLogOutput(config.Gorm.LogOutput, config.Gorm.LogFileName, env)
+	output, err := getGormLogOutput(config.Gorm.LogOutput, config.Gorm.LogFileName, env, config.Log)
 	if err != nil {
 		return nil, err
 	}
 
 	// Logger
-	// TODO: Add a custom logger for GORM like https://www.soberkoder.com/go-gorm-logging/
-	// Or try something like this: https://github.com/moul/zapgorm2
-	customLogger := logger.New(
-		log.New(output, "\r\n", log.LstdFlags), // io writer
-		logger.Config{
-			SlowThreshold:             config.Gorm.SlowThreshold, // Slow SQL threshold (Default: 200ms)
-			LogLevel:                  level,                     // Log level (Silent, Error, Warn, Info) (Default: Warn)
-			IgnoreRecordNotFoundError: true,                      // Ignore ErrRecordNotFound error for logger (Default: false)
-			Colorful:                  true,                      // Disable color (Default: true)
-		},
-	)
+	customLogger := NewGormZapLogger(output, level, config.Gorm.SlowThreshold, logOptions...)
 
 	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
 		Logger: customLogger,
@@ -74,6 +68,7 @@ func NewGormMySQL(config *pkg.Config) (*GormMySQL, error) {
 	return &GormMySQL{
 		DB:     db,
 		config: config,
+		logger: customLogger,
 	}, nil
 }
 
@@ -85,6 +80,21 @@ func (m *GormMySQL) Database(d string) {
 	m.config.Database.Database = d
 }
 
+// TransactionMaxRetries returns the max number of retries of a transaction after a deadlock
+func (m *GormMySQL) TransactionMaxRetries() int {
+	return transactionMaxRetries(m.config.Database.TransactionMaxRetries)
+}
+
+// SetLogLevel changes the GORM log level from the application log level
+func (m *GormMySQL) SetLogLevel(level zapcore.Level) {
+	m.logger.SetLogLevel(level)
+}
+
+// ResetLogLevel restores the configured GORM log level
+func (m *GormMySQL) ResetLogLevel() {
+	m.logger.ResetLogLevel()
+}
+
 // getGormLogLevel returns the log level for GORM.
 // If APP_ENV is development, the default log level is info,
 // warn in other case.
@@ -109,14 +119,15 @@ func getGormLogLevel(level, env string) logger.LogLevel {
 // getGormLogOutput returns GORM log output.
 // The default value is os.Stdout.
 // In development mode, the ouput is set to os.Stdout.
-func getGormLogOutput(output, filePath, env string) (file io.Writer, err error) {
+// The log file is rotated and its writes are buffered according to the log configuration.
+func getGormLogOutput(output, filePath, env string, logConfig pkg.ConfigLog) (file io.Writer, err error) {
 	if env == "development" {
 		return os.Stdout, nil
 	}
 
 	switch output {
 	case "file":
-		f, err := os.OpenFile(path.Clean(filePath), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0666)
+		f, err := logfile.Open(filePath, logConfig)
 		if err != nil {
 			return nil, err
 		}
@@ -43,3 +43,8 @@ func (m *SqlxMySQL) DSN() (string, error) {
 func (m *SqlxMySQL) Database(d string) {
 	m.config.Database.Database = d
 }
+
+// TransactionMaxRetries returns the max number of retries of a transaction after a deadlock
+func (m *SqlxMySQL) TransactionMaxRetries() int {
+	return transactionMaxRetries(m.config.Database.TransactionMaxRetries)
+}
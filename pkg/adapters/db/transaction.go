@@ -0,0 +1,53 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+const (
+	// DefaultTransactionMaxRetries is the default number of retries of a transaction after a deadlock
+	DefaultTransactionMaxRetries = 3
+
+	// DefaultTransactionRetryDelay is the base delay between two attempts of a transaction
+	DefaultTransactionRetryDelay = 20 * time.Millisecond
+)
+
+// MySQL error numbers for which a transaction can safely be retried
+const (
+	mysqlErrLockWaitTimeout uint16 = 1205
+	mysqlErrLockDeadlock    uint16 = 1213
+)
+
+// IsRetryableTransactionError returns true if the error is a MySQL deadlock or lock wait timeout.
+func IsRetryableTransactionError(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlErrLockDeadlock || mysqlErr.Number == mysqlErrLockWaitTimeout
+	}
+	return false
+}
+
+// transactionMaxRetries returns the configured max retries or the default value if it is not set.
+func transactionMaxRetries(n int) int {
+	if n <= 0 {
+		return DefaultTransactionMaxRetries
+	}
+	return n
+}
+
+// RetryTransaction runs fn and runs it again (at most maxRetries times)
+// while it returns a retryable transaction error.
+// The delay between two attempts grows linearly with the number of attempts.
+func RetryTransaction(maxRetries int, fn func() error) (err error) {
+	for attempt := 0; ; attempt++ {
+		err = fn()
+		if err == nil || attempt >= maxRetries || !IsRetryableTransactionError(err) {
+			return
+		}
+
+		time.Sleep(time.Duration(attempt+1) * DefaultTransactionRetryDelay)
+	}
+}
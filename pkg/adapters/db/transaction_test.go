@@ -0,0 +1,90 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRetryableTransactionError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		wanted bool
+	}{
+		{
+			name:   "Nil error",
+			err:    nil,
+			wanted: false,
+		},
+		{
+			name:   "Deadlock",
+			err:    &mysql.MySQLError{Number: 1213},
+			wanted: true,
+		},
+		{
+			name:   "Lock wait timeout",
+			err:    &mysql.MySQLError{Number: 1205},
+			wanted: true,
+		},
+		{
+			name:   "Wrapped deadlock",
+			err:    fmt.Errorf("[repository %w: %w]", errors.New("database error"), &mysql.MySQLError{Number: 1213}),
+			wanted: true,
+		},
+		{
+			name:   "Duplicate entry",
+			err:    &mysql.MySQLError{Number: 1062},
+			wanted: false,
+		},
+		{
+			name:   "Other error",
+			err:    errors.New("other error"),
+			wanted: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wanted, IsRetryableTransactionError(tt.err))
+		})
+	}
+}
+
+func TestRetryTransaction(t *testing.T) {
+	deadlock := &mysql.MySQLError{Number: 1213}
+
+	// Success after two deadlocks
+	calls := 0
+	err := RetryTransaction(3, func() error {
+		calls++
+		if calls < 3 {
+			return deadlock
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, calls)
+
+	// Too many deadlocks
+	calls = 0
+	err = RetryTransaction(2, func() error {
+		calls++
+		return deadlock
+	})
+	assert.ErrorIs(t, err, deadlock)
+	assert.Equal(t, 3, calls)
+
+	// Not retryable error
+	calls = 0
+	errOther := errors.New("other error")
+	err = RetryTransaction(3, func() error {
+		calls++
+		return errOther
+	})
+	assert.ErrorIs(t, err, errOther)
+	assert.Equal(t, 1, calls)
+}
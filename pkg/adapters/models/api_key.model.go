@@ -0,0 +1,115 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-clean-api/pkg/domain/entities"
+	vo "go-clean-api/pkg/domain/value_objects"
+)
+
+// APIKey is the data transfer object for the APIKey entity
+type APIKey struct {
+	ID         string  `db:"id"`
+	UserID     string  `db:"user_id"`
+	Name       string  `db:"name"`
+	Prefix     string  `db:"prefix"`
+	Hash       string  `db:"hash"`
+	Scopes     string  `db:"scopes"`       // JSON array of scopes
+	ExpiresAt  *string `db:"expires_at"`   // Format YYYY-MM-DD HH:MM:SS
+	LastUsedAt *string `db:"last_used_at"` // Format YYYY-MM-DD HH:MM:SS
+	CreatedAt  string  `db:"created_at"`   // Format YYYY-MM-DD HH:MM:SS
+	RevokedAt  *string `db:"revoked_at"`   // Format YYYY-MM-DD HH:MM:SS
+}
+
+// Entity converts the API key model to entity
+func (k APIKey) Entity() (apiKey entities.APIKey, err error) {
+	id, errID := vo.NewIDFrom(k.ID)
+	if errID != nil {
+		err = fmt.Errorf("[models:APIKey:Entity %w: %s]", ErrIDFromString, errID)
+		return
+	}
+
+	userID, errID := vo.NewIDFrom(k.UserID)
+	if errID != nil {
+		err = fmt.Errorf("[models:APIKey:Entity %w: %s]", ErrIDFromString, errID)
+		return
+	}
+
+	var scopes []string
+	if errScopes := json.Unmarshal([]byte(k.Scopes), &scopes); errScopes != nil {
+		err = fmt.Errorf("[models:APIKey:Entity %w: %s]", ErrJSONFromString, errScopes)
+		return
+	}
+
+	createdAt, errDateTime := vo.ParseRFC3339(k.CreatedAt, nil)
+	if errDateTime != nil {
+		err = fmt.Errorf("[models:APIKey:Entity %w: %s]", ErrParseDateTime, errDateTime)
+		return
+	}
+
+	times := make([]*vo.Time, 3)
+	for i, t := range []*string{k.ExpiresAt, k.LastUsedAt, k.RevokedAt} {
+		times[i], errDateTime = parseNullableTime(t)
+		if errDateTime != nil {
+			err = fmt.Errorf("[models:APIKey:Entity %w: %s]", ErrParseDateTime, errDateTime)
+			return
+		}
+	}
+
+	apiKey = entities.APIKey{
+		ID:         id,
+		UserID:     userID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Hash:       k.Hash,
+		Scopes:     scopes,
+		ExpiresAt:  times[0],
+		LastUsedAt: times[1],
+		CreatedAt:  createdAt,
+		RevokedAt:  times[2],
+	}
+
+	return
+}
+
+// APIKeyInsertValues returns the values to insert an API key in this order:
+// id, user_id, name, prefix, hash, scopes, expires_at, created_at
+func APIKeyInsertValues(k entities.APIKey) ([]any, error) {
+	scopes := k.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+	scopesJSON, err := json.Marshal(scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	var expiresAt any
+	if k.ExpiresAt != nil {
+		expiresAt = k.ExpiresAt.SQL()
+	}
+
+	return []any{
+		k.ID.String(),
+		k.UserID.String(),
+		k.Name,
+		k.Prefix,
+		k.Hash,
+		string(scopesJSON),
+		expiresAt,
+		k.CreatedAt.SQL(),
+	}, nil
+}
+
+// parseNullableTime parses a nullable RFC3339 date time
+func parseNullableTime(s *string) (*vo.Time, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	t, err := vo.ParseRFC3339(*s, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,135 @@
+package models
+
+import (
+	"fmt"
+	"go-clean-api/pkg/domain/entities"
+	"go-clean-api/pkg/domain/repositories"
+	vo "go-clean-api/pkg/domain/value_objects"
+	"strings"
+)
+
+// AuditLog is the data transfer object for the AuditLog entity
+type AuditLog struct {
+	ID          string  `db:"id"`
+	ActorID     *string `db:"actor_id"`
+	Action      string  `db:"action"`
+	TargetType  string  `db:"target_type"`
+	TargetID    string  `db:"target_id"`
+	BeforeState *string `db:"before_state"`
+	AfterState  *string `db:"after_state"`
+	IP          string  `db:"ip" gorm:"column:ip"`
+	RequestID   string  `db:"request_id"`
+	CreatedAt   string  `db:"created_at"` // Format YYYY-MM-DD HH:MM:SS
+}
+
+// Entity converts the audit log model to entity
+func (a AuditLog) Entity() (auditLog entities.AuditLog, err error) {
+	id, errID := vo.NewIDFrom(a.ID)
+	if errID != nil {
+		err = fmt.Errorf("[models:AuditLog:Entity %w: %s]", ErrIDFromString, errID)
+		return
+	}
+
+	createdAt, errDateTime := vo.ParseRFC3339(a.CreatedAt, nil)
+	if errDateTime != nil {
+		err = fmt.Errorf("[models:AuditLog:Entity %w: %s]", ErrParseDateTime, errDateTime)
+		return
+	}
+
+	actorID := ""
+	if a.ActorID != nil {
+		actorID = *a.ActorID
+	}
+
+	var before, after []byte
+	if a.BeforeState != nil {
+		before = []byte(*a.BeforeState)
+	}
+	if a.AfterState != nil {
+		after = []byte(*a.AfterState)
+	}
+
+	auditLog = entities.AuditLog{
+		ID:         id,
+		ActorID:    actorID,
+		Action:     entities.AuditAction(a.Action),
+		TargetType: entities.AuditTargetType(a.TargetType),
+		TargetID:   a.TargetID,
+		Before:     before,
+		After:      after,
+		IP:         a.IP,
+		RequestID:  a.RequestID,
+		CreatedAt:  createdAt,
+	}
+
+	return
+}
+
+// AuditLogInsertValues returns the values to insert an audit log in this order:
+// id, actor_id, action, target_type, target_id, before_state, after_state, ip, request_id, created_at
+func AuditLogInsertValues(a entities.AuditLog) []any {
+	return []any{
+		a.ID.String(),
+		nullableString(a.ActorID),
+		string(a.Action),
+		string(a.TargetType),
+		a.TargetID,
+		nullableBytes(a.Before),
+		nullableBytes(a.After),
+		a.IP,
+		a.RequestID,
+		a.CreatedAt.SQL(),
+	}
+}
+
+// AuditLogWhere returns the WHERE clause (with placeholders) and its arguments for the audit log filters.
+func AuditLogWhere(f repositories.AuditLogFilters) (string, []any) {
+	conditions := make([]string, 0)
+	args := make([]any, 0)
+
+	if f.ActorID != "" {
+		conditions = append(conditions, "actor_id = ?")
+		args = append(args, f.ActorID)
+	}
+	if f.Action != "" {
+		conditions = append(conditions, "action = ?")
+		args = append(args, string(f.Action))
+	}
+	if f.TargetType != "" {
+		conditions = append(conditions, "target_type = ?")
+		args = append(args, string(f.TargetType))
+	}
+	if f.TargetID != "" {
+		conditions = append(conditions, "target_id = ?")
+		args = append(args, f.TargetID)
+	}
+	if f.From != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, f.From.SQL())
+	}
+	if f.To != nil {
+		conditions = append(conditions, "created_at <= ?")
+		args = append(args, f.To.SQL())
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// nullableString returns nil for an empty string
+func nullableString(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// nullableBytes returns nil for an empty slice
+func nullableBytes(b []byte) any {
+	if len(b) == 0 {
+		return nil
+	}
+	return string(b)
+}
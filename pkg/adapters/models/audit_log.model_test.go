@@ -0,0 +1,57 @@
+package models
+
+import (
+	"go-clean-api/pkg/domain/entities"
+	"go-clean-api/pkg/domain/repositories"
+	vo "go-clean-api/pkg/domain/value_objects"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditLogWhere(t *testing.T) {
+	from := vo.NewTime(time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC), nil)
+
+	tests := []struct {
+		name       string
+		filters    repositories.AuditLogFilters
+		wantedSQL  string
+		wantedArgs []any
+	}{
+		{
+			name:       "No filter",
+			filters:    repositories.AuditLogFilters{},
+			wantedSQL:  "",
+			wantedArgs: []any{},
+		},
+		{
+			name: "Actor and action",
+			filters: repositories.AuditLogFilters{
+				ActorID: "f47ac10b-58cc-0372-8562-0b8e853961a1",
+				Action:  entities.AuditActionUserDeleted,
+			},
+			wantedSQL:  " WHERE actor_id = ? AND action = ?",
+			wantedArgs: []any{"f47ac10b-58cc-0372-8562-0b8e853961a1", "user.deleted"},
+		},
+		{
+			name: "Target and date",
+			filters: repositories.AuditLogFilters{
+				TargetType: entities.AuditTargetUser,
+				TargetID:   "f47ac10b-58cc-0372-8562-0b8e853961a1",
+				From:       &from,
+			},
+			wantedSQL:  " WHERE target_type = ? AND target_id = ? AND created_at >= ?",
+			wantedArgs: []any{"user", "f47ac10b-58cc-0372-8562-0b8e853961a1", "2025-03-10 08:00:00"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := AuditLogWhere(tt.filters)
+
+			assert.Equal(t, tt.wantedSQL, sql)
+			assert.Equal(t, tt.wantedArgs, args)
+		})
+	}
+}
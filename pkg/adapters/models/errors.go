@@ -8,4 +8,5 @@ var (
 	ErrPasswordFromString = errors.New("error when a new password from a string")
 	ErrEmailFromString    = errors.New("error when a new email from a string")
 	ErrParseDateTime      = errors.New("error when parsing date time")
+	ErrJSONFromString     = errors.New("error when decoding JSON from a string")
 )
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-clean-api/pkg/domain/entities"
+	vo "go-clean-api/pkg/domain/value_objects"
+)
+
+// IdempotencyKey is the data transfer object for the IdempotencyKey entity
+type IdempotencyKey struct {
+	Key             string  `db:"key"`
+	Fingerprint     string  `db:"fingerprint"`
+	Status          string  `db:"status"`
+	ResponseStatus  int     `db:"response_status"`
+	ResponseHeaders *string `db:"response_headers"` // JSON object of headers
+	ResponseBody    []byte  `db:"response_body"`
+	CreatedAt       string  `db:"created_at"` // Format YYYY-MM-DD HH:MM:SS
+	ExpiresAt       string  `db:"expires_at"` // Format YYYY-MM-DD HH:MM:SS
+}
+
+// Entity converts the idempotency key model to entity
+func (k IdempotencyKey) Entity() (key entities.IdempotencyKey, err error) {
+	var headers map[string][]string
+	if k.ResponseHeaders != nil {
+		if errHeaders := json.Unmarshal([]byte(*k.ResponseHeaders), &headers); errHeaders != nil {
+			err = fmt.Errorf("[models:IdempotencyKey:Entity %w: %s]", ErrJSONFromString, errHeaders)
+			return
+		}
+	}
+
+	createdAt, errDateTime := vo.ParseRFC3339(k.CreatedAt, nil)
+	if errDateTime != nil {
+		err = fmt.Errorf("[models:IdempotencyKey:Entity %w: %s]", ErrParseDateTime, errDateTime)
+		return
+	}
+
+	expiresAt, errDateTime := vo.ParseRFC3339(k.ExpiresAt, nil)
+	if errDateTime != nil {
+		err = fmt.Errorf("[models:IdempotencyKey:Entity %w: %s]", ErrParseDateTime, errDateTime)
+		return
+	}
+
+	key = entities.IdempotencyKey{
+		Key:             k.Key,
+		Fingerprint:     k.Fingerprint,
+		Status:          k.Status,
+		ResponseStatus:  k.ResponseStatus,
+		ResponseHeaders: headers,
+		ResponseBody:    k.ResponseBody,
+		CreatedAt:       createdAt,
+		ExpiresAt:       expiresAt,
+	}
+
+	return
+}
+
+// IdempotencyKeyResponseHeaders returns the response headers encoded in JSON
+func IdempotencyKeyResponseHeaders(headers map[string][]string) (string, error) {
+	if headers == nil {
+		headers = map[string][]string{}
+	}
+	data, err := json.Marshal(headers)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
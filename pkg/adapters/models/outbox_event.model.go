@@ -0,0 +1,60 @@
+package models
+
+import (
+	"fmt"
+	"go-clean-api/pkg/domain/entities"
+	vo "go-clean-api/pkg/domain/value_objects"
+)
+
+// OutboxEvent is the data transfer object for the OutboxEvent entity
+type OutboxEvent struct {
+	ID            string  `db:"id"`
+	Name          string  `db:"name"`
+	AggregateID   string  `db:"aggregate_id"`
+	Payload       string  `db:"payload"`
+	RequestID     string  `db:"request_id"`
+	OccurredAt    string  `db:"occurred_at"` // Format YYYY-MM-DD HH:MM:SS
+	Attempts      int     `db:"attempts"`
+	LastError     *string `db:"last_error"`
+	NextAttemptAt string  `db:"next_attempt_at"` // Format YYYY-MM-DD HH:MM:SS
+}
+
+// Entity converts the outbox event model to entity
+func (o OutboxEvent) Entity() (event entities.OutboxEvent, err error) {
+	id, errID := vo.NewIDFrom(o.ID)
+	if errID != nil {
+		err = fmt.Errorf("[models:OutboxEvent:Entity %w: %s]", ErrIDFromString, errID)
+		return
+	}
+
+	occurredAt, errDateTime := vo.ParseRFC3339(o.OccurredAt, nil)
+	if errDateTime != nil {
+		err = fmt.Errorf("[models:OutboxEvent:Entity %w: %s]", ErrParseDateTime, errDateTime)
+		return
+	}
+
+	nextAttemptAt, errDateTime := vo.ParseRFC3339(o.NextAttemptAt, nil)
+	if errDateTime != nil {
+		err = fmt.Errorf("[models:OutboxEvent:Entity %w: %s]", ErrParseDateTime, errDateTime)
+		return
+	}
+
+	lastError := ""
+	if o.LastError != nil {
+		lastError = *o.LastError
+	}
+
+	event = entities.OutboxEvent{
+		ID:            id,
+		Name:          o.Name,
+		AggregateID:   o.AggregateID,
+		Payload:       []byte(o.Payload),
+		RequestID:     o.RequestID,
+		OccurredAt:    occurredAt,
+		Attempts:      o.Attempts,
+		LastError:     lastError,
+		NextAttemptAt: nextAttemptAt,
+	}
+
+	return
+}
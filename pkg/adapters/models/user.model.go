@@ -40,6 +40,7 @@ type User struct {
 	CreatedAt string  `db:"created_at"` // Format YYYY-MM-DD HH:MM:SS
 	UpdatedAt string  `db:"updated_at"` // Format YYYY-MM-DD HH:MM:SS
 	DeletedAt *string `db:"deleted_at"` // Format YYYY-MM-DD HH:MM:SS
+	Version   int     `db:"version"`
 }
 
 // Entity converts the user model to entity
@@ -87,6 +88,7 @@ func (u User) Entity() (user entities.User, err error) {
 		CreatedAt: createdAt,
 		UpdatedAt: updatedAt,
 		DeletedAt: deletedAt,
+		Version:   u.Version,
 	}
 
 	return
@@ -0,0 +1,181 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-clean-api/pkg/domain/entities"
+	vo "go-clean-api/pkg/domain/value_objects"
+	"time"
+)
+
+// Webhook is the data transfer object for the Webhook entity
+type Webhook struct {
+	ID                  string  `db:"id"`
+	URL                 string  `db:"url"`
+	Secret              string  `db:"secret"`
+	Events              string  `db:"events"` // JSON array of event names
+	Enabled             bool    `db:"enabled"`
+	ConsecutiveFailures int     `db:"consecutive_failures"`
+	CreatedAt           string  `db:"created_at"`  // Format YYYY-MM-DD HH:MM:SS
+	UpdatedAt           string  `db:"updated_at"`  // Format YYYY-MM-DD HH:MM:SS
+	DisabledAt          *string `db:"disabled_at"` // Format YYYY-MM-DD HH:MM:SS
+}
+
+// Entity converts the webhook model to entity
+func (w Webhook) Entity() (webhook entities.Webhook, err error) {
+	id, errID := vo.NewIDFrom(w.ID)
+	if errID != nil {
+		err = fmt.Errorf("[models:Webhook:Entity %w: %s]", ErrIDFromString, errID)
+		return
+	}
+
+	var events []string
+	if errEvents := json.Unmarshal([]byte(w.Events), &events); errEvents != nil {
+		err = fmt.Errorf("[models:Webhook:Entity %w: %s]", ErrJSONFromString, errEvents)
+		return
+	}
+
+	createdAt, errDateTime := vo.ParseRFC3339(w.CreatedAt, nil)
+	if errDateTime != nil {
+		err = fmt.Errorf("[models:Webhook:Entity %w: %s]", ErrParseDateTime, errDateTime)
+		return
+	}
+
+	updatedAt, errDateTime := vo.ParseRFC3339(w.UpdatedAt, nil)
+	if errDateTime != nil {
+		err = fmt.Errorf("[models:Webhook:Entity %w: %s]", ErrParseDateTime, errDateTime)
+		return
+	}
+
+	var disabledAt *vo.Time
+	if w.DisabledAt != nil {
+		t, errDateTime := vo.ParseRFC3339(*w.DisabledAt, nil)
+		if errDateTime != nil {
+			err = fmt.Errorf("[models:Webhook:Entity %w: %s]", ErrParseDateTime, errDateTime)
+			return
+		}
+		disabledAt = &t
+	}
+
+	webhook = entities.Webhook{
+		ID:                  id,
+		URL:                 w.URL,
+		Secret:              w.Secret,
+		Events:              events,
+		Enabled:             w.Enabled,
+		ConsecutiveFailures: w.ConsecutiveFailures,
+		CreatedAt:           createdAt,
+		UpdatedAt:           updatedAt,
+		DisabledAt:          disabledAt,
+	}
+
+	return
+}
+
+// WebhookInsertValues returns the values to insert a webhook in this order:
+// id, url, secret, events, enabled, created_at, updated_at
+func WebhookInsertValues(w entities.Webhook) ([]any, error) {
+	events, err := json.Marshal(w.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	return []any{
+		w.ID.String(),
+		w.URL,
+		w.Secret,
+		string(events),
+		w.Enabled,
+		w.CreatedAt.SQL(),
+		w.UpdatedAt.SQL(),
+	}, nil
+}
+
+// WebhookDelivery is the data transfer object for the WebhookDelivery entity
+type WebhookDelivery struct {
+	ID            string  `db:"id"`
+	WebhookID     string  `db:"webhook_id"`
+	EventID       string  `db:"event_id"`
+	EventName     string  `db:"event_name"`
+	Payload       string  `db:"payload"`
+	Attempt       int     `db:"attempt"`
+	StatusCode    int     `db:"status_code"`
+	Success       bool    `db:"success"`
+	Error         *string `db:"error"`
+	DurationMs    int64   `db:"duration_ms"`
+	CreatedAt     string  `db:"created_at"`      // Format YYYY-MM-DD HH:MM:SS
+	NextAttemptAt *string `db:"next_attempt_at"` // Format YYYY-MM-DD HH:MM:SS
+}
+
+// Entity converts the webhook delivery model to entity
+func (d WebhookDelivery) Entity() (delivery entities.WebhookDelivery, err error) {
+	id, errID := vo.NewIDFrom(d.ID)
+	if errID != nil {
+		err = fmt.Errorf("[models:WebhookDelivery:Entity %w: %s]", ErrIDFromString, errID)
+		return
+	}
+
+	webhookID, errID := vo.NewIDFrom(d.WebhookID)
+	if errID != nil {
+		err = fmt.Errorf("[models:WebhookDelivery:Entity %w: %s]", ErrIDFromString, errID)
+		return
+	}
+
+	createdAt, errDateTime := vo.ParseRFC3339(d.CreatedAt, nil)
+	if errDateTime != nil {
+		err = fmt.Errorf("[models:WebhookDelivery:Entity %w: %s]", ErrParseDateTime, errDateTime)
+		return
+	}
+
+	nextAttemptAt, errDateTime := parseNullableTime(d.NextAttemptAt)
+	if errDateTime != nil {
+		err = fmt.Errorf("[models:WebhookDelivery:Entity %w: %s]", ErrParseDateTime, errDateTime)
+		return
+	}
+
+	deliveryError := ""
+	if d.Error != nil {
+		deliveryError = *d.Error
+	}
+
+	delivery = entities.WebhookDelivery{
+		ID:            id,
+		WebhookID:     webhookID,
+		EventID:       d.EventID,
+		EventName:     d.EventName,
+		Payload:       []byte(d.Payload),
+		Attempt:       d.Attempt,
+		StatusCode:    d.StatusCode,
+		Success:       d.Success,
+		Error:         deliveryError,
+		Duration:      time.Duration(d.DurationMs) * time.Millisecond,
+		CreatedAt:     createdAt,
+		NextAttemptAt: nextAttemptAt,
+	}
+
+	return
+}
+
+// WebhookDeliveryInsertValues returns the values to insert a webhook delivery in this order:
+// id, webhook_id, event_id, event_name, payload, attempt, status_code, success, error, duration_ms, created_at, next_attempt_at
+func WebhookDeliveryInsertValues(d entities.WebhookDelivery) []any {
+	var nextAttemptAt any
+	if d.NextAttemptAt != nil {
+		nextAttemptAt = d.NextAttemptAt.SQL()
+	}
+
+	return []any{
+		d.ID.String(),
+		d.WebhookID.String(),
+		d.EventID,
+		d.EventName,
+		string(d.Payload),
+		d.Attempt,
+		d.StatusCode,
+		d.Success,
+		nullableString(d.Error),
+		d.Duration.Milliseconds(),
+		d.CreatedAt.SQL(),
+		nextAttemptAt,
+	}
+}
@@ -0,0 +1,147 @@
+package gorm_mysql
+
+import (
+	"fmt"
+	"go-clean-api/pkg/adapters/db"
+	"go-clean-api/pkg/adapters/models"
+	"go-clean-api/pkg/domain/entities"
+	domainerr "go-clean-api/pkg/domain/errors"
+	"go-clean-api/pkg/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// APIKey is an implementation of the APIKeyRepository interface
+type APIKey struct {
+	db *gorm.DB
+}
+
+// NewAPIKey creates a new APIKeyMysqlRepository
+func NewAPIKey(db *db.GormMySQL) *APIKey {
+	return &APIKey{db: db.DB}
+}
+
+func (k *APIKey) Create(req repositories.CreateAPIKeyRequest) (res repositories.CreateAPIKeyResponse, err error) {
+	values, err := models.APIKeyInsertValues(req.APIKey)
+	if err != nil {
+		return res, fmt.Errorf("[api_key_gorm_mysql:Create %w: %w]", repositories.ErrCreatingAPIKey, err)
+	}
+
+	result := k.db.Exec(`
+		INSERT INTO api_keys (id, user_id, name, prefix, hash, scopes, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		values...,
+	)
+	if result.Error != nil {
+		return res, fmt.Errorf("[api_key_gorm_mysql:Create %w: %w]", repositories.ErrCreatingAPIKey, result.Error)
+	}
+
+	return
+}
+
+func (k *APIKey) GetByPrefix(req repositories.GetAPIKeyByPrefixRequest) (res repositories.GetAPIKeyByPrefixResponse, err error) {
+	var model models.APIKey
+	result := k.db.Raw(`
+		SELECT id, user_id, name, prefix, hash, scopes, expires_at, last_used_at, created_at, revoked_at
+		FROM api_keys
+		WHERE prefix = ?
+		LIMIT 1`,
+		req.Prefix,
+	).Scan(&model)
+	if result.Error != nil {
+		return res, fmt.Errorf("[api_key_gorm_mysql:GetByPrefix %w: %w]", domainerr.ErrNotFound, result.Error)
+	} else if result.RowsAffected == 0 {
+		return res, fmt.Errorf("[api_key_gorm_mysql:GetByPrefix %w]", domainerr.ErrNotFound)
+	}
+
+	apiKey, err := model.Entity()
+	if err != nil {
+		return res, fmt.Errorf("[api_key_gorm_mysql:GetByPrefix %w: %w]", repositories.ErrGettingAPIKeys, err)
+	}
+	res.APIKey = apiKey
+
+	return
+}
+
+func (k *APIKey) GetAllByUser(req repositories.GetAllAPIKeysByUserRequest) (res repositories.GetAllAPIKeysByUserResponse, err error) {
+	offset, limit := db.PaginateValues(req.Pagination.Page(), req.Pagination.Size())
+
+	var apiKeys []models.APIKey
+	result := k.db.Raw(`
+		SELECT id, user_id, name, prefix, hash, scopes, expires_at, last_used_at, created_at, revoked_at
+		FROM api_keys
+		WHERE user_id = ?
+		ORDER BY created_at DESC
+		LIMIT ? OFFSET ?`,
+		req.UserID.String(),
+		limit,
+		offset,
+	).Scan(&apiKeys)
+	if result.Error != nil {
+		return res, fmt.Errorf("[api_key_gorm_mysql:GetAllByUser %w: %w]", repositories.ErrGettingAPIKeys, result.Error)
+	}
+
+	apiKeysEntity := make([]entities.APIKey, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		apiKeyEntity, err := apiKey.Entity()
+		if err != nil {
+			return res, fmt.Errorf("[api_key_gorm_mysql:GetAllByUser %w: %w]", repositories.ErrGettingAPIKeys, err)
+		}
+		apiKeysEntity = append(apiKeysEntity, apiKeyEntity)
+	}
+	res.APIKeys = apiKeysEntity
+
+	return
+}
+
+func (k *APIKey) CountAllByUser(req repositories.CountAllAPIKeysByUserRequest) (repositories.CountAllAPIKeysByUserResponse, error) {
+	var count int64
+	result := k.db.Raw(`
+		SELECT COUNT(id) AS total
+		FROM api_keys
+		WHERE user_id = ?`,
+		req.UserID.String(),
+	).Scan(&count)
+	if result.Error != nil {
+		return repositories.CountAllAPIKeysByUserResponse{}, fmt.Errorf("[api_key_gorm_mysql:CountAllByUser %w: %w]", repositories.ErrGettingAPIKeys, result.Error)
+	}
+
+	return repositories.CountAllAPIKeysByUserResponse{Total: count}, nil
+}
+
+func (k *APIKey) Revoke(req repositories.RevokeAPIKeyRequest) (res repositories.RevokeAPIKeyResponse, err error) {
+	result := k.db.Exec(`
+		UPDATE api_keys
+		SET revoked_at = ?
+		WHERE id = ?
+			AND user_id = ?
+			AND revoked_at IS NULL`,
+		req.RevokedAt.SQL(),
+		req.ID.String(),
+		req.UserID.String(),
+	)
+	if result.Error != nil {
+		return res, fmt.Errorf("[api_key_gorm_mysql:Revoke %w: %w]", domainerr.ErrDatabase, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return res, fmt.Errorf("[api_key_gorm_mysql:Revoke %w]", domainerr.ErrNotFound)
+	}
+
+	return
+}
+
+func (k *APIKey) UpdateLastUsed(req repositories.UpdateAPIKeyLastUsedRequest) (res repositories.UpdateAPIKeyLastUsedResponse, err error) {
+	result := k.db.Exec(`
+		UPDATE api_keys
+		SET last_used_at = ?
+		WHERE id = ?`,
+		req.LastUsedAt.SQL(),
+		req.ID.String(),
+	)
+	if result.Error != nil {
+		return res, fmt.Errorf("[api_key_gorm_mysql:UpdateLastUsed %w: %w]", repositories.ErrUpdatingAPIKey, result.Error)
+	}
+
+	return
+}
@@ -0,0 +1,77 @@
+package gorm_mysql
+
+import (
+	"fmt"
+	"go-clean-api/pkg/adapters/db"
+	"go-clean-api/pkg/adapters/models"
+	"go-clean-api/pkg/domain/entities"
+	"go-clean-api/pkg/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// AuditLog is an implementation of the AuditLogRepository interface
+type AuditLog struct {
+	db *gorm.DB
+}
+
+// NewAuditLog creates a new AuditLogMysqlRepository
+func NewAuditLog(db *db.GormMySQL) *AuditLog {
+	return &AuditLog{db: db.DB}
+}
+
+func (a *AuditLog) Create(req repositories.CreateAuditLogRequest) (res repositories.CreateAuditLogResponse, err error) {
+	result := a.db.Exec(`
+		INSERT INTO audit_logs (id, actor_id, action, target_type, target_id, before_state, after_state, ip, request_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		models.AuditLogInsertValues(req.AuditLog)...,
+	)
+	if result.Error != nil {
+		return res, fmt.Errorf("[audit_log_gorm_mysql:Create %w: %w]", repositories.ErrCreatingAuditLog, result.Error)
+	}
+
+	return
+}
+
+func (a *AuditLog) CountAll(req repositories.CountAllAuditLogsRequest) (repositories.CountAllAuditLogsResponse, error) {
+	where, args := models.AuditLogWhere(req.Filters)
+	q := `
+		SELECT COUNT(id) AS total
+		FROM audit_logs` + where
+
+	var count int64
+	if result := a.db.Raw(q, args...).Scan(&count); result.Error != nil {
+		return repositories.CountAllAuditLogsResponse{}, fmt.Errorf("[audit_log_gorm_mysql:CountAll %w: %w]", repositories.ErrCountingAuditLogs, result.Error)
+	}
+
+	return repositories.CountAllAuditLogsResponse{Total: count}, nil
+}
+
+func (a *AuditLog) GetAll(req repositories.GetAllAuditLogsRequest) (res repositories.GetAllAuditLogsResponse, err error) {
+	where, args := models.AuditLogWhere(req.Filters)
+	q := `
+		SELECT id, actor_id, action, target_type, target_id, before_state, after_state, ip, request_id, created_at
+		FROM audit_logs` + where + `
+		ORDER BY created_at DESC
+		LIMIT ? OFFSET ?`
+
+	offset, limit := db.PaginateValues(req.Pagination.Page(), req.Pagination.Size())
+	args = append(args, limit, offset)
+
+	var auditLogs []models.AuditLog
+	if result := a.db.Raw(q, args...).Scan(&auditLogs); result.Error != nil {
+		return res, fmt.Errorf("[audit_log_gorm_mysql:GetAll %w: %w]", repositories.ErrGettingAuditLogs, result.Error)
+	}
+
+	auditLogsEntity := make([]entities.AuditLog, 0, len(auditLogs))
+	for _, auditLog := range auditLogs {
+		auditLogEntity, err := auditLog.Entity()
+		if err != nil {
+			return res, fmt.Errorf("[audit_log_gorm_mysql:GetAll %w: %w]", repositories.ErrGettingAuditLogs, err)
+		}
+		auditLogsEntity = append(auditLogsEntity, auditLogEntity)
+	}
+	res.AuditLogs = auditLogsEntity
+
+	return
+}
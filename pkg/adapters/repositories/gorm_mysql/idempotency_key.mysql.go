@@ -0,0 +1,112 @@
+package gorm_mysql
+
+import (
+	"fmt"
+	"go-clean-api/pkg/adapters/db"
+	"go-clean-api/pkg/adapters/models"
+	domainerr "go-clean-api/pkg/domain/errors"
+	"go-clean-api/pkg/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// IdempotencyKey is an implementation of the IdempotencyKeyRepository interface
+type IdempotencyKey struct {
+	db *gorm.DB
+}
+
+// NewIdempotencyKey creates a new IdempotencyKeyMysqlRepository
+func NewIdempotencyKey(db *db.GormMySQL) *IdempotencyKey {
+	return &IdempotencyKey{db: db.DB}
+}
+
+func (k *IdempotencyKey) Create(req repositories.CreateIdempotencyKeyRequest) (res repositories.CreateIdempotencyKeyResponse, err error) {
+	result := k.db.Exec(`
+		INSERT INTO idempotency_keys (`+"`key`"+`, fingerprint, status, created_at, expires_at)
+		VALUES (?, ?, ?, ?, ?)`,
+		req.Key,
+		req.Fingerprint,
+		req.Status,
+		req.CreatedAt.SQL(),
+		req.ExpiresAt.SQL(),
+	)
+	if result.Error != nil {
+		if db.IsDuplicateEntryError(result.Error) {
+			return res, fmt.Errorf("[idempotency_key_gorm_mysql:Create %w: %w]", domainerr.ErrConflict, result.Error)
+		}
+		return res, fmt.Errorf("[idempotency_key_gorm_mysql:Create %w: %w]", repositories.ErrCreatingIdempotencyKey, result.Error)
+	}
+
+	return
+}
+
+func (k *IdempotencyKey) GetByKey(req repositories.GetIdempotencyKeyRequest) (res repositories.GetIdempotencyKeyResponse, err error) {
+	var model models.IdempotencyKey
+	result := k.db.Raw(`
+		SELECT `+"`key`"+`, fingerprint, status, response_status, response_headers, response_body, created_at, expires_at
+		FROM idempotency_keys
+		WHERE `+"`key`"+` = ?
+		LIMIT 1`,
+		req.Key,
+	).Scan(&model)
+	if result.Error != nil {
+		return res, fmt.Errorf("[idempotency_key_gorm_mysql:GetByKey %w: %w]", repositories.ErrGettingIdempotencyKey, result.Error)
+	} else if result.RowsAffected == 0 {
+		return res, fmt.Errorf("[idempotency_key_gorm_mysql:GetByKey %w]", domainerr.ErrNotFound)
+	}
+
+	key, err := model.Entity()
+	if err != nil {
+		return res, fmt.Errorf("[idempotency_key_gorm_mysql:GetByKey %w: %w]", repositories.ErrGettingIdempotencyKey, err)
+	}
+	res.IdempotencyKey = key
+
+	return
+}
+
+func (k *IdempotencyKey) Complete(req repositories.CompleteIdempotencyKeyRequest) (res repositories.CompleteIdempotencyKeyResponse, err error) {
+	headers, err := models.IdempotencyKeyResponseHeaders(req.ResponseHeaders)
+	if err != nil {
+		return res, fmt.Errorf("[idempotency_key_gorm_mysql:Complete %w: %w]", repositories.ErrUpdatingIdempotencyKey, err)
+	}
+
+	result := k.db.Exec(`
+		UPDATE idempotency_keys
+		SET status = 'completed', response_status = ?, response_headers = ?, response_body = ?
+		WHERE `+"`key`"+` = ?`,
+		req.ResponseStatus,
+		headers,
+		req.ResponseBody,
+		req.Key,
+	)
+	if result.Error != nil {
+		return res, fmt.Errorf("[idempotency_key_gorm_mysql:Complete %w: %w]", repositories.ErrUpdatingIdempotencyKey, result.Error)
+	}
+
+	return
+}
+
+func (k *IdempotencyKey) Delete(req repositories.DeleteIdempotencyKeyRequest) (res repositories.DeleteIdempotencyKeyResponse, err error) {
+	query, args := "DELETE FROM idempotency_keys WHERE `key` = ?", []any{req.Key}
+	if req.CreatedAt != nil {
+		query, args = query+" AND created_at = ?", append(args, req.CreatedAt.SQL())
+	}
+
+	result := k.db.Exec(query, args...)
+	if result.Error != nil {
+		return res, fmt.Errorf("[idempotency_key_gorm_mysql:Delete %w: %w]", repositories.ErrDeletingIdempotencyKeys, result.Error)
+	}
+	res.Deleted = result.RowsAffected
+
+	return
+}
+
+func (k *IdempotencyKey) DeleteExpired(req repositories.DeleteExpiredIdempotencyKeysRequest) (res repositories.DeleteExpiredIdempotencyKeysResponse, err error) {
+	result := k.db.Exec("DELETE FROM idempotency_keys WHERE expires_at <= ?", req.Now.SQL())
+	if result.Error != nil {
+		return res, fmt.Errorf("[idempotency_key_gorm_mysql:DeleteExpired %w: %w]", repositories.ErrDeletingIdempotencyKeys, result.Error)
+	}
+	res.Deleted = result.RowsAffected
+
+	return
+}
@@ -0,0 +1,133 @@
+package gorm_mysql
+
+import (
+	"fmt"
+	"go-clean-api/pkg/adapters/db"
+	"go-clean-api/pkg/adapters/models"
+	"go-clean-api/pkg/domain/entities"
+	"go-clean-api/pkg/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// Outbox is an implementation of the OutboxRepository interface
+type Outbox struct {
+	db *gorm.DB
+}
+
+// NewOutbox creates a new OutboxMysqlRepository
+func NewOutbox(db *db.GormMySQL) *Outbox {
+	return &Outbox{db: db.DB}
+}
+
+func (o *Outbox) Add(req repositories.AddOutboxEventRequest) (res repositories.AddOutboxEventResponse, err error) {
+	result := o.db.Exec(`
+		INSERT INTO outbox_events (id, name, aggregate_id, payload, request_id, occurred_at, attempts, next_attempt_at)
+		VALUES (?, ?, ?, ?, ?, ?, 0, ?)`,
+		req.ID.String(),
+		req.Name,
+		req.AggregateID,
+		string(req.Payload),
+		req.RequestID,
+		req.OccurredAt.SQL(),
+		req.NextAttemptAt.SQL(),
+	)
+	if result.Error != nil {
+		return res, fmt.Errorf("[outbox_gorm_mysql:Add %w: %w]", repositories.ErrAddingOutboxEvent, result.Error)
+	}
+
+	return
+}
+
+func (o *Outbox) GetPending(req repositories.GetPendingOutboxEventsRequest) (res repositories.GetPendingOutboxEventsResponse, err error) {
+	var events []models.OutboxEvent
+	result := o.db.Raw(`
+		SELECT id, name, aggregate_id, payload, request_id, occurred_at, attempts, last_error, next_attempt_at
+		FROM outbox_events
+		WHERE dispatched_at IS NULL
+			AND failed_at IS NULL
+			AND next_attempt_at <= ?
+		ORDER BY occurred_at
+		LIMIT ?
+		FOR UPDATE SKIP LOCKED`,
+		req.Now.SQL(),
+		req.Limit,
+	).Scan(&events)
+	if result.Error != nil {
+		return res, fmt.Errorf("[outbox_gorm_mysql:GetPending %w: %w]", repositories.ErrGettingOutboxEvents, result.Error)
+	}
+
+	eventsEntity := make([]entities.OutboxEvent, 0, len(events))
+	for _, event := range events {
+		eventEntity, err := event.Entity()
+		if err != nil {
+			return res, fmt.Errorf("[outbox_gorm_mysql:GetPending %w: %w]", repositories.ErrGettingOutboxEvents, err)
+		}
+		eventsEntity = append(eventsEntity, eventEntity)
+	}
+	res.Events = eventsEntity
+
+	return
+}
+
+func (o *Outbox) Lease(req repositories.LeaseOutboxEventsRequest) (res repositories.LeaseOutboxEventsResponse, err error) {
+	if len(req.IDs) == 0 {
+		return
+	}
+
+	ids := make([]string, len(req.IDs))
+	for i, id := range req.IDs {
+		ids[i] = id.String()
+	}
+
+	result := o.db.Exec(`
+		UPDATE outbox_events
+		SET next_attempt_at = ?
+		WHERE id IN ?`,
+		req.Until.SQL(),
+		ids,
+	)
+	if result.Error != nil {
+		return res, fmt.Errorf("[outbox_gorm_mysql:Lease %w: %w]", repositories.ErrUpdatingOutboxEvent, result.Error)
+	}
+
+	return
+}
+
+func (o *Outbox) MarkDispatched(req repositories.MarkOutboxEventDispatchedRequest) (res repositories.MarkOutboxEventResponse, err error) {
+	result := o.db.Exec(`
+		UPDATE outbox_events
+		SET dispatched_at = ?, attempts = attempts + 1, last_error = NULL
+		WHERE id = ?`,
+		req.DispatchedAt.SQL(),
+		req.ID.String(),
+	)
+	if result.Error != nil {
+		return res, fmt.Errorf("[outbox_gorm_mysql:MarkDispatched %w: %w]", repositories.ErrUpdatingOutboxEvent, result.Error)
+	}
+
+	return
+}
+
+func (o *Outbox) MarkFailed(req repositories.MarkOutboxEventFailedRequest) (res repositories.MarkOutboxEventResponse, err error) {
+	var failedAt any
+	if req.FailedAt != nil {
+		failedAt = req.FailedAt.SQL()
+	}
+
+	result := o.db.Exec(`
+		UPDATE outbox_events
+		SET attempts = ?, last_error = ?, next_attempt_at = ?, failed_at = ?
+		WHERE id = ?`,
+		req.Attempts,
+		req.LastError,
+		req.NextAttemptAt.SQL(),
+		failedAt,
+		req.ID.String(),
+	)
+	if result.Error != nil {
+		return res, fmt.Errorf("[outbox_gorm_mysql:MarkFailed %w: %w]", repositories.ErrUpdatingOutboxEvent, result.Error)
+	}
+
+	return
+}
@@ -0,0 +1,46 @@
+package gorm_mysql
+
+import (
+	"go-clean-api/pkg/adapters/db"
+	"go-clean-api/pkg/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// UnitOfWork is an implementation of the UnitOfWork interface using GORM transactions
+type UnitOfWork struct {
+	db         *gorm.DB
+	maxRetries int
+}
+
+// NewUnitOfWork creates a new UnitOfWork
+func NewUnitOfWork(db *db.GormMySQL) *UnitOfWork {
+	return &UnitOfWork{db: db.DB, maxRetries: db.TransactionMaxRetries()}
+}
+
+// Do runs fn in a transaction which is committed if fn returns nil and rolled back otherwise.
+// The transaction is retried on deadlock.
+func (u *UnitOfWork) Do(fn func(repos repositories.Repositories) error) error {
+	return db.RetryTransaction(u.maxRetries, func() error {
+		return u.db.Transaction(func(tx *gorm.DB) error {
+			return fn(&txRepositories{tx: tx})
+		})
+	})
+}
+
+// txRepositories gives access to the repositories bound to a GORM transaction
+type txRepositories struct {
+	tx *gorm.DB
+}
+
+func (r *txRepositories) User() repositories.User {
+	return &User{db: r.tx}
+}
+
+func (r *txRepositories) AuditLog() repositories.AuditLog {
+	return &AuditLog{db: r.tx}
+}
+
+func (r *txRepositories) Outbox() repositories.Outbox {
+	return &Outbox{db: r.tx}
+}
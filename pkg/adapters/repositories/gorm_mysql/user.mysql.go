@@ -30,7 +30,7 @@ func (u *User) GetByEmail(req repositories.GetByEmailRequest) (res repositories.
 			AND deleted_at IS NULL
 		LIMIT 1`, req.Email.Value()).Scan(&model)
 	if result.Error != nil {
-		return res, fmt.Errorf("[user_gorm_mysql:GetByEmail %w: %s]", domainerr.ErrNotFound, result.Error)
+		return res, fmt.Errorf("[user_gorm_mysql:GetByEmail %w: %w]", domainerr.ErrNotFound, result.Error)
 	} else if result.RowsAffected == 0 {
 		return res, fmt.Errorf("[user_gorm_mysql:GetByEmail %w]", domainerr.ErrNotFound)
 	}
@@ -38,26 +38,37 @@ func (u *User) GetByEmail(req repositories.GetByEmailRequest) (res repositories.
 	res, err = model.Repository()
 
 	if err != nil {
-		return res, fmt.Errorf("[user_gorm_mysql:GetByEmail %w: %s]", repositories.ErrGettingUser, err)
+		return res, fmt.Errorf("[user_gorm_mysql:GetByEmail %w: %w]", repositories.ErrGettingUser, err)
 	}
 
 	return res, nil
 }
 
 func (u *User) GetByID(req repositories.GetByIDRequest) (res repositories.GetByIDResponse, err error) {
-	var model models.User
-	if result := u.db.Raw(`
-		SELECT id, email, lastname, firstname, created_at, updated_at, deleted_at
+	q := `
+		SELECT id, email, lastname, firstname, created_at, updated_at, deleted_at, version
 		FROM users
-		WHERE id = ?
-			AND deleted_at IS NULL
-		LIMIT 1`, req.ID.Value()).Scan(&model); result.Error != nil {
-		return res, fmt.Errorf("[user_gorm_mysql:GetByID %w: %s]", domainerr.ErrNotFound, result.Error)
+		WHERE id = ?`
+
+	if req.Deleted {
+		q += " AND deleted_at IS NOT NULL"
+	} else {
+		q += " AND deleted_at IS NULL"
+	}
+
+	q += " LIMIT 1"
+
+	var model models.User
+	result := u.db.Raw(q, req.ID.Value()).Scan(&model)
+	if result.Error != nil {
+		return res, fmt.Errorf("[user_gorm_mysql:GetByID %w: %w]", domainerr.ErrNotFound, result.Error)
+	} else if result.RowsAffected == 0 {
+		return res, fmt.Errorf("[user_gorm_mysql:GetByID %w]", domainerr.ErrNotFound)
 	}
 	user, err := model.Entity()
 
 	if err != nil {
-		return res, fmt.Errorf("[user_gorm_mysql:GetByID %w: %s]", repositories.ErrGettingUser, err)
+		return res, fmt.Errorf("[user_gorm_mysql:GetByID %w: %w]", repositories.ErrGettingUser, err)
 	}
 
 	res.User = user
@@ -79,7 +90,7 @@ func (u *User) CountAll(req repositories.CountAllRequest) (repositories.CountAll
 	var count int64
 	row := u.db.Raw(q)
 	if result := row.Scan(&count); result.Error != nil {
-		return repositories.CountAllResponse{}, fmt.Errorf("[user_gorm_mysql:CountAll %w: %s]", repositories.ErrCountingUsers, result.Error)
+		return repositories.CountAllResponse{}, fmt.Errorf("[user_gorm_mysql:CountAll %w: %w]", repositories.ErrCountingUsers, result.Error)
 	}
 
 	return repositories.CountAllResponse{Total: count}, nil
@@ -95,14 +106,14 @@ func (u *User) GetAll(req repositories.GetAllRequest) (res repositories.GetAllRe
 
 	var users []models.User
 	if result := q.Find(&users); result.Error != nil {
-		return res, fmt.Errorf("[user_gorm_mysql:GetAll %w: %s]", repositories.ErrGettingUsers, result.Error)
+		return res, fmt.Errorf("[user_gorm_mysql:GetAll %w: %w]", repositories.ErrGettingUsers, result.Error)
 	}
 
 	usersEntity := make([]entities.User, 0, len(users))
 	for _, user := range users {
 		userEntity, err := user.Entity()
 		if err != nil {
-			return res, fmt.Errorf("[user_gorm_mysql:GetAll %w: %s]", repositories.ErrGettingUsers, err)
+			return res, fmt.Errorf("[user_gorm_mysql:GetAll %w: %w]", repositories.ErrGettingUsers, err)
 		}
 		usersEntity = append(usersEntity, userEntity)
 	}
@@ -111,6 +122,41 @@ func (u *User) GetAll(req repositories.GetAllRequest) (res repositories.GetAllRe
 	return
 }
 
+func (u *User) StreamAll(req repositories.StreamAllRequest) (res repositories.StreamAllResponse, err error) {
+	q := u.db.Model(&models.User{}).Scopes(db.GormPaginate(req.Pagination.Page(), req.Pagination.Size()))
+	if req.Deleted {
+		q = q.Where("deleted_at IS NOT NULL")
+	} else {
+		q = q.Where("deleted_at IS NULL")
+	}
+
+	rows, err := q.Rows()
+	if err != nil {
+		return res, fmt.Errorf("[user_gorm_mysql:StreamAll %w: %w]", repositories.ErrGettingUsers, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var model models.User
+		if err := u.db.ScanRows(rows, &model); err != nil {
+			return res, fmt.Errorf("[user_gorm_mysql:StreamAll %w: %w]", repositories.ErrGettingUsers, err)
+		}
+		user, err := model.Entity()
+		if err != nil {
+			return res, fmt.Errorf("[user_gorm_mysql:StreamAll %w: %w]", repositories.ErrGettingUsers, err)
+		}
+
+		if err := req.Each(user); err != nil {
+			return res, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return res, fmt.Errorf("[user_gorm_mysql:StreamAll %w: %w]", repositories.ErrGettingUsers, err)
+	}
+
+	return
+}
+
 func (u *User) Create(req repositories.CreateUserRequest) (res repositories.CreateUserResponse, err error) {
 	result := u.db.Exec(`
 		INSERT INTO users (id, email, password, lastname, firstname, created_at, updated_at) 
@@ -124,7 +170,10 @@ func (u *User) Create(req repositories.CreateUserRequest) (res repositories.Crea
 		req.UpdatedAt.SQL(),
 	)
 	if result.Error != nil {
-		return res, fmt.Errorf("[user_gorm_mysql:Create %w: %s]", repositories.ErrCreatingUser, result.Error)
+		if db.IsDuplicateEntryError(result.Error) {
+			return res, fmt.Errorf("[user_gorm_mysql:Create %w: %w]", domainerr.ErrConflict, result.Error)
+		}
+		return res, fmt.Errorf("[user_gorm_mysql:Create %w: %w]", repositories.ErrCreatingUser, result.Error)
 	}
 
 	return repositories.CreateUserResponse{
@@ -136,6 +185,7 @@ func (u *User) Create(req repositories.CreateUserRequest) (res repositories.Crea
 			Firstname: req.Firstname,
 			CreatedAt: req.CreatedAt,
 			UpdatedAt: req.UpdatedAt,
+			Version:   1,
 		},
 	}, nil
 }
@@ -143,16 +193,22 @@ func (u *User) Create(req repositories.CreateUserRequest) (res repositories.Crea
 func (u *User) Delete(req repositories.DeleteRestoreRequest) (res repositories.DeleteRestoreResponse, err error) {
 	result := u.db.Exec(`
 		UPDATE users
-		SET deleted_at = NOW()
+		SET deleted_at = NOW(), version = version + 1
 		WHERE id = ?
-			AND deleted_at IS NULL`,
+			AND deleted_at IS NULL
+			AND (? = 0 OR version = ?)`,
 		req.ID.String(),
+		req.Version,
+		req.Version,
 	)
 	if result.Error != nil {
-		return res, fmt.Errorf("[user_gorm_mysql:Delete %w: %s]", domainerr.ErrDatabase, result.Error)
+		return res, fmt.Errorf("[user_gorm_mysql:Delete %w: %w]", domainerr.ErrDatabase, result.Error)
 	}
 
 	if result.RowsAffected == 0 {
+		if req.Version != 0 {
+			return repositories.DeleteRestoreResponse{}, fmt.Errorf("[user_gorm_mysql:Delete %w]", domainerr.ErrPreconditionFailed)
+		}
 		return repositories.DeleteRestoreResponse{}, fmt.Errorf("[user_gorm_mysql:Delete %w]", domainerr.ErrNotFound)
 	}
 
@@ -162,18 +218,46 @@ func (u *User) Delete(req repositories.DeleteRestoreRequest) (res repositories.D
 func (u *User) Restore(req repositories.DeleteRestoreRequest) (res repositories.DeleteRestoreResponse, err error) {
 	result := u.db.Exec(`
 		UPDATE users
-		SET deleted_at = NULL
+		SET deleted_at = NULL, version = version + 1
 		WHERE id = ?
-			AND deleted_at IS NOT NULL`,
+			AND deleted_at IS NOT NULL
+			AND (? = 0 OR version = ?)`,
 		req.ID.String(),
+		req.Version,
+		req.Version,
 	)
 	if result.Error != nil {
-		return res, fmt.Errorf("[user_gorm_mysql:Restore %w: %s]", domainerr.ErrDatabase, result.Error)
+		return res, fmt.Errorf("[user_gorm_mysql:Restore %w: %w]", domainerr.ErrDatabase, result.Error)
 	}
 
 	if result.RowsAffected == 0 {
+		if req.Version != 0 {
+			return repositories.DeleteRestoreResponse{}, fmt.Errorf("[user_gorm_mysql:Restore %w]", domainerr.ErrPreconditionFailed)
+		}
 		return repositories.DeleteRestoreResponse{}, fmt.Errorf("[user_gorm_mysql:Restore %w]", domainerr.ErrNotFound)
 	}
 
 	return
 }
+
+func (u *User) UpdatePassword(req repositories.UpdatePasswordRequest) (res repositories.UpdatePasswordResponse, err error) {
+	result := u.db.Exec(`
+		UPDATE users
+		SET password = ?
+		WHERE id = ?
+			AND password = ?
+			AND deleted_at IS NULL`,
+		req.Password.Value(),
+		req.ID.String(),
+		req.OldPassword.Value(),
+	)
+	if result.Error != nil {
+		return res, fmt.Errorf("[user_gorm_mysql:UpdatePassword %w: %w]", repositories.ErrUpdatingUser, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return res, fmt.Errorf("[user_gorm_mysql:UpdatePassword %w]", domainerr.ErrNotFound)
+	}
+
+	return
+}
@@ -0,0 +1,308 @@
+package gorm_mysql
+
+import (
+	"fmt"
+	"go-clean-api/pkg/adapters/db"
+	"go-clean-api/pkg/adapters/models"
+	"go-clean-api/pkg/domain/entities"
+	domainerr "go-clean-api/pkg/domain/errors"
+	"go-clean-api/pkg/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// Webhook is an implementation of the WebhookRepository interface
+type Webhook struct {
+	db *gorm.DB
+}
+
+// NewWebhook creates a new WebhookMysqlRepository
+func NewWebhook(db *db.GormMySQL) *Webhook {
+	return &Webhook{db: db.DB}
+}
+
+func (w *Webhook) Create(req repositories.CreateWebhookRequest) (res repositories.CreateWebhookResponse, err error) {
+	values, err := models.WebhookInsertValues(req.Webhook)
+	if err != nil {
+		return res, fmt.Errorf("[webhook_gorm_mysql:Create %w: %w]", repositories.ErrCreatingWebhook, err)
+	}
+
+	result := w.db.Exec(`
+		INSERT INTO webhooks (id, url, secret, events, enabled, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		values...,
+	)
+	if result.Error != nil {
+		return res, fmt.Errorf("[webhook_gorm_mysql:Create %w: %w]", repositories.ErrCreatingWebhook, result.Error)
+	}
+	res.Webhook = req.Webhook
+
+	return
+}
+
+func (w *Webhook) GetByID(req repositories.GetWebhookByIDRequest) (res repositories.GetWebhookByIDResponse, err error) {
+	var model models.Webhook
+	result := w.db.Raw(`
+		SELECT id, url, secret, events, enabled, consecutive_failures, created_at, updated_at, disabled_at
+		FROM webhooks
+		WHERE id = ?
+		LIMIT 1`,
+		req.ID.String(),
+	).Scan(&model)
+	if result.Error != nil {
+		return res, fmt.Errorf("[webhook_gorm_mysql:GetByID %w: %w]", domainerr.ErrNotFound, result.Error)
+	} else if result.RowsAffected == 0 {
+		return res, fmt.Errorf("[webhook_gorm_mysql:GetByID %w]", domainerr.ErrNotFound)
+	}
+
+	webhook, err := model.Entity()
+	if err != nil {
+		return res, fmt.Errorf("[webhook_gorm_mysql:GetByID %w: %w]", repositories.ErrGettingWebhooks, err)
+	}
+	res.Webhook = webhook
+
+	return
+}
+
+func (w *Webhook) GetAll(req repositories.GetAllWebhooksRequest) (res repositories.GetAllWebhooksResponse, err error) {
+	offset, limit := db.PaginateValues(req.Pagination.Page(), req.Pagination.Size())
+
+	var webhooks []models.Webhook
+	result := w.db.Raw(`
+		SELECT id, url, secret, events, enabled, consecutive_failures, created_at, updated_at, disabled_at
+		FROM webhooks
+		ORDER BY created_at
+		LIMIT ? OFFSET ?`,
+		limit,
+		offset,
+	).Scan(&webhooks)
+	if result.Error != nil {
+		return res, fmt.Errorf("[webhook_gorm_mysql:GetAll %w: %w]", repositories.ErrGettingWebhooks, result.Error)
+	}
+
+	res.Webhooks, err = webhooksEntity(webhooks)
+	if err != nil {
+		return res, fmt.Errorf("[webhook_gorm_mysql:GetAll %w: %w]", repositories.ErrGettingWebhooks, err)
+	}
+
+	return
+}
+
+func (w *Webhook) CountAll(req repositories.CountAllWebhooksRequest) (repositories.CountAllWebhooksResponse, error) {
+	var count int64
+	if result := w.db.Raw("SELECT COUNT(id) AS total FROM webhooks").Scan(&count); result.Error != nil {
+		return repositories.CountAllWebhooksResponse{}, fmt.Errorf("[webhook_gorm_mysql:CountAll %w: %w]", repositories.ErrGettingWebhooks, result.Error)
+	}
+
+	return repositories.CountAllWebhooksResponse{Total: count}, nil
+}
+
+func (w *Webhook) GetEnabledByEvent(req repositories.GetEnabledWebhooksByEventRequest) (res repositories.GetEnabledWebhooksByEventResponse, err error) {
+	var webhooks []models.Webhook
+	result := w.db.Raw(`
+		SELECT id, url, secret, events, enabled, consecutive_failures, created_at, updated_at, disabled_at
+		FROM webhooks
+		WHERE enabled = 1
+			AND JSON_CONTAINS(events, JSON_QUOTE(?))`,
+		req.EventName,
+	).Scan(&webhooks)
+	if result.Error != nil {
+		return res, fmt.Errorf("[webhook_gorm_mysql:GetEnabledByEvent %w: %w]", repositories.ErrGettingWebhooks, result.Error)
+	}
+
+	res.Webhooks, err = webhooksEntity(webhooks)
+	if err != nil {
+		return res, fmt.Errorf("[webhook_gorm_mysql:GetEnabledByEvent %w: %w]", repositories.ErrGettingWebhooks, err)
+	}
+
+	return
+}
+
+func (w *Webhook) Delete(req repositories.DeleteWebhookRequest) (res repositories.DeleteWebhookResponse, err error) {
+	result := w.db.Exec("DELETE FROM webhooks WHERE id = ?", req.ID.String())
+	if result.Error != nil {
+		return res, fmt.Errorf("[webhook_gorm_mysql:Delete %w: %w]", domainerr.ErrDatabase, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return res, fmt.Errorf("[webhook_gorm_mysql:Delete %w]", domainerr.ErrNotFound)
+	}
+
+	return
+}
+
+func (w *Webhook) Enable(req repositories.EnableWebhookRequest) (res repositories.EnableWebhookResponse, err error) {
+	result := w.db.Exec(`
+		UPDATE webhooks
+		SET enabled = 1, consecutive_failures = 0, disabled_at = NULL, updated_at = ?
+		WHERE id = ?`,
+		req.UpdatedAt.SQL(),
+		req.ID.String(),
+	)
+	if result.Error != nil {
+		return res, fmt.Errorf("[webhook_gorm_mysql:Enable %w: %w]", repositories.ErrUpdatingWebhook, result.Error)
+	}
+
+	return
+}
+
+func (w *Webhook) RecordSuccess(req repositories.RecordWebhookSuccessRequest) (res repositories.RecordWebhookResultResponse, err error) {
+	result := w.db.Exec(`
+		UPDATE webhooks
+		SET consecutive_failures = 0
+		WHERE id = ?`,
+		req.ID.String(),
+	)
+	if result.Error != nil {
+		return res, fmt.Errorf("[webhook_gorm_mysql:RecordSuccess %w: %w]", repositories.ErrUpdatingWebhook, result.Error)
+	}
+
+	return
+}
+
+func (w *Webhook) RecordFailure(req repositories.RecordWebhookFailureRequest) (res repositories.RecordWebhookResultResponse, err error) {
+	// Assignments are evaluated from left to right, so consecutive_failures is already incremented
+	// when enabled and disabled_at are computed.
+	result := w.db.Exec(`
+		UPDATE webhooks
+		SET consecutive_failures = consecutive_failures + 1,
+			disabled_at = IF(enabled = 1 AND consecutive_failures >= ?, ?, disabled_at),
+			enabled = IF(consecutive_failures >= ?, 0, enabled)
+		WHERE id = ?`,
+		req.DisableAfter,
+		req.Now.SQL(),
+		req.DisableAfter,
+		req.ID.String(),
+	)
+	if result.Error != nil {
+		return res, fmt.Errorf("[webhook_gorm_mysql:RecordFailure %w: %w]", repositories.ErrUpdatingWebhook, result.Error)
+	}
+
+	return
+}
+
+func (w *Webhook) CreateDelivery(req repositories.CreateWebhookDeliveryRequest) (res repositories.CreateWebhookDeliveryResponse, err error) {
+	result := w.db.Exec(`
+		INSERT INTO webhook_deliveries (id, webhook_id, event_id, event_name, payload, attempt, status_code, success, error, duration_ms, created_at, next_attempt_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		models.WebhookDeliveryInsertValues(req.WebhookDelivery)...,
+	)
+	if result.Error != nil {
+		return res, fmt.Errorf("[webhook_gorm_mysql:CreateDelivery %w: %w]", repositories.ErrCreatingWebhookDelivery, result.Error)
+	}
+
+	return
+}
+
+func (w *Webhook) GetDeliveryByID(req repositories.GetWebhookDeliveryByIDRequest) (res repositories.GetWebhookDeliveryByIDResponse, err error) {
+	var model models.WebhookDelivery
+	result := w.db.Raw(`
+		SELECT id, webhook_id, event_id, event_name, payload, attempt, status_code, success, error, duration_ms, created_at, next_attempt_at
+		FROM webhook_deliveries
+		WHERE id = ?
+			AND webhook_id = ?
+		LIMIT 1`,
+		req.ID.String(),
+		req.WebhookID.String(),
+	).Scan(&model)
+	if result.Error != nil {
+		return res, fmt.Errorf("[webhook_gorm_mysql:GetDeliveryByID %w: %w]", domainerr.ErrNotFound, result.Error)
+	} else if result.RowsAffected == 0 {
+		return res, fmt.Errorf("[webhook_gorm_mysql:GetDeliveryByID %w]", domainerr.ErrNotFound)
+	}
+
+	delivery, err := model.Entity()
+	if err != nil {
+		return res, fmt.Errorf("[webhook_gorm_mysql:GetDeliveryByID %w: %w]", repositories.ErrGettingWebhookDeliveries, err)
+	}
+	res.WebhookDelivery = delivery
+
+	return
+}
+
+func (w *Webhook) GetLastDelivery(req repositories.GetLastWebhookDeliveryRequest) (res repositories.GetLastWebhookDeliveryResponse, err error) {
+	var model models.WebhookDelivery
+	result := w.db.Raw(`
+		SELECT id, webhook_id, event_id, event_name, payload, attempt, status_code, success, error, duration_ms, created_at, next_attempt_at
+		FROM webhook_deliveries
+		WHERE webhook_id = ?
+			AND event_id = ?
+		ORDER BY created_at DESC, attempt DESC
+		LIMIT 1`,
+		req.WebhookID.String(),
+		req.EventID,
+	).Scan(&model)
+	if result.Error != nil {
+		return res, fmt.Errorf("[webhook_gorm_mysql:GetLastDelivery %w: %w]", repositories.ErrGettingWebhookDeliveries, result.Error)
+	} else if result.RowsAffected == 0 {
+		return res, fmt.Errorf("[webhook_gorm_mysql:GetLastDelivery %w]", domainerr.ErrNotFound)
+	}
+
+	delivery, err := model.Entity()
+	if err != nil {
+		return res, fmt.Errorf("[webhook_gorm_mysql:GetLastDelivery %w: %w]", repositories.ErrGettingWebhookDeliveries, err)
+	}
+	res.WebhookDelivery = delivery
+
+	return
+}
+
+func (w *Webhook) GetDeliveries(req repositories.GetWebhookDeliveriesRequest) (res repositories.GetWebhookDeliveriesResponse, err error) {
+	offset, limit := db.PaginateValues(req.Pagination.Page(), req.Pagination.Size())
+
+	var deliveries []models.WebhookDelivery
+	result := w.db.Raw(`
+		SELECT id, webhook_id, event_id, event_name, payload, attempt, status_code, success, error, duration_ms, created_at, next_attempt_at
+		FROM webhook_deliveries
+		WHERE webhook_id = ?
+		ORDER BY created_at DESC
+		LIMIT ? OFFSET ?`,
+		req.WebhookID.String(),
+		limit,
+		offset,
+	).Scan(&deliveries)
+	if result.Error != nil {
+		return res, fmt.Errorf("[webhook_gorm_mysql:GetDeliveries %w: %w]", repositories.ErrGettingWebhookDeliveries, result.Error)
+	}
+
+	deliveriesEntity := make([]entities.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		deliveryEntity, err := delivery.Entity()
+		if err != nil {
+			return res, fmt.Errorf("[webhook_gorm_mysql:GetDeliveries %w: %w]", repositories.ErrGettingWebhookDeliveries, err)
+		}
+		deliveriesEntity = append(deliveriesEntity, deliveryEntity)
+	}
+	res.Deliveries = deliveriesEntity
+
+	return
+}
+
+func (w *Webhook) CountDeliveries(req repositories.CountWebhookDeliveriesRequest) (repositories.CountWebhookDeliveriesResponse, error) {
+	var count int64
+	result := w.db.Raw(`
+		SELECT COUNT(id) AS total
+		FROM webhook_deliveries
+		WHERE webhook_id = ?`,
+		req.WebhookID.String(),
+	).Scan(&count)
+	if result.Error != nil {
+		return repositories.CountWebhookDeliveriesResponse{}, fmt.Errorf("[webhook_gorm_mysql:CountDeliveries %w: %w]", repositories.ErrGettingWebhookDeliveries, result.Error)
+	}
+
+	return repositories.CountWebhookDeliveriesResponse{Total: count}, nil
+}
+
+// webhooksEntity converts a list of webhook models to entities
+func webhooksEntity(webhooks []models.Webhook) ([]entities.Webhook, error) {
+	list := make([]entities.Webhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		webhookEntity, err := webhook.Entity()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, webhookEntity)
+	}
+
+	return list, nil
+}
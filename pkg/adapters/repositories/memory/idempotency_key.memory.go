@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"fmt"
+	"go-clean-api/pkg/domain/entities"
+	domainerr "go-clean-api/pkg/domain/errors"
+	"go-clean-api/pkg/domain/repositories"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// IdempotencyKey is an in-memory implementation of the IdempotencyKeyRepository interface.
+// Keys are not shared between several instances of the application.
+type IdempotencyKey struct {
+	mu   sync.Mutex
+	keys map[string]entities.IdempotencyKey
+}
+
+// NewIdempotencyKey creates a new in-memory IdempotencyKeyRepository
+func NewIdempotencyKey() *IdempotencyKey {
+	return &IdempotencyKey{keys: make(map[string]entities.IdempotencyKey)}
+}
+
+func (k *IdempotencyKey) Create(req repositories.CreateIdempotencyKeyRequest) (res repositories.CreateIdempotencyKeyResponse, err error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if _, ok := k.keys[req.Key]; ok {
+		return res, fmt.Errorf("[idempotency_key_memory:Create %w]", domainerr.ErrConflict)
+	}
+	k.keys[req.Key] = req.IdempotencyKey
+
+	return
+}
+
+func (k *IdempotencyKey) GetByKey(req repositories.GetIdempotencyKeyRequest) (res repositories.GetIdempotencyKeyResponse, err error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	key, ok := k.keys[req.Key]
+	if !ok {
+		return res, fmt.Errorf("[idempotency_key_memory:GetByKey %w]", domainerr.ErrNotFound)
+	}
+	key.ResponseHeaders = maps.Clone(key.ResponseHeaders)
+	key.ResponseBody = slices.Clone(key.ResponseBody)
+	res.IdempotencyKey = key
+
+	return
+}
+
+func (k *IdempotencyKey) Complete(req repositories.CompleteIdempotencyKeyRequest) (res repositories.CompleteIdempotencyKeyResponse, err error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	key, ok := k.keys[req.Key]
+	if !ok {
+		return
+	}
+	key.Status = entities.IdempotencyKeyStatusCompleted
+	key.ResponseStatus = req.ResponseStatus
+	key.ResponseHeaders = maps.Clone(req.ResponseHeaders)
+	key.ResponseBody = slices.Clone(req.ResponseBody)
+	k.keys[req.Key] = key
+
+	return
+}
+
+func (k *IdempotencyKey) Delete(req repositories.DeleteIdempotencyKeyRequest) (res repositories.DeleteIdempotencyKeyResponse, err error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	key, ok := k.keys[req.Key]
+	if !ok || (req.CreatedAt != nil && !key.CreatedAt.Value().Equal(req.CreatedAt.Value())) {
+		return
+	}
+	delete(k.keys, req.Key)
+	res.Deleted = 1
+
+	return
+}
+
+func (k *IdempotencyKey) DeleteExpired(req repositories.DeleteExpiredIdempotencyKeysRequest) (res repositories.DeleteExpiredIdempotencyKeysResponse, err error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	for id, key := range k.keys {
+		if key.IsExpired(req.Now) {
+			delete(k.keys, id)
+			res.Deleted++
+		}
+	}
+
+	return
+}
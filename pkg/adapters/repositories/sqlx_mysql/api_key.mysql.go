@@ -0,0 +1,155 @@
+package sqlx_mysql
+
+import (
+	"fmt"
+	"go-clean-api/pkg/adapters/db"
+	"go-clean-api/pkg/adapters/models"
+	"go-clean-api/pkg/domain/entities"
+	domainerr "go-clean-api/pkg/domain/errors"
+	"go-clean-api/pkg/domain/repositories"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// APIKey is an implementation of the APIKeyRepository interface
+type APIKey struct {
+	db sqlx.Ext
+}
+
+// NewAPIKey creates a new APIKeyMysqlRepository
+func NewAPIKey(db *db.SqlxMySQL) *APIKey {
+	return &APIKey{db: db.DB}
+}
+
+func (k *APIKey) Create(req repositories.CreateAPIKeyRequest) (res repositories.CreateAPIKeyResponse, err error) {
+	values, err := models.APIKeyInsertValues(req.APIKey)
+	if err != nil {
+		return res, fmt.Errorf("[api_key_sqlx_mysql:Create %w: %w]", repositories.ErrCreatingAPIKey, err)
+	}
+
+	_, err = k.db.Exec(`
+		INSERT INTO api_keys (id, user_id, name, prefix, hash, scopes, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		values...,
+	)
+	if err != nil {
+		return res, fmt.Errorf("[api_key_sqlx_mysql:Create %w: %w]", repositories.ErrCreatingAPIKey, err)
+	}
+
+	return
+}
+
+func (k *APIKey) GetByPrefix(req repositories.GetAPIKeyByPrefixRequest) (res repositories.GetAPIKeyByPrefixResponse, err error) {
+	var model models.APIKey
+	row := k.db.QueryRowx(`
+		SELECT id, user_id, name, prefix, hash, scopes, expires_at, last_used_at, created_at, revoked_at
+		FROM api_keys
+		WHERE prefix = ?
+		LIMIT 1`,
+		req.Prefix,
+	)
+	if err = row.StructScan(&model); err != nil {
+		return res, fmt.Errorf("[api_key_sqlx_mysql:GetByPrefix %w: %w]", domainerr.ErrNotFound, err)
+	}
+
+	apiKey, err := model.Entity()
+	if err != nil {
+		return res, fmt.Errorf("[api_key_sqlx_mysql:GetByPrefix %w: %w]", repositories.ErrGettingAPIKeys, err)
+	}
+	res.APIKey = apiKey
+
+	return
+}
+
+func (k *APIKey) GetAllByUser(req repositories.GetAllAPIKeysByUserRequest) (res repositories.GetAllAPIKeysByUserResponse, err error) {
+	offset, limit := db.PaginateValues(req.Pagination.Page(), req.Pagination.Size())
+
+	rows, err := k.db.Queryx(`
+		SELECT id, user_id, name, prefix, hash, scopes, expires_at, last_used_at, created_at, revoked_at
+		FROM api_keys
+		WHERE user_id = ?
+		ORDER BY created_at DESC
+		LIMIT ? OFFSET ?`,
+		req.UserID.String(),
+		limit,
+		offset,
+	)
+	if err != nil {
+		return res, fmt.Errorf("[api_key_sqlx_mysql:GetAllByUser %w: %w]", repositories.ErrGettingAPIKeys, err)
+	}
+	defer rows.Close()
+
+	apiKeys := make([]entities.APIKey, 0, limit)
+	for rows.Next() {
+		var model models.APIKey
+		if err := rows.StructScan(&model); err != nil {
+			return repositories.GetAllAPIKeysByUserResponse{}, fmt.Errorf("[api_key_sqlx_mysql:GetAllByUser %w: %w]", repositories.ErrGettingAPIKeys, err)
+		}
+		apiKey, err := model.Entity()
+		if err != nil {
+			return repositories.GetAllAPIKeysByUserResponse{}, fmt.Errorf("[api_key_sqlx_mysql:GetAllByUser %w: %w]", repositories.ErrGettingAPIKeys, err)
+		}
+
+		apiKeys = append(apiKeys, apiKey)
+	}
+
+	return repositories.GetAllAPIKeysByUserResponse{
+		APIKeys: apiKeys,
+	}, nil
+}
+
+func (k *APIKey) CountAllByUser(req repositories.CountAllAPIKeysByUserRequest) (repositories.CountAllAPIKeysByUserResponse, error) {
+	var count int64
+	row := k.db.QueryRowx(`
+		SELECT COUNT(id) AS total
+		FROM api_keys
+		WHERE user_id = ?`,
+		req.UserID.String(),
+	)
+	if err := row.Scan(&count); err != nil {
+		return repositories.CountAllAPIKeysByUserResponse{}, fmt.Errorf("[api_key_sqlx_mysql:CountAllByUser %w: %w]", repositories.ErrGettingAPIKeys, err)
+	}
+
+	return repositories.CountAllAPIKeysByUserResponse{Total: count}, nil
+}
+
+func (k *APIKey) Revoke(req repositories.RevokeAPIKeyRequest) (res repositories.RevokeAPIKeyResponse, err error) {
+	result, err := k.db.Exec(`
+		UPDATE api_keys
+		SET revoked_at = ?
+		WHERE id = ?
+			AND user_id = ?
+			AND revoked_at IS NULL`,
+		req.RevokedAt.SQL(),
+		req.ID.String(),
+		req.UserID.String(),
+	)
+	if err != nil {
+		return res, fmt.Errorf("[api_key_sqlx_mysql:Revoke %w: %w]", domainerr.ErrDatabase, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return res, fmt.Errorf("[api_key_sqlx_mysql:Revoke %w: %w]", domainerr.ErrDatabase, err)
+	}
+	if rowsAffected == 0 {
+		return res, fmt.Errorf("[api_key_sqlx_mysql:Revoke %w]", domainerr.ErrNotFound)
+	}
+
+	return
+}
+
+func (k *APIKey) UpdateLastUsed(req repositories.UpdateAPIKeyLastUsedRequest) (res repositories.UpdateAPIKeyLastUsedResponse, err error) {
+	_, err = k.db.Exec(`
+		UPDATE api_keys
+		SET last_used_at = ?
+		WHERE id = ?`,
+		req.LastUsedAt.SQL(),
+		req.ID.String(),
+	)
+	if err != nil {
+		return res, fmt.Errorf("[api_key_sqlx_mysql:UpdateLastUsed %w: %w]", repositories.ErrUpdatingAPIKey, err)
+	}
+
+	return
+}
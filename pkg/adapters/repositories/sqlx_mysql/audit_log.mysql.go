@@ -0,0 +1,85 @@
+package sqlx_mysql
+
+import (
+	"fmt"
+	"go-clean-api/pkg/adapters/db"
+	"go-clean-api/pkg/adapters/models"
+	"go-clean-api/pkg/domain/entities"
+	"go-clean-api/pkg/domain/repositories"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// AuditLog is an implementation of the AuditLogRepository interface
+type AuditLog struct {
+	db sqlx.Ext
+}
+
+// NewAuditLog creates a new AuditLogMysqlRepository
+func NewAuditLog(db *db.SqlxMySQL) *AuditLog {
+	return &AuditLog{db: db.DB}
+}
+
+func (a *AuditLog) Create(req repositories.CreateAuditLogRequest) (res repositories.CreateAuditLogResponse, err error) {
+	_, err = a.db.Exec(`
+		INSERT INTO audit_logs (id, actor_id, action, target_type, target_id, before_state, after_state, ip, request_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		models.AuditLogInsertValues(req.AuditLog)...,
+	)
+	if err != nil {
+		return res, fmt.Errorf("[audit_log_sqlx_mysql:Create %w: %w]", repositories.ErrCreatingAuditLog, err)
+	}
+
+	return
+}
+
+func (a *AuditLog) CountAll(req repositories.CountAllAuditLogsRequest) (repositories.CountAllAuditLogsResponse, error) {
+	where, args := models.AuditLogWhere(req.Filters)
+	q := `
+		SELECT COUNT(id) AS total
+		FROM audit_logs` + where
+
+	var count int64
+	row := a.db.QueryRowx(q, args...)
+	if err := row.Scan(&count); err != nil {
+		return repositories.CountAllAuditLogsResponse{}, fmt.Errorf("[audit_log_sqlx_mysql:CountAll %w: %w]", repositories.ErrCountingAuditLogs, err)
+	}
+
+	return repositories.CountAllAuditLogsResponse{Total: count}, nil
+}
+
+func (a *AuditLog) GetAll(req repositories.GetAllAuditLogsRequest) (res repositories.GetAllAuditLogsResponse, err error) {
+	where, args := models.AuditLogWhere(req.Filters)
+	q := `
+		SELECT id, actor_id, action, target_type, target_id, before_state, after_state, ip, request_id, created_at
+		FROM audit_logs` + where + `
+		ORDER BY created_at DESC
+		LIMIT ? OFFSET ?`
+
+	offset, limit := db.PaginateValues(req.Pagination.Page(), req.Pagination.Size())
+	args = append(args, limit, offset)
+
+	rows, err := a.db.Queryx(q, args...)
+	if err != nil {
+		return res, fmt.Errorf("[audit_log_sqlx_mysql:GetAll %w: %w]", repositories.ErrGettingAuditLogs, err)
+	}
+	defer rows.Close()
+
+	auditLogs := make([]entities.AuditLog, 0, limit)
+	for rows.Next() {
+		var model models.AuditLog
+		if err := rows.StructScan(&model); err != nil {
+			return repositories.GetAllAuditLogsResponse{}, fmt.Errorf("[audit_log_sqlx_mysql:GetAll %w: %w]", repositories.ErrGettingAuditLogs, err)
+		}
+		auditLog, err := model.Entity()
+		if err != nil {
+			return repositories.GetAllAuditLogsResponse{}, fmt.Errorf("[audit_log_sqlx_mysql:GetAll %w: %w]", repositories.ErrGettingAuditLogs, err)
+		}
+
+		auditLogs = append(auditLogs, auditLog)
+	}
+
+	return repositories.GetAllAuditLogsResponse{
+		AuditLogs: auditLogs,
+	}, nil
+}
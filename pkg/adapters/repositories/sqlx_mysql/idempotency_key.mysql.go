@@ -0,0 +1,118 @@
+package sqlx_mysql
+
+import (
+	"fmt"
+	"go-clean-api/pkg/adapters/db"
+	"go-clean-api/pkg/adapters/models"
+	domainerr "go-clean-api/pkg/domain/errors"
+	"go-clean-api/pkg/domain/repositories"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// IdempotencyKey is an implementation of the IdempotencyKeyRepository interface
+type IdempotencyKey struct {
+	db sqlx.Ext
+}
+
+// NewIdempotencyKey creates a new IdempotencyKeyMysqlRepository
+func NewIdempotencyKey(db *db.SqlxMySQL) *IdempotencyKey {
+	return &IdempotencyKey{db: db.DB}
+}
+
+func (k *IdempotencyKey) Create(req repositories.CreateIdempotencyKeyRequest) (res repositories.CreateIdempotencyKeyResponse, err error) {
+	_, err = k.db.Exec(`
+		INSERT INTO idempotency_keys (`+"`key`"+`, fingerprint, status, created_at, expires_at)
+		VALUES (?, ?, ?, ?, ?)`,
+		req.Key,
+		req.Fingerprint,
+		req.Status,
+		req.CreatedAt.SQL(),
+		req.ExpiresAt.SQL(),
+	)
+	if err != nil {
+		if db.IsDuplicateEntryError(err) {
+			return res, fmt.Errorf("[idempotency_key_sqlx_mysql:Create %w: %w]", domainerr.ErrConflict, err)
+		}
+		return res, fmt.Errorf("[idempotency_key_sqlx_mysql:Create %w: %w]", repositories.ErrCreatingIdempotencyKey, err)
+	}
+
+	return
+}
+
+func (k *IdempotencyKey) GetByKey(req repositories.GetIdempotencyKeyRequest) (res repositories.GetIdempotencyKeyResponse, err error) {
+	var model models.IdempotencyKey
+	row := k.db.QueryRowx(`
+		SELECT `+"`key`"+`, fingerprint, status, response_status, response_headers, response_body, created_at, expires_at
+		FROM idempotency_keys
+		WHERE `+"`key`"+` = ?
+		LIMIT 1`,
+		req.Key,
+	)
+	if err = row.StructScan(&model); err != nil {
+		return res, fmt.Errorf("[idempotency_key_sqlx_mysql:GetByKey %w: %w]", domainerr.ErrNotFound, err)
+	}
+
+	key, err := model.Entity()
+	if err != nil {
+		return res, fmt.Errorf("[idempotency_key_sqlx_mysql:GetByKey %w: %w]", repositories.ErrGettingIdempotencyKey, err)
+	}
+	res.IdempotencyKey = key
+
+	return
+}
+
+func (k *IdempotencyKey) Complete(req repositories.CompleteIdempotencyKeyRequest) (res repositories.CompleteIdempotencyKeyResponse, err error) {
+	headers, err := models.IdempotencyKeyResponseHeaders(req.ResponseHeaders)
+	if err != nil {
+		return res, fmt.Errorf("[idempotency_key_sqlx_mysql:Complete %w: %w]", repositories.ErrUpdatingIdempotencyKey, err)
+	}
+
+	_, err = k.db.Exec(`
+		UPDATE idempotency_keys
+		SET status = 'completed', response_status = ?, response_headers = ?, response_body = ?
+		WHERE `+"`key`"+` = ?`,
+		req.ResponseStatus,
+		headers,
+		req.ResponseBody,
+		req.Key,
+	)
+	if err != nil {
+		return res, fmt.Errorf("[idempotency_key_sqlx_mysql:Complete %w: %w]", repositories.ErrUpdatingIdempotencyKey, err)
+	}
+
+	return
+}
+
+func (k *IdempotencyKey) Delete(req repositories.DeleteIdempotencyKeyRequest) (res repositories.DeleteIdempotencyKeyResponse, err error) {
+	query, args := "DELETE FROM idempotency_keys WHERE `key` = ?", []any{req.Key}
+	if req.CreatedAt != nil {
+		query, args = query+" AND created_at = ?", append(args, req.CreatedAt.SQL())
+	}
+
+	result, err := k.db.Exec(query, args...)
+	if err != nil {
+		return res, fmt.Errorf("[idempotency_key_sqlx_mysql:Delete %w: %w]", repositories.ErrDeletingIdempotencyKeys, err)
+	}
+
+	res.Deleted, err = result.RowsAffected()
+	if err != nil {
+		return res, fmt.Errorf("[idempotency_key_sqlx_mysql:Delete %w: %w]", repositories.ErrDeletingIdempotencyKeys, err)
+	}
+
+	return
+}
+
+func (k *IdempotencyKey) DeleteExpired(req repositories.DeleteExpiredIdempotencyKeysRequest) (res repositories.DeleteExpiredIdempotencyKeysResponse, err error) {
+	result, err := k.db.Exec("DELETE FROM idempotency_keys WHERE expires_at <= ?", req.Now.SQL())
+	if err != nil {
+		return res, fmt.Errorf("[idempotency_key_sqlx_mysql:DeleteExpired %w: %w]", repositories.ErrDeletingIdempotencyKeys, err)
+	}
+
+	res.Deleted, err = result.RowsAffected()
+	if err != nil {
+		return res, fmt.Errorf("[idempotency_key_sqlx_mysql:DeleteExpired %w: %w]", repositories.ErrDeletingIdempotencyKeys, err)
+	}
+
+	return
+}
@@ -0,0 +1,144 @@
+package sqlx_mysql
+
+import (
+	"fmt"
+	"go-clean-api/pkg/adapters/db"
+	"go-clean-api/pkg/adapters/models"
+	"go-clean-api/pkg/domain/entities"
+	"go-clean-api/pkg/domain/repositories"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Outbox is an implementation of the OutboxRepository interface
+type Outbox struct {
+	db sqlx.Ext
+}
+
+// NewOutbox creates a new OutboxMysqlRepository
+func NewOutbox(db *db.SqlxMySQL) *Outbox {
+	return &Outbox{db: db.DB}
+}
+
+func (o *Outbox) Add(req repositories.AddOutboxEventRequest) (res repositories.AddOutboxEventResponse, err error) {
+	_, err = o.db.Exec(`
+		INSERT INTO outbox_events (id, name, aggregate_id, payload, request_id, occurred_at, attempts, next_attempt_at)
+		VALUES (?, ?, ?, ?, ?, ?, 0, ?)`,
+		req.ID.String(),
+		req.Name,
+		req.AggregateID,
+		string(req.Payload),
+		req.RequestID,
+		req.OccurredAt.SQL(),
+		req.NextAttemptAt.SQL(),
+	)
+	if err != nil {
+		return res, fmt.Errorf("[outbox_sqlx_mysql:Add %w: %w]", repositories.ErrAddingOutboxEvent, err)
+	}
+
+	return
+}
+
+func (o *Outbox) GetPending(req repositories.GetPendingOutboxEventsRequest) (res repositories.GetPendingOutboxEventsResponse, err error) {
+	rows, err := o.db.Queryx(`
+		SELECT id, name, aggregate_id, payload, request_id, occurred_at, attempts, last_error, next_attempt_at
+		FROM outbox_events
+		WHERE dispatched_at IS NULL
+			AND failed_at IS NULL
+			AND next_attempt_at <= ?
+		ORDER BY occurred_at
+		LIMIT ?
+		FOR UPDATE SKIP LOCKED`,
+		req.Now.SQL(),
+		req.Limit,
+	)
+	if err != nil {
+		return res, fmt.Errorf("[outbox_sqlx_mysql:GetPending %w: %w]", repositories.ErrGettingOutboxEvents, err)
+	}
+	defer rows.Close()
+
+	events := make([]entities.OutboxEvent, 0, req.Limit)
+	for rows.Next() {
+		var model models.OutboxEvent
+		if err := rows.StructScan(&model); err != nil {
+			return res, fmt.Errorf("[outbox_sqlx_mysql:GetPending %w: %w]", repositories.ErrGettingOutboxEvents, err)
+		}
+		event, err := model.Entity()
+		if err != nil {
+			return res, fmt.Errorf("[outbox_sqlx_mysql:GetPending %w: %w]", repositories.ErrGettingOutboxEvents, err)
+		}
+
+		events = append(events, event)
+	}
+
+	return repositories.GetPendingOutboxEventsResponse{
+		Events: events,
+	}, nil
+}
+
+func (o *Outbox) Lease(req repositories.LeaseOutboxEventsRequest) (res repositories.LeaseOutboxEventsResponse, err error) {
+	if len(req.IDs) == 0 {
+		return
+	}
+
+	ids := make([]string, len(req.IDs))
+	for i, id := range req.IDs {
+		ids[i] = id.String()
+	}
+
+	query, args, err := sqlx.In(`
+		UPDATE outbox_events
+		SET next_attempt_at = ?
+		WHERE id IN (?)`,
+		req.Until.SQL(),
+		ids,
+	)
+	if err != nil {
+		return res, fmt.Errorf("[outbox_sqlx_mysql:Lease %w: %w]", repositories.ErrUpdatingOutboxEvent, err)
+	}
+
+	_, err = o.db.Exec(o.db.Rebind(query), args...)
+	if err != nil {
+		return res, fmt.Errorf("[outbox_sqlx_mysql:Lease %w: %w]", repositories.ErrUpdatingOutboxEvent, err)
+	}
+
+	return
+}
+
+func (o *Outbox) MarkDispatched(req repositories.MarkOutboxEventDispatchedRequest) (res repositories.MarkOutboxEventResponse, err error) {
+	_, err = o.db.Exec(`
+		UPDATE outbox_events
+		SET dispatched_at = ?, attempts = attempts + 1, last_error = NULL
+		WHERE id = ?`,
+		req.DispatchedAt.SQL(),
+		req.ID.String(),
+	)
+	if err != nil {
+		return res, fmt.Errorf("[outbox_sqlx_mysql:MarkDispatched %w: %w]", repositories.ErrUpdatingOutboxEvent, err)
+	}
+
+	return
+}
+
+func (o *Outbox) MarkFailed(req repositories.MarkOutboxEventFailedRequest) (res repositories.MarkOutboxEventResponse, err error) {
+	var failedAt any
+	if req.FailedAt != nil {
+		failedAt = req.FailedAt.SQL()
+	}
+
+	_, err = o.db.Exec(`
+		UPDATE outbox_events
+		SET attempts = ?, last_error = ?, next_attempt_at = ?, failed_at = ?
+		WHERE id = ?`,
+		req.Attempts,
+		req.LastError,
+		req.NextAttemptAt.SQL(),
+		failedAt,
+		req.ID.String(),
+	)
+	if err != nil {
+		return res, fmt.Errorf("[outbox_sqlx_mysql:MarkFailed %w: %w]", repositories.ErrUpdatingOutboxEvent, err)
+	}
+
+	return
+}
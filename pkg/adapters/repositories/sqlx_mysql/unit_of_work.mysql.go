@@ -0,0 +1,69 @@
+package sqlx_mysql
+
+import (
+	"errors"
+	"go-clean-api/pkg/adapters/db"
+	"go-clean-api/pkg/domain/repositories"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// UnitOfWork is an implementation of the UnitOfWork interface using sqlx transactions
+type UnitOfWork struct {
+	db         *sqlx.DB
+	maxRetries int
+}
+
+// NewUnitOfWork creates a new UnitOfWork
+func NewUnitOfWork(db *db.SqlxMySQL) *UnitOfWork {
+	return &UnitOfWork{db: db.DB, maxRetries: db.TransactionMaxRetries()}
+}
+
+// Do runs fn in a transaction which is committed if fn returns nil and rolled back otherwise.
+// The transaction is retried on deadlock.
+func (u *UnitOfWork) Do(fn func(repos repositories.Repositories) error) error {
+	return db.RetryTransaction(u.maxRetries, func() error {
+		return u.transaction(fn)
+	})
+}
+
+// transaction runs fn in a single transaction.
+func (u *UnitOfWork) transaction(fn func(repos repositories.Repositories) error) (err error) {
+	tx, err := u.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err = fn(&txRepositories{tx: tx}); err != nil {
+		if errRollback := tx.Rollback(); errRollback != nil {
+			return errors.Join(err, errRollback)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// txRepositories gives access to the repositories bound to a sqlx transaction
+type txRepositories struct {
+	tx *sqlx.Tx
+}
+
+func (r *txRepositories) User() repositories.User {
+	return &User{db: r.tx}
+}
+
+func (r *txRepositories) AuditLog() repositories.AuditLog {
+	return &AuditLog{db: r.tx}
+}
+
+func (r *txRepositories) Outbox() repositories.Outbox {
+	return &Outbox{db: r.tx}
+}
@@ -13,7 +13,7 @@ import (
 
 // User is an implementation of the UserRepository interface
 type User struct {
-	db *sqlx.DB
+	db sqlx.Ext
 }
 
 // NewUser creates a new UserMysqlRepository
@@ -32,12 +32,12 @@ func (u *User) GetByEmail(req repositories.GetByEmailRequest) (repositories.GetB
 		req.Email.Value(),
 	)
 	if err := row.StructScan(&model); err != nil {
-		return repositories.GetByEmailResponse{}, fmt.Errorf("[user_sqlx_mysql:GetByEmail %w: %s]", domainerr.ErrNotFound, err)
+		return repositories.GetByEmailResponse{}, fmt.Errorf("[user_sqlx_mysql:GetByEmail %w: %w]", domainerr.ErrNotFound, err)
 	}
 
 	response, err := model.Repository()
 	if err != nil {
-		return repositories.GetByEmailResponse{}, fmt.Errorf("[user_sqlx_mysql:GetByEmail %w: %s]", repositories.ErrGettingUser, err)
+		return repositories.GetByEmailResponse{}, fmt.Errorf("[user_sqlx_mysql:GetByEmail %w: %w]", repositories.ErrGettingUser, err)
 	}
 
 	return response, nil
@@ -57,7 +57,10 @@ func (u *User) Create(req repositories.CreateUserRequest) (res repositories.Crea
 	)
 
 	if err != nil {
-		return
+		if db.IsDuplicateEntryError(err) {
+			return res, fmt.Errorf("[user_sqlx_mysql:Create %w: %w]", domainerr.ErrConflict, err)
+		}
+		return res, fmt.Errorf("[user_sqlx_mysql:Create %w: %w]", repositories.ErrCreatingUser, err)
 	}
 
 	return repositories.CreateUserResponse{
@@ -69,27 +72,34 @@ func (u *User) Create(req repositories.CreateUserRequest) (res repositories.Crea
 			Firstname: req.Firstname,
 			CreatedAt: req.CreatedAt,
 			UpdatedAt: req.UpdatedAt,
+			Version:   1,
 		},
 	}, nil
 }
 
 func (u *User) GetByID(req repositories.GetByIDRequest) (res repositories.GetByIDResponse, err error) {
-	var model models.User
-	row := u.db.QueryRowx(`
-		SELECT id, email, lastname, firstname, created_at, updated_at, deleted_at
+	q := `
+		SELECT id, email, lastname, firstname, created_at, updated_at, deleted_at, version
 		FROM users
-		WHERE id = ?
-			AND deleted_at IS NULL
-		LIMIT 1`,
-		req.ID.String(),
-	)
+		WHERE id = ?`
+
+	if req.Deleted {
+		q += " AND deleted_at IS NOT NULL"
+	} else {
+		q += " AND deleted_at IS NULL"
+	}
+
+	q += " LIMIT 1"
+
+	var model models.User
+	row := u.db.QueryRowx(q, req.ID.String())
 	if err = row.StructScan(&model); err != nil {
-		return repositories.GetByIDResponse{}, fmt.Errorf("[user_sqlx_mysql:GetByID %w: %s]", domainerr.ErrNotFound, err)
+		return repositories.GetByIDResponse{}, fmt.Errorf("[user_sqlx_mysql:GetByID %w: %w]", domainerr.ErrNotFound, err)
 	}
 
 	user, err := model.Entity()
 	if err != nil {
-		return repositories.GetByIDResponse{}, fmt.Errorf("[user_sqlx_mysql:GetByID %w: %s]", repositories.ErrGettingUser, err)
+		return repositories.GetByIDResponse{}, fmt.Errorf("[user_sqlx_mysql:GetByID %w: %w]", repositories.ErrGettingUser, err)
 	}
 
 	res.User = user
@@ -111,7 +121,7 @@ func (u *User) CountAll(req repositories.CountAllRequest) (repositories.CountAll
 	var count int64
 	row := u.db.QueryRowx(q)
 	if err := row.Scan(&count); err != nil {
-		return repositories.CountAllResponse{}, fmt.Errorf("[user_sqlx_mysql:CountAll %w: %s]", repositories.ErrCountingUsers, err)
+		return repositories.CountAllResponse{}, fmt.Errorf("[user_sqlx_mysql:CountAll %w: %w]", repositories.ErrCountingUsers, err)
 	}
 
 	return repositories.CountAllResponse{Total: count}, nil
@@ -119,7 +129,7 @@ func (u *User) CountAll(req repositories.CountAllRequest) (repositories.CountAll
 
 func (u *User) GetAll(req repositories.GetAllRequest) (res repositories.GetAllResponse, err error) {
 	q := `
-		SELECT id, email, lastname, firstname, created_at, updated_at, deleted_at
+		SELECT id, email, lastname, firstname, created_at, updated_at, deleted_at, version
 		FROM users`
 
 	if req.Deleted {
@@ -142,11 +152,11 @@ func (u *User) GetAll(req repositories.GetAllRequest) (res repositories.GetAllRe
 	for rows.Next() {
 		var model models.User
 		if err := rows.StructScan(&model); err != nil {
-			return repositories.GetAllResponse{}, fmt.Errorf("[user_sqlx_mysql:GetAll %w: %s]", repositories.ErrGettingUsers, err)
+			return repositories.GetAllResponse{}, fmt.Errorf("[user_sqlx_mysql:GetAll %w: %w]", repositories.ErrGettingUsers, err)
 		}
 		user, err := model.Entity()
 		if err != nil {
-			return repositories.GetAllResponse{}, fmt.Errorf("[user_sqlx_mysql:GetAll %w: %s]", repositories.ErrGettingUsers, err)
+			return repositories.GetAllResponse{}, fmt.Errorf("[user_sqlx_mysql:GetAll %w: %w]", repositories.ErrGettingUsers, err)
 		}
 
 		users = append(users, user)
@@ -157,23 +167,71 @@ func (u *User) GetAll(req repositories.GetAllRequest) (res repositories.GetAllRe
 	}, nil
 }
 
+func (u *User) StreamAll(req repositories.StreamAllRequest) (res repositories.StreamAllResponse, err error) {
+	q := `
+		SELECT id, email, lastname, firstname, created_at, updated_at, deleted_at, version
+		FROM users`
+
+	if req.Deleted {
+		q += " WHERE deleted_at IS NOT NULL"
+	} else {
+		q += " WHERE deleted_at IS NULL"
+	}
+
+	q += " LIMIT ? OFFSET ?"
+
+	offset, limit := db.PaginateValues(req.Pagination.Page(), req.Pagination.Size())
+
+	rows, err := u.db.Queryx(q, limit, offset)
+	if err != nil {
+		return res, fmt.Errorf("[user_sqlx_mysql:StreamAll %w: %w]", repositories.ErrGettingUsers, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var model models.User
+		if err := rows.StructScan(&model); err != nil {
+			return res, fmt.Errorf("[user_sqlx_mysql:StreamAll %w: %w]", repositories.ErrGettingUsers, err)
+		}
+		user, err := model.Entity()
+		if err != nil {
+			return res, fmt.Errorf("[user_sqlx_mysql:StreamAll %w: %w]", repositories.ErrGettingUsers, err)
+		}
+
+		if err := req.Each(user); err != nil {
+			return res, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return res, fmt.Errorf("[user_sqlx_mysql:StreamAll %w: %w]", repositories.ErrGettingUsers, err)
+	}
+
+	return
+}
+
 func (u *User) Delete(req repositories.DeleteRestoreRequest) (res repositories.DeleteRestoreResponse, err error) {
 	result, err := u.db.Exec(`
 		UPDATE users
-		SET deleted_at = NOW()
+		SET deleted_at = NOW(), version = version + 1
 		WHERE id = ?
-			AND deleted_at IS NULL`,
+			AND deleted_at IS NULL
+			AND (? = 0 OR version = ?)`,
 		req.ID.String(),
+		req.Version,
+		req.Version,
 	)
 	if err != nil {
-		return repositories.DeleteRestoreResponse{}, fmt.Errorf("[user_sqlx_mysql:Delete %w: %s]", domainerr.ErrDatabase, err)
+		return repositories.DeleteRestoreResponse{}, fmt.Errorf("[user_sqlx_mysql:Delete %w: %w]", domainerr.ErrDatabase, err)
 	}
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return repositories.DeleteRestoreResponse{}, fmt.Errorf("[user_sqlx_mysql:Delete %w: %s]", domainerr.ErrDatabase, err)
+		return repositories.DeleteRestoreResponse{}, fmt.Errorf("[user_sqlx_mysql:Delete %w: %w]", domainerr.ErrDatabase, err)
 	}
 	if rowsAffected == 0 {
-		return repositories.DeleteRestoreResponse{}, fmt.Errorf("[user_sqlx_mysql:Delete %w: %s]", domainerr.ErrNotFound, err)
+		if req.Version != 0 {
+			return repositories.DeleteRestoreResponse{}, fmt.Errorf("[user_sqlx_mysql:Delete %w]", domainerr.ErrPreconditionFailed)
+		}
+		return repositories.DeleteRestoreResponse{}, fmt.Errorf("[user_sqlx_mysql:Delete %w]", domainerr.ErrNotFound)
 	}
 
 	return
@@ -182,21 +240,53 @@ func (u *User) Delete(req repositories.DeleteRestoreRequest) (res repositories.D
 func (u *User) Restore(req repositories.DeleteRestoreRequest) (res repositories.DeleteRestoreResponse, err error) {
 	result, err := u.db.Exec(`
 		UPDATE users
-		SET deleted_at = NULL
+		SET deleted_at = NULL, version = version + 1
+		WHERE id = ?
+			AND deleted_at IS NOT NULL
+			AND (? = 0 OR version = ?)`,
+		req.ID.String(),
+		req.Version,
+		req.Version,
+	)
+	if err != nil {
+		return repositories.DeleteRestoreResponse{}, fmt.Errorf("[user_sqlx_mysql:Restore %w: %w]", domainerr.ErrDatabase, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return repositories.DeleteRestoreResponse{}, fmt.Errorf("[user_sqlx_mysql:Restore %w: %w]", domainerr.ErrDatabase, err)
+	}
+	if rowsAffected == 0 {
+		if req.Version != 0 {
+			return repositories.DeleteRestoreResponse{}, fmt.Errorf("[user_sqlx_mysql:Restore %w]", domainerr.ErrPreconditionFailed)
+		}
+		return repositories.DeleteRestoreResponse{}, fmt.Errorf("[user_sqlx_mysql:Restore %w]", domainerr.ErrNotFound)
+	}
+
+	return
+}
+
+func (u *User) UpdatePassword(req repositories.UpdatePasswordRequest) (res repositories.UpdatePasswordResponse, err error) {
+	result, err := u.db.Exec(`
+		UPDATE users
+		SET password = ?
 		WHERE id = ?
-			AND deleted_at IS NOT NULL`,
+			AND password = ?
+			AND deleted_at IS NULL`,
+		req.Password.Value(),
 		req.ID.String(),
+		req.OldPassword.Value(),
 	)
 	if err != nil {
-		return repositories.DeleteRestoreResponse{}, fmt.Errorf("[user_sqlx_mysql:Restore %w: %s]", domainerr.ErrDatabase, err)
+		return res, fmt.Errorf("[user_sqlx_mysql:UpdatePassword %w: %w]", repositories.ErrUpdatingUser, err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return repositories.DeleteRestoreResponse{}, fmt.Errorf("[user_sqlx_mysql:Restore %w: %s]", domainerr.ErrDatabase, err)
+		return res, fmt.Errorf("[user_sqlx_mysql:UpdatePassword %w: %w]", repositories.ErrUpdatingUser, err)
 	}
 	if rowsAffected == 0 {
-		return repositories.DeleteRestoreResponse{}, fmt.Errorf("[user_sqlx_mysql:Restore %w: %s]", domainerr.ErrNotFound, err)
+		return res, fmt.Errorf("[user_sqlx_mysql:UpdatePassword %w]", domainerr.ErrNotFound)
 	}
 
 	return
@@ -0,0 +1,312 @@
+package sqlx_mysql
+
+import (
+	"fmt"
+	"go-clean-api/pkg/adapters/db"
+	"go-clean-api/pkg/adapters/models"
+	"go-clean-api/pkg/domain/entities"
+	domainerr "go-clean-api/pkg/domain/errors"
+	"go-clean-api/pkg/domain/repositories"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Webhook is an implementation of the WebhookRepository interface
+type Webhook struct {
+	db sqlx.Ext
+}
+
+// NewWebhook creates a new WebhookMysqlRepository
+func NewWebhook(db *db.SqlxMySQL) *Webhook {
+	return &Webhook{db: db.DB}
+}
+
+func (w *Webhook) Create(req repositories.CreateWebhookRequest) (res repositories.CreateWebhookResponse, err error) {
+	values, err := models.WebhookInsertValues(req.Webhook)
+	if err != nil {
+		return res, fmt.Errorf("[webhook_sqlx_mysql:Create %w: %w]", repositories.ErrCreatingWebhook, err)
+	}
+
+	_, err = w.db.Exec(`
+		INSERT INTO webhooks (id, url, secret, events, enabled, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		values...,
+	)
+	if err != nil {
+		return res, fmt.Errorf("[webhook_sqlx_mysql:Create %w: %w]", repositories.ErrCreatingWebhook, err)
+	}
+	res.Webhook = req.Webhook
+
+	return
+}
+
+func (w *Webhook) GetByID(req repositories.GetWebhookByIDRequest) (res repositories.GetWebhookByIDResponse, err error) {
+	var model models.Webhook
+	row := w.db.QueryRowx(`
+		SELECT id, url, secret, events, enabled, consecutive_failures, created_at, updated_at, disabled_at
+		FROM webhooks
+		WHERE id = ?
+		LIMIT 1`,
+		req.ID.String(),
+	)
+	if err = row.StructScan(&model); err != nil {
+		return res, fmt.Errorf("[webhook_sqlx_mysql:GetByID %w: %w]", domainerr.ErrNotFound, err)
+	}
+
+	webhook, err := model.Entity()
+	if err != nil {
+		return res, fmt.Errorf("[webhook_sqlx_mysql:GetByID %w: %w]", repositories.ErrGettingWebhooks, err)
+	}
+	res.Webhook = webhook
+
+	return
+}
+
+func (w *Webhook) GetAll(req repositories.GetAllWebhooksRequest) (res repositories.GetAllWebhooksResponse, err error) {
+	offset, limit := db.PaginateValues(req.Pagination.Page(), req.Pagination.Size())
+
+	res.Webhooks, err = w.selectWebhooks(`
+		SELECT id, url, secret, events, enabled, consecutive_failures, created_at, updated_at, disabled_at
+		FROM webhooks
+		ORDER BY created_at
+		LIMIT ? OFFSET ?`,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return res, fmt.Errorf("[webhook_sqlx_mysql:GetAll %w: %w]", repositories.ErrGettingWebhooks, err)
+	}
+
+	return
+}
+
+func (w *Webhook) CountAll(req repositories.CountAllWebhooksRequest) (repositories.CountAllWebhooksResponse, error) {
+	var count int64
+	row := w.db.QueryRowx("SELECT COUNT(id) AS total FROM webhooks")
+	if err := row.Scan(&count); err != nil {
+		return repositories.CountAllWebhooksResponse{}, fmt.Errorf("[webhook_sqlx_mysql:CountAll %w: %w]", repositories.ErrGettingWebhooks, err)
+	}
+
+	return repositories.CountAllWebhooksResponse{Total: count}, nil
+}
+
+func (w *Webhook) GetEnabledByEvent(req repositories.GetEnabledWebhooksByEventRequest) (res repositories.GetEnabledWebhooksByEventResponse, err error) {
+	res.Webhooks, err = w.selectWebhooks(`
+		SELECT id, url, secret, events, enabled, consecutive_failures, created_at, updated_at, disabled_at
+		FROM webhooks
+		WHERE enabled = 1
+			AND JSON_CONTAINS(events, JSON_QUOTE(?))`,
+		req.EventName,
+	)
+	if err != nil {
+		return res, fmt.Errorf("[webhook_sqlx_mysql:GetEnabledByEvent %w: %w]", repositories.ErrGettingWebhooks, err)
+	}
+
+	return
+}
+
+func (w *Webhook) Delete(req repositories.DeleteWebhookRequest) (res repositories.DeleteWebhookResponse, err error) {
+	result, err := w.db.Exec("DELETE FROM webhooks WHERE id = ?", req.ID.String())
+	if err != nil {
+		return res, fmt.Errorf("[webhook_sqlx_mysql:Delete %w: %w]", domainerr.ErrDatabase, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return res, fmt.Errorf("[webhook_sqlx_mysql:Delete %w: %w]", domainerr.ErrDatabase, err)
+	}
+	if rowsAffected == 0 {
+		return res, fmt.Errorf("[webhook_sqlx_mysql:Delete %w]", domainerr.ErrNotFound)
+	}
+
+	return
+}
+
+func (w *Webhook) Enable(req repositories.EnableWebhookRequest) (res repositories.EnableWebhookResponse, err error) {
+	_, err = w.db.Exec(`
+		UPDATE webhooks
+		SET enabled = 1, consecutive_failures = 0, disabled_at = NULL, updated_at = ?
+		WHERE id = ?`,
+		req.UpdatedAt.SQL(),
+		req.ID.String(),
+	)
+	if err != nil {
+		return res, fmt.Errorf("[webhook_sqlx_mysql:Enable %w: %w]", repositories.ErrUpdatingWebhook, err)
+	}
+
+	return
+}
+
+func (w *Webhook) RecordSuccess(req repositories.RecordWebhookSuccessRequest) (res repositories.RecordWebhookResultResponse, err error) {
+	_, err = w.db.Exec(`
+		UPDATE webhooks
+		SET consecutive_failures = 0
+		WHERE id = ?`,
+		req.ID.String(),
+	)
+	if err != nil {
+		return res, fmt.Errorf("[webhook_sqlx_mysql:RecordSuccess %w: %w]", repositories.ErrUpdatingWebhook, err)
+	}
+
+	return
+}
+
+func (w *Webhook) RecordFailure(req repositories.RecordWebhookFailureRequest) (res repositories.RecordWebhookResultResponse, err error) {
+	// Assignments are evaluated from left to right, so consecutive_failures is already incremented
+	// when enabled and disabled_at are computed.
+	_, err = w.db.Exec(`
+		UPDATE webhooks
+		SET consecutive_failures = consecutive_failures + 1,
+			disabled_at = IF(enabled = 1 AND consecutive_failures >= ?, ?, disabled_at),
+			enabled = IF(consecutive_failures >= ?, 0, enabled)
+		WHERE id = ?`,
+		req.DisableAfter,
+		req.Now.SQL(),
+		req.DisableAfter,
+		req.ID.String(),
+	)
+	if err != nil {
+		return res, fmt.Errorf("[webhook_sqlx_mysql:RecordFailure %w: %w]", repositories.ErrUpdatingWebhook, err)
+	}
+
+	return
+}
+
+func (w *Webhook) CreateDelivery(req repositories.CreateWebhookDeliveryRequest) (res repositories.CreateWebhookDeliveryResponse, err error) {
+	_, err = w.db.Exec(`
+		INSERT INTO webhook_deliveries (id, webhook_id, event_id, event_name, payload, attempt, status_code, success, error, duration_ms, created_at, next_attempt_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		models.WebhookDeliveryInsertValues(req.WebhookDelivery)...,
+	)
+	if err != nil {
+		return res, fmt.Errorf("[webhook_sqlx_mysql:CreateDelivery %w: %w]", repositories.ErrCreatingWebhookDelivery, err)
+	}
+
+	return
+}
+
+func (w *Webhook) GetDeliveryByID(req repositories.GetWebhookDeliveryByIDRequest) (res repositories.GetWebhookDeliveryByIDResponse, err error) {
+	var model models.WebhookDelivery
+	row := w.db.QueryRowx(`
+		SELECT id, webhook_id, event_id, event_name, payload, attempt, status_code, success, error, duration_ms, created_at, next_attempt_at
+		FROM webhook_deliveries
+		WHERE id = ?
+			AND webhook_id = ?
+		LIMIT 1`,
+		req.ID.String(),
+		req.WebhookID.String(),
+	)
+	if err = row.StructScan(&model); err != nil {
+		return res, fmt.Errorf("[webhook_sqlx_mysql:GetDeliveryByID %w: %w]", domainerr.ErrNotFound, err)
+	}
+
+	delivery, err := model.Entity()
+	if err != nil {
+		return res, fmt.Errorf("[webhook_sqlx_mysql:GetDeliveryByID %w: %w]", repositories.ErrGettingWebhookDeliveries, err)
+	}
+	res.WebhookDelivery = delivery
+
+	return
+}
+
+func (w *Webhook) GetLastDelivery(req repositories.GetLastWebhookDeliveryRequest) (res repositories.GetLastWebhookDeliveryResponse, err error) {
+	var model models.WebhookDelivery
+	row := w.db.QueryRowx(`
+		SELECT id, webhook_id, event_id, event_name, payload, attempt, status_code, success, error, duration_ms, created_at, next_attempt_at
+		FROM webhook_deliveries
+		WHERE webhook_id = ?
+			AND event_id = ?
+		ORDER BY created_at DESC, attempt DESC
+		LIMIT 1`,
+		req.WebhookID.String(),
+		req.EventID,
+	)
+	if err = row.StructScan(&model); err != nil {
+		return res, fmt.Errorf("[webhook_sqlx_mysql:GetLastDelivery %w: %w]", domainerr.ErrNotFound, err)
+	}
+
+	delivery, err := model.Entity()
+	if err != nil {
+		return res, fmt.Errorf("[webhook_sqlx_mysql:GetLastDelivery %w: %w]", repositories.ErrGettingWebhookDeliveries, err)
+	}
+	res.WebhookDelivery = delivery
+
+	return
+}
+
+func (w *Webhook) GetDeliveries(req repositories.GetWebhookDeliveriesRequest) (res repositories.GetWebhookDeliveriesResponse, err error) {
+	offset, limit := db.PaginateValues(req.Pagination.Page(), req.Pagination.Size())
+
+	rows, err := w.db.Queryx(`
+		SELECT id, webhook_id, event_id, event_name, payload, attempt, status_code, success, error, duration_ms, created_at, next_attempt_at
+		FROM webhook_deliveries
+		WHERE webhook_id = ?
+		ORDER BY created_at DESC
+		LIMIT ? OFFSET ?`,
+		req.WebhookID.String(),
+		limit,
+		offset,
+	)
+	if err != nil {
+		return res, fmt.Errorf("[webhook_sqlx_mysql:GetDeliveries %w: %w]", repositories.ErrGettingWebhookDeliveries, err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]entities.WebhookDelivery, 0, limit)
+	for rows.Next() {
+		var model models.WebhookDelivery
+		if err := rows.StructScan(&model); err != nil {
+			return repositories.GetWebhookDeliveriesResponse{}, fmt.Errorf("[webhook_sqlx_mysql:GetDeliveries %w: %w]", repositories.ErrGettingWebhookDeliveries, err)
+		}
+		delivery, err := model.Entity()
+		if err != nil {
+			return repositories.GetWebhookDeliveriesResponse{}, fmt.Errorf("[webhook_sqlx_mysql:GetDeliveries %w: %w]", repositories.ErrGettingWebhookDeliveries, err)
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return repositories.GetWebhookDeliveriesResponse{
+		Deliveries: deliveries,
+	}, nil
+}
+
+func (w *Webhook) CountDeliveries(req repositories.CountWebhookDeliveriesRequest) (repositories.CountWebhookDeliveriesResponse, error) {
+	var count int64
+	row := w.db.QueryRowx(`
+		SELECT COUNT(id) AS total
+		FROM webhook_deliveries
+		WHERE webhook_id = ?`,
+		req.WebhookID.String(),
+	)
+	if err := row.Scan(&count); err != nil {
+		return repositories.CountWebhookDeliveriesResponse{}, fmt.Errorf("[webhook_sqlx_mysql:CountDeliveries %w: %w]", repositories.ErrGettingWebhookDeliveries, err)
+	}
+
+	return repositories.CountWebhookDeliveriesResponse{Total: count}, nil
+}
+
+// selectWebhooks runs the query and converts the rows to webhook entities
+func (w *Webhook) selectWebhooks(query string, args ...any) ([]entities.Webhook, error) {
+	rows, err := w.db.Queryx(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := make([]entities.Webhook, 0)
+	for rows.Next() {
+		var model models.Webhook
+		if err := rows.StructScan(&model); err != nil {
+			return nil, err
+		}
+		webhook, err := model.Entity()
+		if err != nil {
+			return nil, err
+		}
+
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, nil
+}
@@ -3,11 +3,15 @@ package pkg
 import (
 	"fmt"
 	"go-clean-api/pkg/apperr"
+	"net/http"
+	"net/netip"
+	"regexp"
 	"runtime"
 	"time"
 
 	"github.com/fabienbellanger/goutils"
 	"github.com/spf13/viper"
+	"golang.org/x/crypto/bcrypt"
 )
 
 // ConfigServer represents the configuration of the HTTP server
@@ -32,6 +36,9 @@ type ConfigServer struct {
 
 	// Maximal number of CPUs (Mst be lower than the number of CPUs of the machine)
 	MaxCPU int
+
+	// Directory of the panic reports (empty for none)
+	CrashDumpPath string
 }
 
 // NewConfigServer creates a new ConfigServer instance
@@ -61,6 +68,7 @@ func NewConfigServer() (*ConfigServer, error) {
 		BasicAuthUsername: viper.GetString("SERVER_BASICAUTH_USERNAME"),
 		BasicAuthPassword: viper.GetString("SERVER_BASICAUTH_PASSWORD"),
 		MaxCPU:            maxCPU,
+		CrashDumpPath:     viper.GetString("SERVER_CRASH_DUMP_PATH"),
 	}, nil
 }
 
@@ -104,6 +112,9 @@ type ConfigDatabase struct {
 
 	// Connection max idle time
 	ConnMaxIdleTime time.Duration
+
+	// Max retries of a transaction after a deadlock (0 = default)
+	TransactionMaxRetries int
 }
 
 // NewConfigDatabase creates a new ConfigDatabase instance
@@ -125,19 +136,20 @@ func NewConfigDatabase() (*ConfigDatabase, error) {
 	}
 
 	return &ConfigDatabase{
-		Driver:          driver,
-		Host:            viper.GetString("DB_HOST"),
-		Username:        viper.GetString("DB_USERNAME"),
-		Password:        viper.GetString("DB_PASSWORD"),
-		Port:            viper.GetInt("DB_PORT"),
-		Database:        database,
-		Charset:         viper.GetString("DB_CHARSET"),
-		Collation:       viper.GetString("DB_COLLATION"),
-		Location:        location,
-		MaxIdleConns:    viper.GetInt("DB_MAX_IDLE_CONNS"),
-		MaxOpenConns:    viper.GetInt("DB_MAX_OPEN_CONNS"),
-		ConnMaxLifetime: viper.GetDuration("DB_CONN_MAX_LIFETIME") * time.Hour,
-		ConnMaxIdleTime: viper.GetDuration("DB_CONN_MAX_IDLE_TIME") * time.Hour,
+		Driver:                driver,
+		Host:                  viper.GetString("DB_HOST"),
+		Username:              viper.GetString("DB_USERNAME"),
+		Password:              viper.GetString("DB_PASSWORD"),
+		Port:                  viper.GetInt("DB_PORT"),
+		Database:              database,
+		Charset:               viper.GetString("DB_CHARSET"),
+		Collation:             viper.GetString("DB_COLLATION"),
+		Location:              location,
+		MaxIdleConns:          viper.GetInt("DB_MAX_IDLE_CONNS"),
+		MaxOpenConns:          viper.GetInt("DB_MAX_OPEN_CONNS"),
+		ConnMaxLifetime:       viper.GetDuration("DB_CONN_MAX_LIFETIME") * time.Hour,
+		ConnMaxIdleTime:       viper.GetDuration("DB_CONN_MAX_IDLE_TIME") * time.Hour,
+		TransactionMaxRetries: viper.GetInt("DB_TRANSACTION_MAX_RETRIES"),
 	}, nil
 }
 
@@ -212,8 +224,56 @@ type ConfigLog struct {
 	// Level (debug | info | warn | error | fatal | panic)
 	Level string
 
+	// Backend of the server logger (zap | slog)
+	Backend string
+
 	// Enable access log
 	EnableAccessLog bool
+
+	// Rotation of the log files (server and GORM)
+	Rotation ConfigLogRotation
+
+	// Size of the write buffer of the log files in bytes (0 for unbuffered writes)
+	BufferSize int
+
+	// Interval between two flushes of the write buffer
+	FlushInterval time.Duration
+
+	// Default delay before reverting a runtime level change to the configured level (0 for no revert)
+	LevelRevertAfter time.Duration
+
+	// Redaction of the sensitive data
+	Redaction ConfigLogRedaction
+}
+
+// ConfigLogRedaction represents the configuration of the redaction of the sensitive data in the logs
+type ConfigLogRedaction struct {
+	// Enable the redaction (enabled if not set)
+	Enable bool
+
+	// Names of the fields redacted in addition to the default ones (password, token, authorization, etc.)
+	Fields []string
+
+	// Regular expressions of the data redacted in addition to the default ones (emails, JWTs, bearer tokens, password hashes)
+	Patterns []string
+}
+
+// ConfigLogRotation represents the configuration of the log files rotation
+type ConfigLogRotation struct {
+	// Maximum size of a file in megabytes (0 for no rotation by size)
+	MaxSize int
+
+	// Interval between two rotations (0 for no rotation by time, 24h to rotate at midnight)
+	Interval time.Duration
+
+	// Maximum number of rotated files (0 to keep all of them)
+	MaxBackups int
+
+	// Maximum age of the rotated files in days (0 to keep all of them)
+	MaxAge int
+
+	// Compress the rotated files with gzip
+	Compress bool
 }
 
 // NewConfigLog creates a new ConfigLog instance
@@ -236,11 +296,58 @@ func NewConfigLog() (*ConfigLog, error) {
 		return nil, apperr.NewAppErr(fmt.Errorf("error in configuration"), "missing log path", nil, nil)
 	}
 
+	backend := viper.GetString("LOG_BACKEND")
+	if backend == "" {
+		backend = "zap"
+	}
+	if backend != "zap" && backend != "slog" {
+		return nil, apperr.NewAppErr(fmt.Errorf("error in configuration"), "invalid log backend", nil, nil)
+	}
+
+	rotation := ConfigLogRotation{
+		MaxSize:    viper.GetInt("LOG_ROTATION_MAX_SIZE"),
+		Interval:   viper.GetDuration("LOG_ROTATION_INTERVAL"),
+		MaxBackups: viper.GetInt("LOG_ROTATION_MAX_BACKUPS"),
+		MaxAge:     viper.GetInt("LOG_ROTATION_MAX_AGE"),
+		Compress:   viper.GetBool("LOG_ROTATION_COMPRESS"),
+	}
+	if rotation.MaxSize < 0 || rotation.Interval < 0 || rotation.MaxBackups < 0 || rotation.MaxAge < 0 {
+		return nil, apperr.NewAppErr(fmt.Errorf("error in configuration"), "invalid log rotation", nil, nil)
+	}
+
+	bufferSize := viper.GetInt("LOG_BUFFER_SIZE")
+	flushInterval := viper.GetDuration("LOG_FLUSH_INTERVAL")
+	if bufferSize < 0 || (bufferSize > 0 && flushInterval <= 0) {
+		return nil, apperr.NewAppErr(fmt.Errorf("error in configuration"), "invalid log buffer", nil, nil)
+	}
+
+	levelRevertAfter := viper.GetDuration("LOG_LEVEL_REVERT_AFTER")
+	if levelRevertAfter < 0 {
+		return nil, apperr.NewAppErr(fmt.Errorf("error in configuration"), "invalid log level revert delay", nil, nil)
+	}
+
+	redaction := ConfigLogRedaction{
+		Enable:   !viper.IsSet("LOG_REDACT_ENABLE") || viper.GetBool("LOG_REDACT_ENABLE"),
+		Fields:   viper.GetStringSlice("LOG_REDACT_FIELDS"),
+		Patterns: viper.GetStringSlice("LOG_REDACT_PATTERNS"),
+	}
+	for _, p := range redaction.Patterns {
+		if _, err := regexp.Compile(p); err != nil {
+			return nil, apperr.NewAppErr(fmt.Errorf("error in configuration"), "invalid log redaction pattern", nil, nil)
+		}
+	}
+
 	return &ConfigLog{
-		Path:            path,
-		Outputs:         outputs,
-		Level:           level,
-		EnableAccessLog: viper.GetBool("LOG_ACCESS_ENABLE"),
+		Path:             path,
+		Outputs:          outputs,
+		Level:            level,
+		Backend:          backend,
+		EnableAccessLog:  viper.GetBool("LOG_ACCESS_ENABLE"),
+		Rotation:         rotation,
+		BufferSize:       bufferSize,
+		FlushInterval:    flushInterval,
+		LevelRevertAfter: levelRevertAfter,
+		Redaction:        redaction,
 	}, nil
 }
 
@@ -290,6 +397,171 @@ func NewConfigJWT() (*ConfigJWT, error) {
 	}, nil
 }
 
+// ConfigPassword represents the configuration of the password policy and of the password hashing
+type ConfigPassword struct {
+	// Minimum number of characters (8 by default)
+	MinLength int
+
+	// Maximum number of bytes (72 at most with bcrypt which ignores the next bytes)
+	MaxLength int
+
+	// Required character classes
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+
+	// Path of the file of the forbidden passwords (one by line, empty for no denylist)
+	DenylistPath string
+
+	// Hashing algorithm of the new passwords (bcrypt | argon2id, bcrypt by default)
+	Hasher string
+
+	// bcrypt cost (4 to 31, bcrypt.DefaultCost by default)
+	BcryptCost int
+
+	// argon2id memory (in KiB)
+	Argon2Memory uint32
+
+	// argon2id number of iterations
+	Argon2Iterations uint32
+
+	// argon2id number of threads
+	Argon2Parallelism uint8
+}
+
+// NewConfigPassword creates a new ConfigPassword instance
+func NewConfigPassword() (*ConfigPassword, error) {
+	hasher := viper.GetString("PASSWORD_HASHER")
+	maxLength := viper.GetInt("PASSWORD_MAX_LENGTH")
+	bcryptCost := viper.GetInt("PASSWORD_BCRYPT_COST")
+	argon2Memory := viper.GetUint32("PASSWORD_ARGON2_MEMORY")
+	argon2Iterations := viper.GetUint32("PASSWORD_ARGON2_ITERATIONS")
+	argon2Parallelism := viper.GetUint8("PASSWORD_ARGON2_PARALLELISM")
+
+	if hasher == "" {
+		hasher = "bcrypt"
+	}
+	if hasher != "bcrypt" && hasher != "argon2id" {
+		return nil, apperr.NewAppErr(fmt.Errorf("error in configuration"), "invalid password hasher", nil, nil)
+	}
+
+	minLength := viper.GetInt("PASSWORD_MIN_LENGTH")
+	if minLength <= 0 {
+		minLength = 8
+	}
+
+	if maxLength <= 0 {
+		maxLength = 72
+	}
+
+	if hasher == "bcrypt" && maxLength > 72 {
+		return nil, apperr.NewAppErr(fmt.Errorf("error in configuration"), "password max length must not exceed 72 bytes with bcrypt", nil, nil)
+	}
+
+	if bcryptCost == 0 {
+		bcryptCost = bcrypt.DefaultCost
+	}
+	if hasher == "bcrypt" && (bcryptCost < 4 || bcryptCost > 31) {
+		return nil, apperr.NewAppErr(fmt.Errorf("error in configuration"), "invalid bcrypt cost", nil, nil)
+	}
+
+	if hasher == "argon2id" && (argon2Memory == 0 || argon2Iterations == 0 || argon2Parallelism == 0) {
+		return nil, apperr.NewAppErr(fmt.Errorf("error in configuration"), "invalid argon2id parameters", nil, nil)
+	}
+
+	return &ConfigPassword{
+		MinLength:         minLength,
+		MaxLength:         maxLength,
+		RequireUppercase:  viper.GetBool("PASSWORD_REQUIRE_UPPERCASE"),
+		RequireLowercase:  viper.GetBool("PASSWORD_REQUIRE_LOWERCASE"),
+		RequireDigit:      viper.GetBool("PASSWORD_REQUIRE_DIGIT"),
+		RequireSymbol:     viper.GetBool("PASSWORD_REQUIRE_SYMBOL"),
+		DenylistPath:      viper.GetString("PASSWORD_DENYLIST_PATH"),
+		Hasher:            hasher,
+		BcryptCost:        bcryptCost,
+		Argon2Memory:      argon2Memory,
+		Argon2Iterations:  argon2Iterations,
+		Argon2Parallelism: argon2Parallelism,
+	}, nil
+}
+
+// ConfigEmail represents the configuration of the domains allowed for the new emails
+type ConfigEmail struct {
+	// Only allowed domains (all the domains if empty)
+	AllowedDomains []string
+
+	// Forbidden domains
+	BlockedDomains []string
+
+	// Path of the file of the disposable email domains (one by line, empty for no list)
+	DisposableDomainsPath string
+}
+
+// NewConfigEmail creates a new ConfigEmail instance
+func NewConfigEmail() *ConfigEmail {
+	return &ConfigEmail{
+		AllowedDomains:        viper.GetStringSlice("EMAIL_ALLOWED_DOMAINS"),
+		BlockedDomains:        viper.GetStringSlice("EMAIL_BLOCKED_DOMAINS"),
+		DisposableDomainsPath: viper.GetString("EMAIL_DISPOSABLE_DOMAINS_PATH"),
+	}
+}
+
+// ConfigRequestID represents the configuration of the request IDs
+type ConfigRequestID struct {
+	// Header of the request ID in the requests, the responses and the outbound calls (canonical form)
+	Header string
+
+	// IPs and CIDRs of the proxies (gateways) whose request IDs are accepted
+	TrustedProxies []string
+
+	// Generator of the request IDs (uuidv7 | ulid)
+	Generator string
+
+	// Maximum length of an inbound request ID (at most 128)
+	MaxLength int
+}
+
+// NewConfigRequestID creates a new ConfigRequestID instance
+func NewConfigRequestID() (*ConfigRequestID, error) {
+	header := viper.GetString("REQUEST_ID_HEADER")
+	if header == "" {
+		header = "X-Request-Id"
+	}
+
+	trustedProxies := viper.GetStringSlice("REQUEST_ID_TRUSTED_PROXIES")
+	for _, proxy := range trustedProxies {
+		if _, err := netip.ParsePrefix(proxy); err != nil {
+			if _, err := netip.ParseAddr(proxy); err != nil {
+				return nil, apperr.NewAppErr(fmt.Errorf("error in configuration"), "invalid request ID trusted proxy", nil, nil)
+			}
+		}
+	}
+
+	generator := viper.GetString("REQUEST_ID_GENERATOR")
+	if generator == "" {
+		generator = "uuidv7"
+	}
+	if generator != "uuidv7" && generator != "ulid" {
+		return nil, apperr.NewAppErr(fmt.Errorf("error in configuration"), "invalid request ID generator", nil, nil)
+	}
+
+	maxLength := viper.GetInt("REQUEST_ID_MAX_LENGTH")
+	if maxLength == 0 {
+		maxLength = 128
+	}
+	if maxLength < 0 || maxLength > 128 {
+		return nil, apperr.NewAppErr(fmt.Errorf("error in configuration"), "invalid request ID max length", nil, nil)
+	}
+
+	return &ConfigRequestID{
+		Header:         http.CanonicalHeaderKey(header),
+		TrustedProxies: trustedProxies,
+		Generator:      generator,
+		MaxLength:      maxLength,
+	}, nil
+}
+
 // ConfigCORS represents the configuration of the CORS
 type ConfigCORS struct {
 	// Allowed origins
@@ -344,6 +616,162 @@ func NewConfigPprof() *ConfigPprof {
 	}
 }
 
+// ConfigOutbox represents the configuration of the domain events outbox
+type ConfigOutbox struct {
+	// Enable the background dispatcher
+	Enable bool
+
+	// Interval between two dispatches
+	Interval time.Duration
+
+	// Number of events dispatched at once
+	BatchSize int
+
+	// Max number of delivery attempts of an event
+	MaxAttempts int
+
+	// Duration during which the claimed events are reserved to a dispatcher (longer than the publication of a batch)
+	LeaseDuration time.Duration
+
+	// Publishers (log | file | webhook | webhooks)
+	Publishers []string
+
+	// File path of the file publisher
+	FilePath string
+
+	// URL of the webhook publisher
+	WebhookURL string
+
+	// Timeout of the webhook publisher
+	WebhookTimeout time.Duration
+}
+
+// NewConfigOutbox creates a new ConfigOutbox instance
+func NewConfigOutbox() (*ConfigOutbox, error) {
+	publishers := viper.GetStringSlice("OUTBOX_PUBLISHERS")
+	filePath := viper.GetString("OUTBOX_FILE_PATH")
+	webhookURL := viper.GetString("OUTBOX_WEBHOOK_URL")
+
+	for _, publisher := range publishers {
+		if publisher != "log" && publisher != "file" && publisher != "webhook" && publisher != "webhooks" {
+			return nil, apperr.NewAppErr(fmt.Errorf("error in configuration"), "invalid outbox publishers", nil, nil)
+		}
+	}
+
+	if goutils.StringInSlice("file", publishers) && filePath == "" {
+		return nil, apperr.NewAppErr(fmt.Errorf("error in configuration"), "missing outbox file path", nil, nil)
+	}
+
+	if goutils.StringInSlice("webhook", publishers) && webhookURL == "" {
+		return nil, apperr.NewAppErr(fmt.Errorf("error in configuration"), "missing outbox webhook URL", nil, nil)
+	}
+
+	return &ConfigOutbox{
+		Enable:         viper.GetBool("OUTBOX_ENABLE"),
+		Interval:       viper.GetDuration("OUTBOX_INTERVAL"),
+		BatchSize:      viper.GetInt("OUTBOX_BATCH_SIZE"),
+		MaxAttempts:    viper.GetInt("OUTBOX_MAX_ATTEMPTS"),
+		LeaseDuration:  viper.GetDuration("OUTBOX_LEASE_DURATION"),
+		Publishers:     publishers,
+		FilePath:       filePath,
+		WebhookURL:     webhookURL,
+		WebhookTimeout: viper.GetDuration("OUTBOX_WEBHOOK_TIMEOUT"),
+	}, nil
+}
+
+// ConfigWebhooks represents the configuration of the deliveries to the registered webhooks
+type ConfigWebhooks struct {
+	// Number of attempts to deliver an event to a webhook
+	MaxAttempts int
+
+	// Delay before the second attempt, doubled for each new attempt
+	RetryDelay time.Duration
+
+	// Timeout of a webhook request
+	Timeout time.Duration
+
+	// Number of consecutive failed deliveries after which a webhook is disabled
+	DisableAfterFailures int
+}
+
+// NewConfigWebhooks creates a new ConfigWebhooks instance
+func NewConfigWebhooks() *ConfigWebhooks {
+	return &ConfigWebhooks{
+		MaxAttempts:          viper.GetInt("WEBHOOKS_MAX_ATTEMPTS"),
+		RetryDelay:           viper.GetDuration("WEBHOOKS_RETRY_DELAY"),
+		Timeout:              viper.GetDuration("WEBHOOKS_TIMEOUT"),
+		DisableAfterFailures: viper.GetInt("WEBHOOKS_DISABLE_AFTER_FAILURES"),
+	}
+}
+
+// ConfigIdempotency represents the configuration of the Idempotency-Key support
+type ConfigIdempotency struct {
+	// Enable the Idempotency-Key header
+	Enable bool
+
+	// Store of the keys (memory | mysql)
+	Store string
+
+	// Lifetime of a key
+	TTL time.Duration
+
+	// Delay after which a request still in progress is considered as lost
+	LockTimeout time.Duration
+
+	// Interval between two deletions of the expired keys
+	CleanupInterval time.Duration
+}
+
+// NewConfigIdempotency creates a new ConfigIdempotency instance
+func NewConfigIdempotency() (*ConfigIdempotency, error) {
+	store := viper.GetString("IDEMPOTENCY_STORE")
+
+	if store != "memory" && store != "mysql" {
+		return nil, apperr.NewAppErr(fmt.Errorf("error in configuration"), "invalid idempotency store", nil, nil)
+	}
+
+	return &ConfigIdempotency{
+		Enable:          viper.GetBool("IDEMPOTENCY_ENABLE"),
+		Store:           store,
+		TTL:             viper.GetDuration("IDEMPOTENCY_TTL"),
+		LockTimeout:     viper.GetDuration("IDEMPOTENCY_LOCK_TIMEOUT"),
+		CleanupInterval: viper.GetDuration("IDEMPOTENCY_CLEANUP_INTERVAL"),
+	}, nil
+}
+
+// ConfigCompression represents the configuration of the response compression
+type ConfigCompression struct {
+	// Enable the compression of the responses
+	Enable bool
+
+	// Supported encodings, in order of preference (zstd | br | gzip)
+	Encodings []string
+
+	// Minimum size of a response to compress (in bytes)
+	MinSize int
+
+	// Compressible content types (a "type/*" pattern matches all subtypes)
+	ContentTypes []string
+}
+
+// NewConfigCompression creates a new ConfigCompression instance
+func NewConfigCompression() (*ConfigCompression, error) {
+	encodings := viper.GetStringSlice("COMPRESSION_ENCODINGS")
+
+	for _, encoding := range encodings {
+		if encoding != "zstd" && encoding != "br" && encoding != "gzip" {
+			return nil, apperr.NewAppErr(fmt.Errorf("error in configuration"), "invalid compression encodings", nil, nil)
+		}
+	}
+
+	return &ConfigCompression{
+		Enable:       viper.GetBool("COMPRESSION_ENABLE"),
+		Encodings:    encodings,
+		MinSize:      viper.GetInt("COMPRESSION_MIN_SIZE"),
+		ContentTypes: viper.GetStringSlice("COMPRESSION_CONTENT_TYPES"),
+	}, nil
+}
+
 // Config represents the configuration of the application from the .env file
 type Config struct {
 	// Application environment (development, production or test)
@@ -372,6 +800,27 @@ type Config struct {
 
 	// Pprof configuration
 	Pprof ConfigPprof
+
+	// Outbox configuration
+	Outbox ConfigOutbox
+
+	// Webhooks configuration
+	Webhooks ConfigWebhooks
+
+	// Idempotency configuration
+	Idempotency ConfigIdempotency
+
+	// Compression configuration
+	Compression ConfigCompression
+
+	// Password configuration
+	Password ConfigPassword
+
+	// Email configuration
+	Email ConfigEmail
+
+	// Request ID configuration
+	RequestID ConfigRequestID
 }
 
 // NewConfig creates a new Config instance
@@ -408,15 +857,47 @@ func NewConfig(file string) (*Config, error) {
 		return nil, apperr.NewAppErr(err, "error in server configuration", nil, nil)
 	}
 
+	outboxConfig, err := NewConfigOutbox()
+	if err != nil {
+		return nil, apperr.NewAppErr(err, "error in outbox configuration", nil, nil)
+	}
+
+	idempotencyConfig, err := NewConfigIdempotency()
+	if err != nil {
+		return nil, apperr.NewAppErr(err, "error in idempotency configuration", nil, nil)
+	}
+
+	compressionConfig, err := NewConfigCompression()
+	if err != nil {
+		return nil, apperr.NewAppErr(err, "error in compression configuration", nil, nil)
+	}
+
+	passwordConfig, err := NewConfigPassword()
+	if err != nil {
+		return nil, apperr.NewAppErr(err, "error in password configuration", nil, nil)
+	}
+
+	requestIDConfig, err := NewConfigRequestID()
+	if err != nil {
+		return nil, apperr.NewAppErr(err, "error in request ID configuration", nil, nil)
+	}
+
 	return &Config{
-		AppEnv:   viper.GetString("APP_ENV"),
-		AppName:  viper.GetString("APP_NAME"),
-		Server:   *serverConfig,
-		Database: *databaseConfig,
-		Gorm:     *gormConfig,
-		Log:      *logConfig,
-		JWT:      *jwtConfig,
-		CORS:     *NewConfigCORS(),
-		Pprof:    *NewConfigPprof(),
+		AppEnv:      viper.GetString("APP_ENV"),
+		AppName:     viper.GetString("APP_NAME"),
+		Server:      *serverConfig,
+		Database:    *databaseConfig,
+		Gorm:        *gormConfig,
+		Log:         *logConfig,
+		JWT:         *jwtConfig,
+		CORS:        *NewConfigCORS(),
+		Pprof:       *NewConfigPprof(),
+		Outbox:      *outboxConfig,
+		Webhooks:    *NewConfigWebhooks(),
+		Idempotency: *idempotencyConfig,
+		Compression: *compressionConfig,
+		Password:    *passwordConfig,
+		Email:       *NewConfigEmail(),
+		RequestID:   *requestIDConfig,
 	}, nil
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestNewConfigPprof(t *testing.T) {
@@ -327,6 +328,7 @@ func TestNewConfigServerWithCorrectParameters(t *testing.T) {
 	viper.Set("SERVER_BASICAUTH_USERNAME", "")
 	viper.Set("SERVER_BASICAUTH_PASSWORD", "")
 	viper.Set("SERVER_MAX_CPU", 0)
+	viper.Set("SERVER_CRASH_DUMP_PATH", "/tmp/crashes")
 
 	c, err := NewConfigServer()
 
@@ -338,6 +340,9 @@ func TestNewConfigServerWithCorrectParameters(t *testing.T) {
 	assert.Equal(t, c.BasicAuthUsername, "")
 	assert.Equal(t, c.BasicAuthPassword, "")
 	assert.Equal(t, c.MaxCPU, runtime.NumCPU())
+	assert.Equal(t, c.CrashDumpPath, "/tmp/crashes")
+
+	viper.Set("SERVER_CRASH_DUMP_PATH", "")
 }
 
 func TestNewConfigServerWithEmptyAddress(t *testing.T) {
@@ -512,3 +517,302 @@ func TestConfigDatabaseDsnInvalid(t *testing.T) {
 	_, err = c.DSN()
 	assert.NotNil(t, err)
 }
+
+func TestNewConfigOutboxWithCorrectParameters(t *testing.T) {
+	viper.Set("OUTBOX_ENABLE", true)
+	viper.Set("OUTBOX_INTERVAL", "10s")
+	viper.Set("OUTBOX_BATCH_SIZE", 50)
+	viper.Set("OUTBOX_MAX_ATTEMPTS", 5)
+	viper.Set("OUTBOX_PUBLISHERS", []string{"log", "file"})
+	viper.Set("OUTBOX_FILE_PATH", "/tmp/events.log")
+	viper.Set("OUTBOX_WEBHOOK_URL", "")
+
+	c, err := NewConfigOutbox()
+
+	assert.Nil(t, err)
+	assert.Equal(t, c.Enable, true)
+	assert.Equal(t, c.Interval, 10*time.Second)
+	assert.Equal(t, c.BatchSize, 50)
+	assert.Equal(t, c.MaxAttempts, 5)
+	assert.Equal(t, c.Publishers, []string{"log", "file"})
+	assert.Equal(t, c.FilePath, "/tmp/events.log")
+}
+
+func TestNewConfigOutboxWithInvalidParameters(t *testing.T) {
+	viper.Set("OUTBOX_PUBLISHERS", []string{"log", "kafka"})
+	viper.Set("OUTBOX_FILE_PATH", "")
+	viper.Set("OUTBOX_WEBHOOK_URL", "")
+
+	_, err := NewConfigOutbox()
+
+	appErr, ok := err.(*apperr.AppErr)
+	assert.True(t, ok)
+	assert.Equal(t, appErr.Msg, "invalid outbox publishers")
+
+	viper.Set("OUTBOX_PUBLISHERS", []string{"webhook"})
+
+	_, err = NewConfigOutbox()
+
+	appErr, ok = err.(*apperr.AppErr)
+	assert.True(t, ok)
+	assert.Equal(t, appErr.Msg, "missing outbox webhook URL")
+}
+
+func TestNewConfigPasswordWithCorrectParameters(t *testing.T) {
+	viper.Set("PASSWORD_MIN_LENGTH", 12)
+	viper.Set("PASSWORD_MAX_LENGTH", 0)
+	viper.Set("PASSWORD_REQUIRE_DIGIT", true)
+	viper.Set("PASSWORD_HASHER", "argon2id")
+	viper.Set("PASSWORD_ARGON2_MEMORY", 65536)
+	viper.Set("PASSWORD_ARGON2_ITERATIONS", 3)
+	viper.Set("PASSWORD_ARGON2_PARALLELISM", 2)
+
+	c, err := NewConfigPassword()
+
+	assert.Nil(t, err)
+	assert.Equal(t, c.MinLength, 12)
+	assert.Equal(t, c.MaxLength, 72)
+	assert.Equal(t, c.RequireDigit, true)
+	assert.Equal(t, c.Hasher, "argon2id")
+	assert.Equal(t, c.Argon2Memory, uint32(65536))
+	assert.Equal(t, c.Argon2Iterations, uint32(3))
+	assert.Equal(t, c.Argon2Parallelism, uint8(2))
+}
+
+func TestNewConfigPasswordWithDefaultParameters(t *testing.T) {
+	viper.Set("PASSWORD_MIN_LENGTH", nil)
+	viper.Set("PASSWORD_MAX_LENGTH", nil)
+	viper.Set("PASSWORD_HASHER", nil)
+	viper.Set("PASSWORD_BCRYPT_COST", nil)
+
+	c, err := NewConfigPassword()
+
+	assert.Nil(t, err)
+	assert.Equal(t, c.MinLength, 8)
+	assert.Equal(t, c.MaxLength, 72)
+	assert.Equal(t, c.Hasher, "bcrypt")
+	assert.Equal(t, c.BcryptCost, bcrypt.DefaultCost)
+}
+
+func TestNewConfigPasswordWithInvalidParameters(t *testing.T) {
+	viper.Set("PASSWORD_HASHER", "md5")
+
+	_, err := NewConfigPassword()
+
+	appErr, ok := err.(*apperr.AppErr)
+	assert.True(t, ok)
+	assert.Equal(t, appErr.Msg, "invalid password hasher")
+
+	viper.Set("PASSWORD_HASHER", "bcrypt")
+	viper.Set("PASSWORD_MAX_LENGTH", 128)
+	viper.Set("PASSWORD_BCRYPT_COST", 10)
+
+	_, err = NewConfigPassword()
+
+	appErr, ok = err.(*apperr.AppErr)
+	assert.True(t, ok)
+	assert.Equal(t, appErr.Msg, "password max length must not exceed 72 bytes with bcrypt")
+
+	viper.Set("PASSWORD_MAX_LENGTH", 72)
+	viper.Set("PASSWORD_BCRYPT_COST", 2)
+
+	_, err = NewConfigPassword()
+
+	appErr, ok = err.(*apperr.AppErr)
+	assert.True(t, ok)
+	assert.Equal(t, appErr.Msg, "invalid bcrypt cost")
+
+	viper.Set("PASSWORD_HASHER", "argon2id")
+	viper.Set("PASSWORD_ARGON2_MEMORY", 0)
+
+	_, err = NewConfigPassword()
+
+	appErr, ok = err.(*apperr.AppErr)
+	assert.True(t, ok)
+	assert.Equal(t, appErr.Msg, "invalid argon2id parameters")
+}
+
+func TestNewConfigEmail(t *testing.T) {
+	viper.Set("EMAIL_ALLOWED_DOMAINS", "test.com example.com")
+	viper.Set("EMAIL_BLOCKED_DOMAINS", "")
+	viper.Set("EMAIL_DISPOSABLE_DOMAINS_PATH", "./assets/disposable_email_domains.txt")
+
+	c := NewConfigEmail()
+
+	assert.Equal(t, c.AllowedDomains, []string{"test.com", "example.com"})
+	assert.Equal(t, c.BlockedDomains, []string{})
+	assert.Equal(t, c.DisposableDomainsPath, "./assets/disposable_email_domains.txt")
+}
+
+func TestNewConfigLogRotationAndBuffer(t *testing.T) {
+	viper.Set("LOG_LEVEL", "info")
+	viper.Set("LOG_OUTPUTS", "file")
+	viper.Set("LOG_PATH", "/tmp")
+	viper.Set("LOG_ROTATION_MAX_SIZE", 100)
+	viper.Set("LOG_ROTATION_INTERVAL", "24h")
+	viper.Set("LOG_ROTATION_MAX_BACKUPS", 7)
+	viper.Set("LOG_ROTATION_MAX_AGE", 30)
+	viper.Set("LOG_ROTATION_COMPRESS", true)
+	viper.Set("LOG_BUFFER_SIZE", 4096)
+	viper.Set("LOG_FLUSH_INTERVAL", "5s")
+
+	c, err := NewConfigLog()
+
+	assert.Nil(t, err)
+	assert.Equal(t, c.Rotation, ConfigLogRotation{MaxSize: 100, Interval: 24 * time.Hour, MaxBackups: 7, MaxAge: 30, Compress: true})
+	assert.Equal(t, c.BufferSize, 4096)
+	assert.Equal(t, c.FlushInterval, 5*time.Second)
+
+	viper.Set("LOG_FLUSH_INTERVAL", "0s")
+
+	_, err = NewConfigLog()
+
+	appErr, ok := err.(*apperr.AppErr)
+	assert.True(t, ok)
+	assert.Equal(t, appErr.Msg, "invalid log buffer")
+
+	viper.Set("LOG_BUFFER_SIZE", 0)
+	viper.Set("LOG_ROTATION_MAX_BACKUPS", -1)
+
+	_, err = NewConfigLog()
+
+	appErr, ok = err.(*apperr.AppErr)
+	assert.True(t, ok)
+	assert.Equal(t, appErr.Msg, "invalid log rotation")
+
+	viper.Set("LOG_ROTATION_MAX_BACKUPS", 0)
+}
+
+func TestNewConfigLogLevelRevertAfter(t *testing.T) {
+	viper.Set("LOG_LEVEL", "info")
+	viper.Set("LOG_OUTPUTS", "stdout")
+	viper.Set("LOG_LEVEL_REVERT_AFTER", "15m")
+
+	c, err := NewConfigLog()
+
+	assert.Nil(t, err)
+	assert.Equal(t, c.LevelRevertAfter, 15*time.Minute)
+
+	viper.Set("LOG_LEVEL_REVERT_AFTER", "-1m")
+
+	_, err = NewConfigLog()
+
+	appErr, ok := err.(*apperr.AppErr)
+	assert.True(t, ok)
+	assert.Equal(t, appErr.Msg, "invalid log level revert delay")
+
+	viper.Set("LOG_LEVEL_REVERT_AFTER", "0s")
+}
+
+func TestNewConfigLogBackend(t *testing.T) {
+	viper.Set("LOG_LEVEL", "info")
+	viper.Set("LOG_OUTPUTS", "stdout")
+
+	c, err := NewConfigLog()
+
+	assert.Nil(t, err)
+	assert.Equal(t, c.Backend, "zap")
+
+	viper.Set("LOG_BACKEND", "slog")
+
+	c, err = NewConfigLog()
+
+	assert.Nil(t, err)
+	assert.Equal(t, c.Backend, "slog")
+
+	viper.Set("LOG_BACKEND", "logrus")
+
+	_, err = NewConfigLog()
+
+	appErr, ok := err.(*apperr.AppErr)
+	assert.True(t, ok)
+	assert.Equal(t, appErr.Msg, "invalid log backend")
+
+	viper.Set("LOG_BACKEND", "")
+}
+
+func TestNewConfigLogRedaction(t *testing.T) {
+	viper.Set("LOG_LEVEL", "info")
+	viper.Set("LOG_OUTPUTS", "stdout")
+	viper.Set("LOG_REDACT_FIELDS", "iban card_number")
+	viper.Set("LOG_REDACT_PATTERNS", `\d{16}`)
+
+	c, err := NewConfigLog()
+
+	assert.Nil(t, err)
+	assert.Equal(t, c.Redaction, ConfigLogRedaction{Enable: true, Fields: []string{"iban", "card_number"}, Patterns: []string{`\d{16}`}})
+
+	viper.Set("LOG_REDACT_ENABLE", false)
+
+	c, err = NewConfigLog()
+
+	assert.Nil(t, err)
+	assert.False(t, c.Redaction.Enable)
+
+	viper.Set("LOG_REDACT_PATTERNS", "(")
+
+	_, err = NewConfigLog()
+
+	appErr, ok := err.(*apperr.AppErr)
+	assert.True(t, ok)
+	assert.Equal(t, appErr.Msg, "invalid log redaction pattern")
+
+	viper.Set("LOG_REDACT_ENABLE", true)
+	viper.Set("LOG_REDACT_FIELDS", "")
+	viper.Set("LOG_REDACT_PATTERNS", "")
+}
+
+func TestNewConfigRequestID(t *testing.T) {
+	viper.Set("REQUEST_ID_HEADER", "x-correlation-id")
+	viper.Set("REQUEST_ID_TRUSTED_PROXIES", "10.0.0.0/8 192.168.1.1 ::1")
+	viper.Set("REQUEST_ID_GENERATOR", "ulid")
+	viper.Set("REQUEST_ID_MAX_LENGTH", 64)
+
+	c, err := NewConfigRequestID()
+
+	assert.Nil(t, err)
+	assert.Equal(t, c, &ConfigRequestID{
+		Header:         "X-Correlation-Id",
+		TrustedProxies: []string{"10.0.0.0/8", "192.168.1.1", "::1"},
+		Generator:      "ulid",
+		MaxLength:      64,
+	})
+
+	viper.Set("REQUEST_ID_HEADER", "")
+	viper.Set("REQUEST_ID_TRUSTED_PROXIES", "")
+	viper.Set("REQUEST_ID_GENERATOR", "")
+	viper.Set("REQUEST_ID_MAX_LENGTH", 0)
+
+	c, err = NewConfigRequestID()
+
+	assert.Nil(t, err)
+	assert.Equal(t, c, &ConfigRequestID{Header: "X-Request-Id", TrustedProxies: []string{}, Generator: "uuidv7", MaxLength: 128})
+
+	viper.Set("REQUEST_ID_TRUSTED_PROXIES", "gateway")
+
+	_, err = NewConfigRequestID()
+
+	appErr, ok := err.(*apperr.AppErr)
+	assert.True(t, ok)
+	assert.Equal(t, appErr.Msg, "invalid request ID trusted proxy")
+
+	viper.Set("REQUEST_ID_TRUSTED_PROXIES", "")
+	viper.Set("REQUEST_ID_GENERATOR", "uuidv4")
+
+	_, err = NewConfigRequestID()
+
+	appErr, ok = err.(*apperr.AppErr)
+	assert.True(t, ok)
+	assert.Equal(t, appErr.Msg, "invalid request ID generator")
+
+	viper.Set("REQUEST_ID_GENERATOR", "")
+	viper.Set("REQUEST_ID_MAX_LENGTH", 256)
+
+	_, err = NewConfigRequestID()
+
+	appErr, ok = err.(*apperr.AppErr)
+	assert.True(t, ok)
+	assert.Equal(t, appErr.Msg, "invalid request ID max length")
+
+	viper.Set("REQUEST_ID_MAX_LENGTH", 0)
+}
@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"slices"
+	"time"
+
+	vo "go-clean-api/pkg/domain/value_objects"
+)
+
+// APIKeyID is a type for API key ID
+type APIKeyID = vo.ID
+
+// API key scopes list.
+// An API key without scope has the same access as its user.
+const (
+	APIKeyScopeUsersRead     = "users:read"
+	APIKeyScopeUsersWrite    = "users:write"
+	APIKeyScopeAuditLogsRead = "audit-logs:read"
+	APIKeyScopeWebhooksRead  = "webhooks:read"
+	APIKeyScopeWebhooksWrite = "webhooks:write"
+	APIKeyScopeAPIKeysRead   = "api-keys:read"
+	APIKeyScopeAPIKeysWrite  = "api-keys:write"
+)
+
+// APIKeyScopes returns all the API key scopes
+func APIKeyScopes() []string {
+	return []string{
+		APIKeyScopeUsersRead,
+		APIKeyScopeUsersWrite,
+		APIKeyScopeAuditLogsRead,
+		APIKeyScopeWebhooksRead,
+		APIKeyScopeWebhooksWrite,
+		APIKeyScopeAPIKeysRead,
+		APIKeyScopeAPIKeysWrite,
+	}
+}
+
+// APIKey is a struct that represents a long-lived key used by machines to authenticate as a user.
+// Only the SHA-256 hash of the key is stored, the prefix is used to find it.
+type APIKey struct {
+	ID         APIKeyID
+	UserID     UserID
+	Name       string
+	Prefix     string
+	Hash       string
+	Scopes     []string
+	ExpiresAt  *vo.Time
+	LastUsedAt *vo.Time
+	CreatedAt  vo.Time
+	RevokedAt  *vo.Time
+}
+
+// IsActive returns true if the API key is neither revoked nor expired
+func (k APIKey) IsActive(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(k.ExpiresAt.Value())
+}
+
+// HasScope returns true if the API key is granted the scope
+func (k APIKey) HasScope(scope string) bool {
+	return len(k.Scopes) == 0 || slices.Contains(k.Scopes, scope)
+}
@@ -0,0 +1,48 @@
+package entities
+
+import (
+	vo "go-clean-api/pkg/domain/value_objects"
+)
+
+// AuditLogID is a type for audit log ID
+type AuditLogID = vo.ID
+
+// AuditAction is the action recorded in an audit log
+type AuditAction string
+
+// Audit actions list
+const (
+	AuditActionUserCreated  AuditAction = "user.created"
+	AuditActionUserDeleted  AuditAction = "user.deleted"
+	AuditActionUserRestored AuditAction = "user.restored"
+)
+
+// AuditTargetType is the type of the resource targeted by an audited action
+type AuditTargetType string
+
+// Audit target types list
+const (
+	AuditTargetUser AuditTargetType = "user"
+)
+
+// Actor represents who performs an action and from where.
+// An empty ID means that the action has been performed by the system (CLI, jobs, etc.).
+type Actor struct {
+	ID        string
+	IP        string
+	RequestID string
+}
+
+// AuditLog is a struct that represents an audit log entry
+type AuditLog struct {
+	ID         AuditLogID
+	ActorID    string
+	Action     AuditAction
+	TargetType AuditTargetType
+	TargetID   string
+	Before     []byte // JSON snapshot of the target before the action
+	After      []byte // JSON snapshot of the target after the action
+	IP         string
+	RequestID  string
+	CreatedAt  vo.Time
+}
@@ -0,0 +1,33 @@
+package entities
+
+import (
+	vo "go-clean-api/pkg/domain/value_objects"
+)
+
+// Idempotency key statuses
+const (
+	IdempotencyKeyStatusInProgress = "in_progress"
+	IdempotencyKeyStatusCompleted  = "completed"
+)
+
+// IdempotencyKey is a request identified by a client key, stored with its response to be replayed for duplicates
+type IdempotencyKey struct {
+	Key             string // SHA-256 hash of the user ID and the Idempotency-Key header
+	Fingerprint     string // SHA-256 hash of the request method, path and body
+	Status          string
+	ResponseStatus  int
+	ResponseHeaders map[string][]string
+	ResponseBody    []byte
+	CreatedAt       vo.Time
+	ExpiresAt       vo.Time
+}
+
+// IsCompleted returns true if the response of the request is stored
+func (k IdempotencyKey) IsCompleted() bool {
+	return k.Status == IdempotencyKeyStatusCompleted
+}
+
+// IsExpired returns true if the key is expired at the given time
+func (k IdempotencyKey) IsExpired(now vo.Time) bool {
+	return !k.ExpiresAt.Value().After(now.Value())
+}
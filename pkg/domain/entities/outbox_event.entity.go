@@ -0,0 +1,23 @@
+package entities
+
+import (
+	vo "go-clean-api/pkg/domain/value_objects"
+)
+
+// OutboxEventID is a type for outbox event ID
+type OutboxEventID = vo.ID
+
+// OutboxEvent is a domain event stored in the outbox until it is delivered to the publishers
+type OutboxEvent struct {
+	ID            OutboxEventID
+	Name          string
+	AggregateID   string
+	Payload       []byte // JSON
+	RequestID     string // ID of the request which raised the event (empty if none)
+	OccurredAt    vo.Time
+	Attempts      int
+	LastError     string
+	NextAttemptAt vo.Time
+	DispatchedAt  *vo.Time
+	FailedAt      *vo.Time
+}
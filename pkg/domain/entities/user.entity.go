@@ -7,7 +7,8 @@ import (
 // UserID is a type for user ID
 type UserID = vo.ID
 
-// User is a struct that represents a user
+// User is a struct that represents a user.
+// The version is incremented at each update of the user to detect concurrent updates.
 type User struct {
 	ID        UserID
 	Email     vo.Email
@@ -17,4 +18,5 @@ type User struct {
 	CreatedAt vo.Time
 	UpdatedAt vo.Time
 	DeletedAt *vo.Time
+	Version   int
 }
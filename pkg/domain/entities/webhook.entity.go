@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"slices"
+	"time"
+
+	vo "go-clean-api/pkg/domain/value_objects"
+)
+
+// WebhookID is a type for webhook ID
+type WebhookID = vo.ID
+
+// Webhook is a struct that represents an endpoint notified of the domain events it subscribed to
+type Webhook struct {
+	ID                  WebhookID
+	URL                 string
+	Secret              string
+	Events              []string
+	Enabled             bool
+	ConsecutiveFailures int
+	CreatedAt           vo.Time
+	UpdatedAt           vo.Time
+	DisabledAt          *vo.Time
+}
+
+// IsSubscribed returns true if the webhook subscribed to the event
+func (w Webhook) IsSubscribed(eventName string) bool {
+	return slices.Contains(w.Events, eventName)
+}
+
+// WebhookDeliveryID is a type for webhook delivery ID
+type WebhookDeliveryID = vo.ID
+
+// WebhookDelivery is a struct that represents an attempt to deliver an event to a webhook
+type WebhookDelivery struct {
+	ID         WebhookDeliveryID
+	WebhookID  WebhookID
+	EventID    string
+	EventName  string
+	Payload    []byte // JSON body sent to the webhook
+	Attempt    int
+	StatusCode int
+	Success    bool
+	Error      string
+	Duration   time.Duration
+	CreatedAt  vo.Time
+
+	// Date of the next automatic attempt of a failed delivery (nil if it succeeded or will not be retried)
+	NextAttemptAt *vo.Time
+}
@@ -1,8 +1,197 @@
 package domainerr
 
-import "errors"
+import (
+	"errors"
+	"maps"
+	"strings"
+)
+
+// Kind is the category of a domain error
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindNotFound
+	KindConflict
+	KindValidation
+	KindUnauthorized
+	KindForbidden
+	KindPreconditionFailed
+	KindUnprocessable
+)
+
+// Generic error codes of the kinds
+const (
+	CodeInternal           = "internal_error"
+	CodeNotFound           = "not_found"
+	CodeConflict           = "conflict"
+	CodeValidation         = "validation_error"
+	CodeUnauthorized       = "unauthorized"
+	CodeForbidden          = "forbidden"
+	CodePreconditionFailed = "precondition_failed"
+	CodeUnprocessable      = "unprocessable_entity"
+	CodeDatabase           = "database_error"
+)
 
 var (
-	ErrNotFound = errors.New("not found")
-	ErrDatabase = errors.New("database error")
+	ErrInternal           = New(KindInternal, CodeInternal, "internal error")
+	ErrNotFound           = New(KindNotFound, CodeNotFound, "not found")
+	ErrConflict           = New(KindConflict, CodeConflict, "already exists")
+	ErrValidation         = New(KindValidation, CodeValidation, "validation error")
+	ErrUnauthorized       = New(KindUnauthorized, CodeUnauthorized, "unauthorized")
+	ErrForbidden          = New(KindForbidden, CodeForbidden, "forbidden")
+	ErrPreconditionFailed = New(KindPreconditionFailed, CodePreconditionFailed, "precondition failed")
+	ErrUnprocessable      = New(KindUnprocessable, CodeUnprocessable, "unprocessable request")
+	ErrDatabase           = New(KindInternal, CodeDatabase, "database error")
 )
+
+// Error is a typed domain error.
+//
+// Errors are declared once as sentinels (see New) and wrapped with the operation and the cause where they occur
+// (see Wrap), so that errors.Is matches the sentinel and errors.As gives access to the kind, the code and the context.
+type Error struct {
+	// Kind is the category of the error
+	Kind Kind
+
+	// Code is a stable machine-readable identifier of the error
+	Code string
+
+	// Message is a human-readable message which can be sent to clients
+	Message string
+
+	// Op is the operation where the error occurred (e.g. "user_uc:GetByID")
+	Op string
+
+	// Context contains structured data about the error (e.g. the ID of a resource not found)
+	Context map[string]any
+
+	// Err is the cause of the error
+	Err error
+}
+
+// New returns a new domain error.
+func New(kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// NewNotFound returns a new not found domain error.
+func NewNotFound(code, message string) *Error {
+	return New(KindNotFound, code, message)
+}
+
+// NewConflict returns a new conflict domain error.
+func NewConflict(code, message string) *Error {
+	return New(KindConflict, code, message)
+}
+
+// NewValidation returns a new validation domain error.
+func NewValidation(code, message string) *Error {
+	return New(KindValidation, code, message)
+}
+
+// NewUnauthorized returns a new unauthorized domain error.
+func NewUnauthorized(code, message string) *Error {
+	return New(KindUnauthorized, code, message)
+}
+
+// NewForbidden returns a new forbidden domain error.
+func NewForbidden(code, message string) *Error {
+	return New(KindForbidden, code, message)
+}
+
+// NewPreconditionFailed returns a new precondition failed domain error (a resource modified concurrently for example).
+func NewPreconditionFailed(code, message string) *Error {
+	return New(KindPreconditionFailed, code, message)
+}
+
+// NewUnprocessable returns a new unprocessable domain error (a valid request which cannot be processed in the current state).
+func NewUnprocessable(code, message string) *Error {
+	return New(KindUnprocessable, code, message)
+}
+
+// NewInternal returns a new internal domain error.
+func NewInternal(code, message string) *Error {
+	return New(KindInternal, code, message)
+}
+
+// Error returns the error message, prefixed by the operation and followed by the cause.
+func (e *Error) Error() string {
+	var b strings.Builder
+	if e.Op != "" {
+		b.WriteString("[" + e.Op + " ")
+	}
+	b.WriteString(e.Message)
+	if e.Err != nil {
+		b.WriteString(": " + e.Err.Error())
+	}
+	if e.Op != "" {
+		b.WriteString("]")
+	}
+	return b.String()
+}
+
+// Unwrap returns the cause of the error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is returns true if the target is a domain error with the same kind and code.
+// The generic error of a kind (ErrNotFound for example) matches every error of this kind.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok || t.Kind != e.Kind {
+		return false
+	}
+	return t.Code == e.Code || t.Code == genericCode(t.Kind)
+}
+
+// Wrap returns a copy of the error which occurred in the operation because of the cause (which can be nil).
+func (e *Error) Wrap(op string, cause error) *Error {
+	c := *e
+	c.Op = op
+	c.Err = cause
+	c.Context = maps.Clone(e.Context)
+	return &c
+}
+
+// With returns a copy of the error with a context value.
+func (e *Error) With(key string, value any) *Error {
+	c := *e
+	c.Context = maps.Clone(e.Context)
+	if c.Context == nil {
+		c.Context = make(map[string]any, 1)
+	}
+	c.Context[key] = value
+	return &c
+}
+
+// KindOf returns the kind of the first domain error of the chain, KindInternal otherwise.
+func KindOf(err error) Kind {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	return KindInternal
+}
+
+// genericCode returns the code of the generic error of a kind
+func genericCode(kind Kind) string {
+	switch kind {
+	case KindNotFound:
+		return CodeNotFound
+	case KindConflict:
+		return CodeConflict
+	case KindValidation:
+		return CodeValidation
+	case KindUnauthorized:
+		return CodeUnauthorized
+	case KindForbidden:
+		return CodeForbidden
+	case KindPreconditionFailed:
+		return CodePreconditionFailed
+	case KindUnprocessable:
+		return CodeUnprocessable
+	default:
+		return CodeInternal
+	}
+}
@@ -0,0 +1,46 @@
+package domainerr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorIs(t *testing.T) {
+	errUserNotFound := NewNotFound("user_not_found", "user not found")
+	errWebhookNotFound := NewNotFound("webhook_not_found", "webhook not found")
+	wrapped := errUserNotFound.Wrap("user_uc:GetByID", errors.New("no row"))
+
+	assert.ErrorIs(t, wrapped, errUserNotFound)
+	assert.ErrorIs(t, wrapped, ErrNotFound)
+	assert.ErrorIs(t, fmt.Errorf("[handler %w]", wrapped), errUserNotFound)
+	assert.NotErrorIs(t, wrapped, errWebhookNotFound)
+	assert.NotErrorIs(t, wrapped, ErrConflict)
+	assert.NotErrorIs(t, ErrNotFound, errUserNotFound)
+	assert.NotErrorIs(t, ErrDatabase, NewInternal("user_creation_failed", "error when creating user"))
+	assert.ErrorIs(t, ErrDatabase, ErrInternal)
+	assert.ErrorIs(t, NewPreconditionFailed("user_version_mismatch", "user has been modified"), ErrPreconditionFailed)
+	assert.ErrorIs(t, NewUnprocessable("idempotency_key_reused", "idempotency key already used"), ErrUnprocessable)
+}
+
+func TestErrorWrap(t *testing.T) {
+	cause := errors.New("no row")
+	err := ErrNotFound.Wrap("user_uc:GetByID", cause).With("id", "123")
+
+	assert.Equal(t, "[user_uc:GetByID not found: no row]", err.Error())
+	assert.ErrorIs(t, err, cause)
+	assert.Equal(t, map[string]any{"id": "123"}, err.Context)
+
+	// The sentinel is not modified
+	assert.Equal(t, "", ErrNotFound.Op)
+	assert.Nil(t, ErrNotFound.Err)
+	assert.Nil(t, ErrNotFound.Context)
+	assert.Equal(t, "not found", ErrNotFound.Error())
+}
+
+func TestKindOf(t *testing.T) {
+	assert.Equal(t, KindConflict, KindOf(fmt.Errorf("[repository %w]", ErrConflict)))
+	assert.Equal(t, KindInternal, KindOf(errors.New("unknown")))
+}
@@ -0,0 +1,86 @@
+package events
+
+import (
+	"go-clean-api/pkg/domain/entities"
+	"slices"
+)
+
+// Name is the name of a domain event
+type Name string
+
+// Domain events names list
+const (
+	UserCreatedName  Name = "user.created"
+	UserDeletedName  Name = "user.deleted"
+	UserRestoredName Name = "user.restored"
+)
+
+// Names returns the names of all domain events
+func Names() []Name {
+	return []Name{UserCreatedName, UserDeletedName, UserRestoredName}
+}
+
+// IsValidName returns true if name is the name of a domain event
+func IsValidName(name string) bool {
+	return slices.Contains(Names(), Name(name))
+}
+
+// Event is the interface implemented by all domain events.
+// The event itself is the payload serialized in the outbox.
+type Event interface {
+	Name() Name
+	AggregateID() string
+}
+
+//
+// ======== User events ========
+//
+
+// UserCreated is raised when a user is created.
+type UserCreated struct {
+	UserID    string `json:"user_id"`
+	Email     string `json:"email"`
+	Lastname  string `json:"lastname"`
+	Firstname string `json:"firstname"`
+	CreatedAt string `json:"created_at"`
+}
+
+// NewUserCreated creates a new UserCreated event
+func NewUserCreated(user entities.User) UserCreated {
+	return UserCreated{
+		UserID:    user.ID.String(),
+		Email:     user.Email.Value(),
+		Lastname:  user.Lastname,
+		Firstname: user.Firstname,
+		CreatedAt: user.CreatedAt.RFC3339(),
+	}
+}
+
+func (e UserCreated) Name() Name          { return UserCreatedName }
+func (e UserCreated) AggregateID() string { return e.UserID }
+
+// UserDeleted is raised when a user is (soft) deleted.
+type UserDeleted struct {
+	UserID string `json:"user_id"`
+}
+
+// NewUserDeleted creates a new UserDeleted event
+func NewUserDeleted(id entities.UserID) UserDeleted {
+	return UserDeleted{UserID: id.String()}
+}
+
+func (e UserDeleted) Name() Name          { return UserDeletedName }
+func (e UserDeleted) AggregateID() string { return e.UserID }
+
+// UserRestored is raised when a deleted user is restored.
+type UserRestored struct {
+	UserID string `json:"user_id"`
+}
+
+// NewUserRestored creates a new UserRestored event
+func NewUserRestored(id entities.UserID) UserRestored {
+	return UserRestored{UserID: id.String()}
+}
+
+func (e UserRestored) Name() Name          { return UserRestoredName }
+func (e UserRestored) AggregateID() string { return e.UserID }
@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"errors"
+	"go-clean-api/pkg/domain/entities"
+	vo "go-clean-api/pkg/domain/value_objects"
+)
+
+var (
+	// ErrCreatingAPIKey is the error returned when creating an API key.
+	ErrCreatingAPIKey = errors.New("error when creating API key")
+
+	// ErrGettingAPIKeys is the error returned when getting API keys.
+	ErrGettingAPIKeys = errors.New("error when getting API keys")
+
+	// ErrUpdatingAPIKey is the error returned when updating an API key.
+	ErrUpdatingAPIKey = errors.New("error when updating API key")
+)
+
+// APIKey is the interface that wraps the basic methods to interact with the API key repository.
+type APIKey interface {
+	Create(CreateAPIKeyRequest) (CreateAPIKeyResponse, error)
+	GetByPrefix(GetAPIKeyByPrefixRequest) (GetAPIKeyByPrefixResponse, error)
+	GetAllByUser(GetAllAPIKeysByUserRequest) (GetAllAPIKeysByUserResponse, error)
+	CountAllByUser(CountAllAPIKeysByUserRequest) (CountAllAPIKeysByUserResponse, error)
+	Revoke(RevokeAPIKeyRequest) (RevokeAPIKeyResponse, error)
+	UpdateLastUsed(UpdateAPIKeyLastUsedRequest) (UpdateAPIKeyLastUsedResponse, error)
+}
+
+//
+// ======== Create ========
+//
+
+// CreateAPIKeyRequest is the data transfer object for the Create method request.
+type CreateAPIKeyRequest struct {
+	entities.APIKey
+}
+
+// CreateAPIKeyResponse is the data transfer object for the Create method response.
+type CreateAPIKeyResponse struct{}
+
+//
+// ======== GetByPrefix ========
+//
+
+// GetAPIKeyByPrefixRequest is the data transfer object for the GetByPrefix method request.
+type GetAPIKeyByPrefixRequest struct {
+	Prefix string
+}
+
+// GetAPIKeyByPrefixResponse is the data transfer object for the GetByPrefix method response.
+type GetAPIKeyByPrefixResponse struct {
+	entities.APIKey
+}
+
+//
+// ======== GetAllByUser / CountAllByUser ========
+//
+
+// GetAllAPIKeysByUserRequest is the data transfer object for the GetAllByUser method request.
+type GetAllAPIKeysByUserRequest struct {
+	UserID     entities.UserID
+	Pagination vo.Pagination
+}
+
+// GetAllAPIKeysByUserResponse is the data transfer object for the GetAllByUser method response.
+type GetAllAPIKeysByUserResponse struct {
+	APIKeys []entities.APIKey
+}
+
+// CountAllAPIKeysByUserRequest is the data transfer object for the CountAllByUser method request.
+type CountAllAPIKeysByUserRequest struct {
+	UserID entities.UserID
+}
+
+// CountAllAPIKeysByUserResponse is the data transfer object for the CountAllByUser method response.
+type CountAllAPIKeysByUserResponse struct {
+	Total int64
+}
+
+//
+// ======== Revoke ========
+//
+
+// RevokeAPIKeyRequest is the data transfer object for the Revoke method request.
+type RevokeAPIKeyRequest struct {
+	ID        entities.APIKeyID
+	UserID    entities.UserID
+	RevokedAt vo.Time
+}
+
+// RevokeAPIKeyResponse is the data transfer object for the Revoke method response.
+type RevokeAPIKeyResponse struct{}
+
+//
+// ======== UpdateLastUsed ========
+//
+
+// UpdateAPIKeyLastUsedRequest is the data transfer object for the UpdateLastUsed method request.
+type UpdateAPIKeyLastUsedRequest struct {
+	ID         entities.APIKeyID
+	LastUsedAt vo.Time
+}
+
+// UpdateAPIKeyLastUsedResponse is the data transfer object for the UpdateLastUsed method response.
+type UpdateAPIKeyLastUsedResponse struct{}
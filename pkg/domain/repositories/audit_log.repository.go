@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"errors"
+	"go-clean-api/pkg/domain/entities"
+	vo "go-clean-api/pkg/domain/value_objects"
+)
+
+var (
+	// ErrCreatingAuditLog is the error returned when creating an audit log.
+	ErrCreatingAuditLog = errors.New("error when creating audit log")
+
+	// ErrCountingAuditLogs is the error returned when counting audit logs.
+	ErrCountingAuditLogs = errors.New("error when counting audit logs")
+
+	// ErrGettingAuditLogs is the error returned when getting audit logs.
+	ErrGettingAuditLogs = errors.New("error when getting audit logs")
+)
+
+// AuditLog is the interface that wraps the basic methods to interact with the audit log repository.
+type AuditLog interface {
+	Create(CreateAuditLogRequest) (CreateAuditLogResponse, error)
+	GetAll(GetAllAuditLogsRequest) (GetAllAuditLogsResponse, error)
+	CountAll(CountAllAuditLogsRequest) (CountAllAuditLogsResponse, error)
+}
+
+// AuditLogFilters represents the filters available to search audit logs.
+// Empty values are ignored.
+type AuditLogFilters struct {
+	ActorID    string
+	Action     entities.AuditAction
+	TargetType entities.AuditTargetType
+	TargetID   string
+	From       *vo.Time
+	To         *vo.Time
+}
+
+//
+// ======== Create ========
+//
+
+// CreateAuditLogRequest is the data transfer object for the Create method request.
+type CreateAuditLogRequest struct {
+	entities.AuditLog
+}
+
+// CreateAuditLogResponse is the data transfer object for the Create method response.
+type CreateAuditLogResponse struct{}
+
+//
+// ======== GetAll ========
+//
+
+// GetAllAuditLogsRequest is the data transfer object for the GetAll method request.
+type GetAllAuditLogsRequest struct {
+	Pagination vo.Pagination
+	Filters    AuditLogFilters
+}
+
+// GetAllAuditLogsResponse is the data transfer object for the GetAll method response.
+type GetAllAuditLogsResponse struct {
+	AuditLogs []entities.AuditLog
+}
+
+//
+// ======== CountAll ========
+//
+
+// CountAllAuditLogsRequest is the data transfer object for the CountAll method request.
+type CountAllAuditLogsRequest struct {
+	Filters AuditLogFilters
+}
+
+// CountAllAuditLogsResponse is the data transfer object for the CountAll method response.
+type CountAllAuditLogsResponse struct {
+	Total int64
+}
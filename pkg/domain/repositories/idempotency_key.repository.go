@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"errors"
+	"go-clean-api/pkg/domain/entities"
+	vo "go-clean-api/pkg/domain/value_objects"
+)
+
+var (
+	// ErrCreatingIdempotencyKey is the error returned when creating an idempotency key.
+	ErrCreatingIdempotencyKey = errors.New("error when creating idempotency key")
+
+	// ErrGettingIdempotencyKey is the error returned when getting an idempotency key.
+	ErrGettingIdempotencyKey = errors.New("error when getting idempotency key")
+
+	// ErrUpdatingIdempotencyKey is the error returned when updating an idempotency key.
+	ErrUpdatingIdempotencyKey = errors.New("error when updating idempotency key")
+
+	// ErrDeletingIdempotencyKeys is the error returned when deleting idempotency keys.
+	ErrDeletingIdempotencyKeys = errors.New("error when deleting idempotency keys")
+)
+
+// IdempotencyKey is the interface that wraps the basic methods to interact with the idempotency key store.
+type IdempotencyKey interface {
+	Create(CreateIdempotencyKeyRequest) (CreateIdempotencyKeyResponse, error)
+	GetByKey(GetIdempotencyKeyRequest) (GetIdempotencyKeyResponse, error)
+	Complete(CompleteIdempotencyKeyRequest) (CompleteIdempotencyKeyResponse, error)
+	Delete(DeleteIdempotencyKeyRequest) (DeleteIdempotencyKeyResponse, error)
+	DeleteExpired(DeleteExpiredIdempotencyKeysRequest) (DeleteExpiredIdempotencyKeysResponse, error)
+}
+
+//
+// ======== Create ========
+//
+
+// CreateIdempotencyKeyRequest is the data transfer object for the Create method request.
+// A domainerr.ErrConflict error is returned if the key already exists.
+type CreateIdempotencyKeyRequest struct {
+	entities.IdempotencyKey
+}
+
+// CreateIdempotencyKeyResponse is the data transfer object for the Create method response.
+type CreateIdempotencyKeyResponse struct{}
+
+//
+// ======== GetByKey ========
+//
+
+// GetIdempotencyKeyRequest is the data transfer object for the GetByKey method request.
+type GetIdempotencyKeyRequest struct {
+	Key string
+}
+
+// GetIdempotencyKeyResponse is the data transfer object for the GetByKey method response.
+type GetIdempotencyKeyResponse struct {
+	entities.IdempotencyKey
+}
+
+//
+// ======== Complete ========
+//
+
+// CompleteIdempotencyKeyRequest is the data transfer object for the Complete method request.
+type CompleteIdempotencyKeyRequest struct {
+	Key             string
+	ResponseStatus  int
+	ResponseHeaders map[string][]string
+	ResponseBody    []byte
+}
+
+// CompleteIdempotencyKeyResponse is the data transfer object for the Complete method response.
+type CompleteIdempotencyKeyResponse struct{}
+
+//
+// ======== Delete ========
+//
+
+// DeleteIdempotencyKeyRequest is the data transfer object for the Delete method request.
+type DeleteIdempotencyKeyRequest struct {
+	Key string
+
+	// CreatedAt deletes the key only if it has not been replaced since it was read (optional)
+	CreatedAt *vo.Time
+}
+
+// DeleteIdempotencyKeyResponse is the data transfer object for the Delete method response.
+type DeleteIdempotencyKeyResponse struct {
+	Deleted int64
+}
+
+//
+// ======== DeleteExpired ========
+//
+
+// DeleteExpiredIdempotencyKeysRequest is the data transfer object for the DeleteExpired method request.
+type DeleteExpiredIdempotencyKeysRequest struct {
+	Now vo.Time
+}
+
+// DeleteExpiredIdempotencyKeysResponse is the data transfer object for the DeleteExpired method response.
+type DeleteExpiredIdempotencyKeysResponse struct {
+	Deleted int64
+}
@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"errors"
+	"go-clean-api/pkg/domain/entities"
+	vo "go-clean-api/pkg/domain/value_objects"
+)
+
+var (
+	// ErrAddingOutboxEvent is the error returned when adding an event to the outbox.
+	ErrAddingOutboxEvent = errors.New("error when adding outbox event")
+
+	// ErrGettingOutboxEvents is the error returned when getting outbox events.
+	ErrGettingOutboxEvents = errors.New("error when getting outbox events")
+
+	// ErrUpdatingOutboxEvent is the error returned when updating an outbox event.
+	ErrUpdatingOutboxEvent = errors.New("error when updating outbox event")
+)
+
+// Outbox is the interface that wraps the basic methods to interact with the outbox repository.
+type Outbox interface {
+	Add(AddOutboxEventRequest) (AddOutboxEventResponse, error)
+	GetPending(GetPendingOutboxEventsRequest) (GetPendingOutboxEventsResponse, error)
+	Lease(LeaseOutboxEventsRequest) (LeaseOutboxEventsResponse, error)
+	MarkDispatched(MarkOutboxEventDispatchedRequest) (MarkOutboxEventResponse, error)
+	MarkFailed(MarkOutboxEventFailedRequest) (MarkOutboxEventResponse, error)
+}
+
+//
+// ======== Add ========
+//
+
+// AddOutboxEventRequest is the data transfer object for the Add method request.
+type AddOutboxEventRequest struct {
+	entities.OutboxEvent
+}
+
+// AddOutboxEventResponse is the data transfer object for the Add method response.
+type AddOutboxEventResponse struct{}
+
+//
+// ======== GetPending ========
+//
+
+// GetPendingOutboxEventsRequest is the data transfer object for the GetPending method request.
+// Pending events are locked until the end of the transaction and skipped by the other dispatchers.
+type GetPendingOutboxEventsRequest struct {
+	Limit int
+	Now   vo.Time
+}
+
+// GetPendingOutboxEventsResponse is the data transfer object for the GetPending method response.
+type GetPendingOutboxEventsResponse struct {
+	Events []entities.OutboxEvent
+}
+
+//
+// ======== Lease ========
+//
+
+// LeaseOutboxEventsRequest is the data transfer object for the Lease method request.
+// The events are not pending until the end of the lease, so that they can be published outside of a transaction
+// without being claimed by the other dispatchers. They are pending again if they are not marked before.
+type LeaseOutboxEventsRequest struct {
+	IDs   []entities.OutboxEventID
+	Until vo.Time
+}
+
+// LeaseOutboxEventsResponse is the data transfer object for the Lease method response.
+type LeaseOutboxEventsResponse struct{}
+
+//
+// ======== MarkDispatched / MarkFailed ========
+//
+
+// MarkOutboxEventDispatchedRequest is the data transfer object for the MarkDispatched method request.
+type MarkOutboxEventDispatchedRequest struct {
+	ID           entities.OutboxEventID
+	DispatchedAt vo.Time
+}
+
+// MarkOutboxEventFailedRequest is the data transfer object for the MarkFailed method request.
+// If FailedAt is not nil, the event will not be dispatched anymore.
+type MarkOutboxEventFailedRequest struct {
+	ID            entities.OutboxEventID
+	Attempts      int
+	LastError     string
+	NextAttemptAt vo.Time
+	FailedAt      *vo.Time
+}
+
+// MarkOutboxEventResponse is the data transfer object for the MarkDispatched and MarkFailed methods response.
+type MarkOutboxEventResponse struct{}
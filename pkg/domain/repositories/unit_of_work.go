@@ -0,0 +1,17 @@
+package repositories
+
+// Repositories gives access to the repositories bound to a unit of work.
+type Repositories interface {
+	User() User
+	AuditLog() AuditLog
+	Outbox() Outbox
+}
+
+// UnitOfWork is the interface used by the use cases to run several repository calls atomically.
+//
+// The function fn receives transaction-scoped repositories. The transaction is committed
+// if fn returns nil and rolled back otherwise. Implementations may call fn several times
+// (for example after a deadlock), so fn must not have side effects outside of the repositories.
+type UnitOfWork interface {
+	Do(fn func(repos Repositories) error) error
+}
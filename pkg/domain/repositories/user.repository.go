@@ -18,6 +18,9 @@ var (
 
 	// ErrCreatingUser is the error returned when creating user.
 	ErrCreatingUser = errors.New("error when creating user")
+
+	// ErrUpdatingUser is the error returned when updating user.
+	ErrUpdatingUser = errors.New("error when updating user")
 )
 
 // User is the interface that wraps the basic methods to interact with the user repository.
@@ -26,9 +29,11 @@ type User interface {
 	Create(CreateUserRequest) (CreateUserResponse, error)
 	GetByID(GetByIDRequest) (GetByIDResponse, error)
 	GetAll(GetAllRequest) (GetAllResponse, error)
+	StreamAll(StreamAllRequest) (StreamAllResponse, error)
 	CountAll(CountAllRequest) (CountAllResponse, error)
 	Delete(DeleteRestoreRequest) (DeleteRestoreResponse, error)
 	Restore(DeleteRestoreRequest) (DeleteRestoreResponse, error)
+	UpdatePassword(UpdatePasswordRequest) (UpdatePasswordResponse, error)
 }
 
 //
@@ -69,8 +74,10 @@ type CreateUserResponse struct {
 //
 
 // GetByIDRequest is the data transfer object for the GetByID method request.
+// If Deleted is true, the user is searched among the deleted users.
 type GetByIDRequest struct {
-	ID entities.UserID
+	ID      entities.UserID
+	Deleted bool
 }
 
 // GetByIDResponse is the data transfer object for the GetByID method response.
@@ -93,6 +100,21 @@ type GetAllResponse struct {
 	Users []entities.User
 }
 
+//
+// ======== StreamAll ========
+//
+
+// StreamAllRequest is the data transfer object for the StreamAll method request.
+// Each is called for each user as soon as it is read, the reading stops at the first error returned by Each.
+type StreamAllRequest struct {
+	Pagination vo.Pagination
+	Deleted    bool
+	Each       func(entities.User) error
+}
+
+// StreamAllResponse is the data transfer object for the StreamAll method response.
+type StreamAllResponse struct{}
+
 //
 // ======== CountAll ========
 //
@@ -112,9 +134,27 @@ type CountAllResponse struct {
 //
 
 // DeleteRestoreRequest is the data transfer object for the Delete method request.
+// If Version is not 0, the user is updated only if it has this version, otherwise
+// a domainerr.ErrPreconditionFailed error is returned.
 type DeleteRestoreRequest struct {
-	ID entities.UserID
+	ID      entities.UserID
+	Version int
 }
 
 // DeleteRestoreResponse is the data transfer object for the Delete method response.
 type DeleteRestoreResponse struct{}
+
+//
+// ======== UpdatePassword ========
+//
+
+// UpdatePasswordRequest is the data transfer object for the UpdatePassword method request.
+// The password is updated only if it is still OldPassword, otherwise a domainerr.ErrNotFound error is returned.
+type UpdatePasswordRequest struct {
+	ID          entities.UserID
+	OldPassword vo.Password
+	Password    vo.Password
+}
+
+// UpdatePasswordResponse is the data transfer object for the UpdatePassword method response.
+type UpdatePasswordResponse struct{}
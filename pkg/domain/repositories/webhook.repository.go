@@ -0,0 +1,205 @@
+package repositories
+
+import (
+	"errors"
+	"go-clean-api/pkg/domain/entities"
+	vo "go-clean-api/pkg/domain/value_objects"
+)
+
+var (
+	// ErrCreatingWebhook is the error returned when creating a webhook.
+	ErrCreatingWebhook = errors.New("error when creating webhook")
+
+	// ErrGettingWebhooks is the error returned when getting webhooks.
+	ErrGettingWebhooks = errors.New("error when getting webhooks")
+
+	// ErrUpdatingWebhook is the error returned when updating a webhook.
+	ErrUpdatingWebhook = errors.New("error when updating webhook")
+
+	// ErrCreatingWebhookDelivery is the error returned when creating a webhook delivery.
+	ErrCreatingWebhookDelivery = errors.New("error when creating webhook delivery")
+
+	// ErrGettingWebhookDeliveries is the error returned when getting webhook deliveries.
+	ErrGettingWebhookDeliveries = errors.New("error when getting webhook deliveries")
+)
+
+// Webhook is the interface that wraps the basic methods to interact with the webhook repository.
+type Webhook interface {
+	Create(CreateWebhookRequest) (CreateWebhookResponse, error)
+	GetByID(GetWebhookByIDRequest) (GetWebhookByIDResponse, error)
+	GetAll(GetAllWebhooksRequest) (GetAllWebhooksResponse, error)
+	CountAll(CountAllWebhooksRequest) (CountAllWebhooksResponse, error)
+	GetEnabledByEvent(GetEnabledWebhooksByEventRequest) (GetEnabledWebhooksByEventResponse, error)
+	Delete(DeleteWebhookRequest) (DeleteWebhookResponse, error)
+	Enable(EnableWebhookRequest) (EnableWebhookResponse, error)
+	RecordSuccess(RecordWebhookSuccessRequest) (RecordWebhookResultResponse, error)
+	RecordFailure(RecordWebhookFailureRequest) (RecordWebhookResultResponse, error)
+	CreateDelivery(CreateWebhookDeliveryRequest) (CreateWebhookDeliveryResponse, error)
+	GetDeliveryByID(GetWebhookDeliveryByIDRequest) (GetWebhookDeliveryByIDResponse, error)
+	GetLastDelivery(GetLastWebhookDeliveryRequest) (GetLastWebhookDeliveryResponse, error)
+	GetDeliveries(GetWebhookDeliveriesRequest) (GetWebhookDeliveriesResponse, error)
+	CountDeliveries(CountWebhookDeliveriesRequest) (CountWebhookDeliveriesResponse, error)
+}
+
+//
+// ======== Create ========
+//
+
+// CreateWebhookRequest is the data transfer object for the Create method request.
+type CreateWebhookRequest struct {
+	entities.Webhook
+}
+
+// CreateWebhookResponse is the data transfer object for the Create method response.
+type CreateWebhookResponse struct {
+	entities.Webhook
+}
+
+//
+// ======== GetByID ========
+//
+
+// GetWebhookByIDRequest is the data transfer object for the GetByID method request.
+type GetWebhookByIDRequest struct {
+	ID entities.WebhookID
+}
+
+// GetWebhookByIDResponse is the data transfer object for the GetByID method response.
+type GetWebhookByIDResponse struct {
+	entities.Webhook
+}
+
+//
+// ======== GetAll / CountAll ========
+//
+
+// GetAllWebhooksRequest is the data transfer object for the GetAll method request.
+type GetAllWebhooksRequest struct {
+	Pagination vo.Pagination
+}
+
+// GetAllWebhooksResponse is the data transfer object for the GetAll method response.
+type GetAllWebhooksResponse struct {
+	Webhooks []entities.Webhook
+}
+
+// CountAllWebhooksRequest is the data transfer object for the CountAll method request.
+type CountAllWebhooksRequest struct{}
+
+// CountAllWebhooksResponse is the data transfer object for the CountAll method response.
+type CountAllWebhooksResponse struct {
+	Total int64
+}
+
+//
+// ======== GetEnabledByEvent ========
+//
+
+// GetEnabledWebhooksByEventRequest is the data transfer object for the GetEnabledByEvent method request.
+type GetEnabledWebhooksByEventRequest struct {
+	EventName string
+}
+
+// GetEnabledWebhooksByEventResponse is the data transfer object for the GetEnabledByEvent method response.
+type GetEnabledWebhooksByEventResponse struct {
+	Webhooks []entities.Webhook
+}
+
+//
+// ======== Delete / Enable ========
+//
+
+// DeleteWebhookRequest is the data transfer object for the Delete method request.
+type DeleteWebhookRequest struct {
+	ID entities.WebhookID
+}
+
+// DeleteWebhookResponse is the data transfer object for the Delete method response.
+type DeleteWebhookResponse struct{}
+
+// EnableWebhookRequest is the data transfer object for the Enable method request.
+type EnableWebhookRequest struct {
+	ID        entities.WebhookID
+	UpdatedAt vo.Time
+}
+
+// EnableWebhookResponse is the data transfer object for the Enable method response.
+type EnableWebhookResponse struct{}
+
+//
+// ======== RecordSuccess / RecordFailure ========
+//
+
+// RecordWebhookSuccessRequest is the data transfer object for the RecordSuccess method request.
+// It resets the number of consecutive failures.
+type RecordWebhookSuccessRequest struct {
+	ID entities.WebhookID
+}
+
+// RecordWebhookFailureRequest is the data transfer object for the RecordFailure method request.
+// It increments the number of consecutive failures and disables the webhook
+// when this number reaches DisableAfter.
+type RecordWebhookFailureRequest struct {
+	ID           entities.WebhookID
+	DisableAfter int
+	Now          vo.Time
+}
+
+// RecordWebhookResultResponse is the data transfer object for the RecordSuccess and RecordFailure methods response.
+type RecordWebhookResultResponse struct{}
+
+//
+// ======== Deliveries ========
+//
+
+// CreateWebhookDeliveryRequest is the data transfer object for the CreateDelivery method request.
+type CreateWebhookDeliveryRequest struct {
+	entities.WebhookDelivery
+}
+
+// CreateWebhookDeliveryResponse is the data transfer object for the CreateDelivery method response.
+type CreateWebhookDeliveryResponse struct{}
+
+// GetWebhookDeliveryByIDRequest is the data transfer object for the GetDeliveryByID method request.
+type GetWebhookDeliveryByIDRequest struct {
+	WebhookID entities.WebhookID
+	ID        entities.WebhookDeliveryID
+}
+
+// GetWebhookDeliveryByIDResponse is the data transfer object for the GetDeliveryByID method response.
+type GetWebhookDeliveryByIDResponse struct {
+	entities.WebhookDelivery
+}
+
+// GetLastWebhookDeliveryRequest is the data transfer object for the GetLastDelivery method request.
+// It returns the most recent delivery of an event to a webhook.
+type GetLastWebhookDeliveryRequest struct {
+	WebhookID entities.WebhookID
+	EventID   string
+}
+
+// GetLastWebhookDeliveryResponse is the data transfer object for the GetLastDelivery method response.
+type GetLastWebhookDeliveryResponse struct {
+	entities.WebhookDelivery
+}
+
+// GetWebhookDeliveriesRequest is the data transfer object for the GetDeliveries method request.
+type GetWebhookDeliveriesRequest struct {
+	WebhookID  entities.WebhookID
+	Pagination vo.Pagination
+}
+
+// GetWebhookDeliveriesResponse is the data transfer object for the GetDeliveries method response.
+type GetWebhookDeliveriesResponse struct {
+	Deliveries []entities.WebhookDelivery
+}
+
+// CountWebhookDeliveriesRequest is the data transfer object for the CountDeliveries method request.
+type CountWebhookDeliveriesRequest struct {
+	WebhookID entities.WebhookID
+}
+
+// CountWebhookDeliveriesResponse is the data transfer object for the CountDeliveries method response.
+type CountWebhookDeliveriesResponse struct {
+	Total int64
+}
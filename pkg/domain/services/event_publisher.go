@@ -0,0 +1,10 @@
+package services
+
+import "go-clean-api/pkg/domain/entities"
+
+// EventPublisher defines the interface for delivering domain events outside of the application.
+// Publish may be called several times for the same event (at-least-once delivery),
+// so consumers should deduplicate events with their ID.
+type EventPublisher interface {
+	Publish(event entities.OutboxEvent) error
+}
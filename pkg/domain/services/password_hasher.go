@@ -0,0 +1,13 @@
+package services
+
+// PasswordHasher defines the interface for hashing and verifying passwords.
+type PasswordHasher interface {
+	// Hash returns the hash of a password with the configured algorithm
+	Hash(password string) (string, error)
+
+	// Verify returns true if the password matches the hash, whatever the algorithm of the hash
+	Verify(hash, password string) (bool, error)
+
+	// NeedsRehash returns true if the hash does not use the configured algorithm and parameters
+	NeedsRehash(hash string) bool
+}
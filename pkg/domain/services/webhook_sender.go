@@ -0,0 +1,26 @@
+package services
+
+import "time"
+
+// WebhookRequest is the request sent to a webhook
+type WebhookRequest struct {
+	URL        string
+	Secret     string
+	DeliveryID string
+	EventID    string
+	EventName  string
+	RequestID  string // ID of the request at the origin of the event (empty if none)
+	Body       []byte
+}
+
+// WebhookResponse is the result of a webhook request
+type WebhookResponse struct {
+	StatusCode int
+	Duration   time.Duration
+}
+
+// WebhookSender defines the interface for sending signed requests to webhooks.
+// An error is returned if the request failed or if the response status code is not 2xx.
+type WebhookSender interface {
+	Send(req WebhookRequest) (WebhookResponse, error)
+}
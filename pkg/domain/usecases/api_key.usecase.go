@@ -0,0 +1,319 @@
+package usecases
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"go-clean-api/pkg/domain/entities"
+	domainerr "go-clean-api/pkg/domain/errors"
+	"go-clean-api/pkg/domain/repositories"
+	vo "go-clean-api/pkg/domain/value_objects"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// apiKeyTag is the first part of an API key: <tag>_<prefix>_<secret>
+	apiKeyTag = "gca"
+
+	// apiKeyPrefixBytes is the number of random bytes of the prefix (hex encoded)
+	apiKeyPrefixBytes = 6
+
+	// apiKeySecretBytes is the number of random bytes of the secret (base64 encoded)
+	apiKeySecretBytes = 32
+
+	// apiKeyLastUsedResolution is the minimum delay between two updates of the last use date
+	apiKeyLastUsedResolution = time.Minute
+)
+
+var (
+	ErrInvalidAPIKey           = domainerr.NewUnauthorized(CodeInvalidAPIKey, "invalid API key")
+	ErrInvalidAPIKeyScope      = domainerr.NewValidation(CodeInvalidAPIKeyScope, "invalid API key scope")
+	ErrInvalidAPIKeyExpiration = domainerr.NewValidation(CodeInvalidAPIKeyExpiration, "API key expiration date must be in the future")
+	ErrAPIKeyScopeNotGranted   = domainerr.NewForbidden(CodeAPIKeyScopeNotGranted, "API key scope not granted to the current API key")
+	ErrAPIKeyCreation          = domainerr.NewInternal("api_key_creation_failed", "error when creating API key")
+)
+
+// APIKey is an interface for API key use cases.
+type APIKey interface {
+	Create(CreateAPIKeyRequest) (CreateAPIKeyResponse, error)
+	GetAll(GetAllAPIKeysRequest) (GetAllAPIKeysResponse, error)
+	Revoke(RevokeAPIKeyRequest) (RevokeAPIKeyResponse, error)
+	Authenticate(AuthenticateAPIKeyRequest) (AuthenticateAPIKeyResponse, error)
+}
+
+type apiKeyUseCase struct {
+	apiKeyRepository repositories.APIKey
+	userRepository   repositories.User
+}
+
+// NewAPIKey returns a new APIKey use case
+func NewAPIKey(apiKeyRepository repositories.APIKey, userRepository repositories.User) APIKey {
+	return &apiKeyUseCase{apiKeyRepository, userRepository}
+}
+
+//
+// ======== Create ========
+//
+
+// CreateAPIKeyRequest is the data transfer object for the Create method request.
+type CreateAPIKeyRequest struct {
+	UserID    entities.UserID
+	Name      string
+	Scopes    []string
+	ExpiresAt *vo.Time
+
+	// Scopes granted to the API key used to create the key, nil if the user is authenticated with a JWT.
+	// A key created with an API key must have scopes and they must be granted to this API key.
+	CreatorScopes []string
+}
+
+// CreateAPIKeyResponse is the data transfer object for the Create method response.
+// The key is only returned at creation, only its hash is stored.
+type CreateAPIKeyResponse struct {
+	entities.APIKey
+	Key string
+}
+
+// Create creates a new API key for a user.
+func (uc apiKeyUseCase) Create(req CreateAPIKeyRequest) (res CreateAPIKeyResponse, err error) {
+	for _, scope := range req.Scopes {
+		if !slices.Contains(entities.APIKeyScopes(), scope) {
+			err = ErrInvalidAPIKeyScope.Wrap("api_key_uc:Create", nil).With("scope", scope)
+			return
+		}
+	}
+
+	// An API key cannot create a key with more rights than itself
+	if req.CreatorScopes != nil {
+		if len(req.Scopes) == 0 {
+			err = ErrAPIKeyScopeNotGranted.Wrap("api_key_uc:Create", nil).With("scope", "*")
+			return
+		}
+		for _, scope := range req.Scopes {
+			if !slices.Contains(req.CreatorScopes, scope) {
+				err = ErrAPIKeyScopeNotGranted.Wrap("api_key_uc:Create", nil).With("scope", scope)
+				return
+			}
+		}
+	}
+
+	now := time.Now()
+	if req.ExpiresAt != nil && !req.ExpiresAt.Value().After(now) {
+		err = ErrInvalidAPIKeyExpiration.Wrap("api_key_uc:Create", nil)
+		return
+	}
+
+	if _, errRepo := uc.userRepository.GetByID(repositories.GetByIDRequest{ID: req.UserID}); errRepo != nil {
+		if errors.Is(errRepo, domainerr.ErrNotFound) {
+			err = ErrUserNotFound.Wrap("api_key_uc:Create", errRepo).With("id", req.UserID.String())
+		} else {
+			err = domainerr.ErrDatabase.Wrap("api_key_uc:Create", errRepo)
+		}
+		return
+	}
+
+	key, prefix, errKey := newAPIKey()
+	if errKey != nil {
+		err = ErrAPIKeyCreation.Wrap("api_key_uc:Create", errKey)
+		return
+	}
+
+	apiKey := entities.APIKey{
+		ID:        vo.NewID(),
+		UserID:    req.UserID,
+		Name:      req.Name,
+		Prefix:    prefix,
+		Hash:      hashAPIKey(key),
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+		CreatedAt: vo.NewTime(now, nil),
+	}
+	if _, errRepo := uc.apiKeyRepository.Create(repositories.CreateAPIKeyRequest{APIKey: apiKey}); errRepo != nil {
+		err = domainerr.ErrDatabase.Wrap("api_key_uc:Create", errRepo)
+		return
+	}
+
+	res.APIKey = apiKey
+	res.Key = key
+
+	return
+}
+
+//
+// ======== GetAll ========
+//
+
+// GetAllAPIKeysRequest is the data transfer object for the GetAll method request.
+type GetAllAPIKeysRequest struct {
+	UserID     entities.UserID
+	Pagination vo.Pagination
+}
+
+// GetAllAPIKeysResponse is the data transfer object for the GetAll method response.
+type GetAllAPIKeysResponse struct {
+	Data  []entities.APIKey
+	Total int64
+}
+
+// GetAll returns the API keys of a user (pagination).
+func (uc apiKeyUseCase) GetAll(req GetAllAPIKeysRequest) (res GetAllAPIKeysResponse, err error) {
+	total, errRepo := uc.apiKeyRepository.CountAllByUser(repositories.CountAllAPIKeysByUserRequest{UserID: req.UserID})
+	if errRepo != nil {
+		err = domainerr.ErrDatabase.Wrap("api_key_uc:GetAll", errRepo)
+		return
+	}
+
+	res.Data = []entities.APIKey{}
+	res.Total = total.Total
+	if total.Total == 0 {
+		return
+	}
+
+	apiKeys, errRepo := uc.apiKeyRepository.GetAllByUser(repositories.GetAllAPIKeysByUserRequest{
+		UserID:     req.UserID,
+		Pagination: req.Pagination,
+	})
+	if errRepo != nil {
+		err = domainerr.ErrDatabase.Wrap("api_key_uc:GetAll", errRepo)
+		return
+	}
+	res.Data = apiKeys.APIKeys
+
+	return
+}
+
+//
+// ======== Revoke ========
+//
+
+// RevokeAPIKeyRequest is the data transfer object for the Revoke method request.
+type RevokeAPIKeyRequest struct {
+	ID     entities.APIKeyID
+	UserID entities.UserID
+}
+
+// RevokeAPIKeyResponse is the data transfer object for the Revoke method response.
+type RevokeAPIKeyResponse struct{}
+
+// Revoke revokes an API key of a user.
+func (uc apiKeyUseCase) Revoke(req RevokeAPIKeyRequest) (res RevokeAPIKeyResponse, err error) {
+	_, errRepo := uc.apiKeyRepository.Revoke(repositories.RevokeAPIKeyRequest{
+		ID:        req.ID,
+		UserID:    req.UserID,
+		RevokedAt: vo.NewTime(time.Now(), nil),
+	})
+	if errRepo != nil {
+		if errors.Is(errRepo, domainerr.ErrNotFound) {
+			err = ErrAPIKeyNotFound.Wrap("api_key_uc:Revoke", errRepo).With("id", req.ID.String())
+		} else {
+			err = domainerr.ErrDatabase.Wrap("api_key_uc:Revoke", errRepo)
+		}
+	}
+
+	return
+}
+
+//
+// ======== Authenticate ========
+//
+
+// AuthenticateAPIKeyRequest is the data transfer object for the Authenticate method request.
+type AuthenticateAPIKeyRequest struct {
+	Key string
+}
+
+// AuthenticateAPIKeyResponse is the data transfer object for the Authenticate method response.
+type AuthenticateAPIKeyResponse struct {
+	entities.APIKey
+}
+
+// Authenticate checks an API key and records its use.
+// The key must be active and belong to a user who is not deleted.
+func (uc apiKeyUseCase) Authenticate(req AuthenticateAPIKeyRequest) (res AuthenticateAPIKeyResponse, err error) {
+	prefix, ok := apiKeyPrefix(req.Key)
+	if !ok {
+		err = ErrInvalidAPIKey.Wrap("api_key_uc:Authenticate", errors.New("malformed key"))
+		return
+	}
+
+	apiKey, errRepo := uc.apiKeyRepository.GetByPrefix(repositories.GetAPIKeyByPrefixRequest{Prefix: prefix})
+	if errRepo != nil {
+		if errors.Is(errRepo, domainerr.ErrNotFound) {
+			err = ErrInvalidAPIKey.Wrap("api_key_uc:Authenticate", errRepo)
+		} else {
+			err = domainerr.ErrDatabase.Wrap("api_key_uc:Authenticate", errRepo)
+		}
+		return
+	}
+
+	if subtle.ConstantTimeCompare([]byte(apiKey.Hash), []byte(hashAPIKey(req.Key))) != 1 {
+		err = ErrInvalidAPIKey.Wrap("api_key_uc:Authenticate", errors.New("hash mismatch"))
+		return
+	}
+
+	now := time.Now()
+	if !apiKey.IsActive(now) {
+		err = ErrInvalidAPIKey.Wrap("api_key_uc:Authenticate", errors.New("revoked or expired"))
+		return
+	}
+
+	if _, errRepo := uc.userRepository.GetByID(repositories.GetByIDRequest{ID: apiKey.UserID}); errRepo != nil {
+		if errors.Is(errRepo, domainerr.ErrNotFound) {
+			err = ErrInvalidAPIKey.Wrap("api_key_uc:Authenticate", errRepo)
+		} else {
+			err = domainerr.ErrDatabase.Wrap("api_key_uc:Authenticate", errRepo)
+		}
+		return
+	}
+
+	// Limit the number of writes for keys used intensively
+	if apiKey.LastUsedAt == nil || now.Sub(apiKey.LastUsedAt.Value()) >= apiKeyLastUsedResolution {
+		lastUsedAt := vo.NewTime(now, nil)
+		_, errRepo := uc.apiKeyRepository.UpdateLastUsed(repositories.UpdateAPIKeyLastUsedRequest{
+			ID:         apiKey.ID,
+			LastUsedAt: lastUsedAt,
+		})
+		if errRepo != nil {
+			err = domainerr.ErrDatabase.Wrap("api_key_uc:Authenticate", errRepo)
+			return
+		}
+		apiKey.LastUsedAt = &lastUsedAt
+	}
+	res.APIKey = apiKey.APIKey
+
+	return
+}
+
+// newAPIKey generates a new API key and returns it with its prefix.
+func newAPIKey() (key, prefix string, err error) {
+	b := make([]byte, apiKeyPrefixBytes+apiKeySecretBytes)
+	if _, err = rand.Read(b); err != nil {
+		return
+	}
+
+	prefix = hex.EncodeToString(b[:apiKeyPrefixBytes])
+	key = apiKeyTag + "_" + prefix + "_" + base64.RawURLEncoding.EncodeToString(b[apiKeyPrefixBytes:])
+
+	return
+}
+
+// apiKeyPrefix extracts the prefix from an API key.
+func apiKeyPrefix(key string) (string, bool) {
+	parts := strings.SplitN(key, "_", 3)
+	if len(parts) != 3 || parts[0] != apiKeyTag || len(parts[1]) != 2*apiKeyPrefixBytes || parts[2] == "" {
+		return "", false
+	}
+	return parts[1], true
+}
+
+// hashAPIKey returns the hex encoded SHA-256 hash of an API key.
+// A fast hash is enough since the key is a long random string.
+func hashAPIKey(key string) string {
+	h := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(h[:])
+}
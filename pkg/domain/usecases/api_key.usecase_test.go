@@ -0,0 +1,111 @@
+package usecases
+
+import (
+	"go-clean-api/pkg/domain/entities"
+	domainerr "go-clean-api/pkg/domain/errors"
+	vo "go-clean-api/pkg/domain/value_objects"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyCreateAndAuthenticate(t *testing.T) {
+	user := entities.User{ID: vo.NewID()}
+	apiKeys := &fakeAPIKeyRepository{}
+	uc := NewAPIKey(apiKeys, &fakeUserRepository{users: []entities.User{user}})
+
+	res, err := uc.Create(CreateAPIKeyRequest{UserID: user.ID, Name: "batch", Scopes: []string{entities.APIKeyScopeUsersRead}})
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(res.Key, apiKeyTag+"_"+res.Prefix+"_"))
+	assert.Equal(t, 1, len(apiKeys.apiKeys))
+	assert.NotContains(t, apiKeys.apiKeys[0].Hash, res.Key)
+	assert.Equal(t, hashAPIKey(res.Key), apiKeys.apiKeys[0].Hash)
+
+	// Successful authentication records the last use only once per minute
+	auth, err := uc.Authenticate(AuthenticateAPIKeyRequest{Key: res.Key})
+	assert.Nil(t, err)
+	assert.Equal(t, user.ID.String(), auth.UserID.String())
+	assert.True(t, auth.HasScope(entities.APIKeyScopeUsersRead))
+	assert.False(t, auth.HasScope(entities.APIKeyScopeUsersWrite))
+	assert.NotNil(t, auth.LastUsedAt)
+
+	_, err = uc.Authenticate(AuthenticateAPIKeyRequest{Key: res.Key})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, apiKeys.lastUsedUpdates)
+
+	// Invalid keys
+	for _, key := range []string{"", "invalid", res.Key + "x", apiKeyTag + "_" + res.Prefix + "_secret", apiKeyTag + "_000000000000_secret"} {
+		_, err = uc.Authenticate(AuthenticateAPIKeyRequest{Key: key})
+		assert.ErrorIs(t, err, ErrInvalidAPIKey, key)
+	}
+
+	// Revoked key
+	_, err = uc.Revoke(RevokeAPIKeyRequest{ID: res.ID, UserID: user.ID})
+	assert.Nil(t, err)
+	_, err = uc.Authenticate(AuthenticateAPIKeyRequest{Key: res.Key})
+	assert.ErrorIs(t, err, ErrInvalidAPIKey)
+
+	_, err = uc.Revoke(RevokeAPIKeyRequest{ID: res.ID, UserID: user.ID})
+	assert.ErrorIs(t, err, domainerr.ErrNotFound)
+}
+
+func TestAPIKeyCreateErrors(t *testing.T) {
+	user := entities.User{ID: vo.NewID()}
+	uc := NewAPIKey(&fakeAPIKeyRepository{}, &fakeUserRepository{users: []entities.User{user}})
+
+	_, err := uc.Create(CreateAPIKeyRequest{UserID: user.ID, Name: "batch", Scopes: []string{"unknown"}})
+	assert.ErrorIs(t, err, ErrInvalidAPIKeyScope)
+
+	past := vo.NewTime(time.Now().Add(-time.Hour), nil)
+	_, err = uc.Create(CreateAPIKeyRequest{UserID: user.ID, Name: "batch", ExpiresAt: &past})
+	assert.ErrorIs(t, err, ErrInvalidAPIKeyExpiration)
+
+	_, err = uc.Create(CreateAPIKeyRequest{UserID: vo.NewID(), Name: "batch"})
+	assert.ErrorIs(t, err, domainerr.ErrNotFound)
+}
+
+func TestAPIKeyCreateWithAPIKey(t *testing.T) {
+	user := entities.User{ID: vo.NewID()}
+	uc := NewAPIKey(&fakeAPIKeyRepository{}, &fakeUserRepository{users: []entities.User{user}})
+	creatorScopes := []string{entities.APIKeyScopeAPIKeysWrite, entities.APIKeyScopeUsersRead}
+
+	// A key without scope would be granted all the scopes
+	_, err := uc.Create(CreateAPIKeyRequest{UserID: user.ID, Name: "batch", CreatorScopes: creatorScopes})
+	assert.ErrorIs(t, err, ErrAPIKeyScopeNotGranted)
+	assert.Equal(t, domainerr.KindForbidden, domainerr.KindOf(err))
+
+	_, err = uc.Create(CreateAPIKeyRequest{
+		UserID:        user.ID,
+		Name:          "batch",
+		Scopes:        []string{entities.APIKeyScopeUsersRead, entities.APIKeyScopeUsersWrite},
+		CreatorScopes: creatorScopes,
+	})
+	assert.ErrorIs(t, err, ErrAPIKeyScopeNotGranted)
+
+	res, err := uc.Create(CreateAPIKeyRequest{
+		UserID:        user.ID,
+		Name:          "batch",
+		Scopes:        []string{entities.APIKeyScopeUsersRead},
+		CreatorScopes: creatorScopes,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{entities.APIKeyScopeUsersRead}, res.Scopes)
+}
+
+func TestAPIKeyExpired(t *testing.T) {
+	user := entities.User{ID: vo.NewID()}
+	apiKeys := &fakeAPIKeyRepository{}
+	uc := NewAPIKey(apiKeys, &fakeUserRepository{users: []entities.User{user}})
+
+	soon := vo.NewTime(time.Now().Add(time.Hour), nil)
+	res, err := uc.Create(CreateAPIKeyRequest{UserID: user.ID, Name: "batch", ExpiresAt: &soon})
+	assert.Nil(t, err)
+
+	expired := vo.NewTime(time.Now().Add(-time.Second), nil)
+	apiKeys.apiKeys[0].ExpiresAt = &expired
+
+	_, err = uc.Authenticate(AuthenticateAPIKeyRequest{Key: res.Key})
+	assert.ErrorIs(t, err, ErrInvalidAPIKey)
+}
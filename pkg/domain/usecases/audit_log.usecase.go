@@ -0,0 +1,159 @@
+package usecases
+
+import (
+	"encoding/json"
+	"go-clean-api/pkg/domain/entities"
+	domainerr "go-clean-api/pkg/domain/errors"
+	"go-clean-api/pkg/domain/repositories"
+	vo "go-clean-api/pkg/domain/value_objects"
+	"time"
+)
+
+var (
+	ErrAuditLogCreation = domainerr.NewInternal("audit_log_creation_failed", "error when creating audit log")
+)
+
+// AuditLog is an interface for audit log use cases.
+type AuditLog interface {
+	GetAll(GetAllAuditLogsRequest) (GetAllAuditLogsResponse, error)
+}
+
+type auditLogUseCase struct {
+	unitOfWork repositories.UnitOfWork
+}
+
+// NewAuditLog returns a new AuditLog use case
+func NewAuditLog(unitOfWork repositories.UnitOfWork) AuditLog {
+	return &auditLogUseCase{unitOfWork}
+}
+
+//
+// ======== GetAll ========
+//
+
+// GetAllAuditLogsRequest is the data transfer object for the GetAll method request.
+type GetAllAuditLogsRequest struct {
+	Pagination vo.Pagination
+	Filters    repositories.AuditLogFilters
+}
+
+// GetAllAuditLogsResponse is the data transfer object for the GetAll method response.
+type GetAllAuditLogsResponse struct {
+	Data  []entities.AuditLog
+	Total int64
+}
+
+// GetAll returns the audit logs matching the filters (pagination).
+func (uc auditLogUseCase) GetAll(req GetAllAuditLogsRequest) (res GetAllAuditLogsResponse, err error) {
+	var total int64
+	auditLogs := []entities.AuditLog{}
+
+	errUoW := uc.unitOfWork.Do(func(repos repositories.Repositories) error {
+		// Get total audit logs
+		resTotal, errTotal := repos.AuditLog().CountAll(repositories.CountAllAuditLogsRequest{Filters: req.Filters})
+		if errTotal != nil {
+			return errTotal
+		}
+		total = resTotal.Total
+
+		auditLogs = []entities.AuditLog{}
+		if total > 0 {
+			// Get audit logs
+			resAuditLogs, errAuditLogs := repos.AuditLog().GetAll(repositories.GetAllAuditLogsRequest{
+				Pagination: req.Pagination,
+				Filters:    req.Filters,
+			})
+			if errAuditLogs != nil {
+				return errAuditLogs
+			}
+
+			auditLogs = resAuditLogs.AuditLogs
+		}
+
+		return nil
+	})
+	if errUoW != nil {
+		err = domainerr.ErrDatabase.Wrap("audit_log_uc:GetAll", errUoW)
+		return
+	}
+
+	return GetAllAuditLogsResponse{
+		Data:  auditLogs,
+		Total: total,
+	}, nil
+}
+
+//
+// ======== Recording ========
+//
+
+// userSnapshot is the representation of a user stored in audit logs (without password).
+type userSnapshot struct {
+	ID        string  `json:"id"`
+	Email     string  `json:"email"`
+	Lastname  string  `json:"lastname"`
+	Firstname string  `json:"firstname"`
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
+	DeletedAt *string `json:"deleted_at"`
+}
+
+// newUserSnapshot returns the JSON snapshot of a user or nil if the user is nil.
+func newUserSnapshot(user *entities.User) ([]byte, error) {
+	if user == nil {
+		return nil, nil
+	}
+
+	var deletedAt *string
+	if user.DeletedAt != nil {
+		d := user.DeletedAt.RFC3339()
+		deletedAt = &d
+	}
+
+	return json.Marshal(userSnapshot{
+		ID:        user.ID.String(),
+		Email:     user.Email.Value(),
+		Lastname:  user.Lastname,
+		Firstname: user.Firstname,
+		CreatedAt: user.CreatedAt.RFC3339(),
+		UpdatedAt: user.UpdatedAt.RFC3339(),
+		DeletedAt: deletedAt,
+	})
+}
+
+// recordUserAudit adds an audit log for an action on a user with the repositories of the unit of work.
+func recordUserAudit(repos repositories.Repositories, actor entities.Actor, action entities.AuditAction, before, after *entities.User) error {
+	beforeState, err := newUserSnapshot(before)
+	if err != nil {
+		return ErrAuditLogCreation.Wrap("", err)
+	}
+	afterState, err := newUserSnapshot(after)
+	if err != nil {
+		return ErrAuditLogCreation.Wrap("", err)
+	}
+
+	target := after
+	if target == nil {
+		target = before
+	}
+
+	_, err = repos.AuditLog().Create(repositories.CreateAuditLogRequest{
+		AuditLog: entities.AuditLog{
+			ID:         vo.NewID(),
+			ActorID:    actor.ID,
+			Action:     action,
+			TargetType: entities.AuditTargetUser,
+			TargetID:   target.ID.String(),
+			Before:     beforeState,
+			After:      afterState,
+			IP:         actor.IP,
+			RequestID:  actor.RequestID,
+			CreatedAt:  vo.NewTime(time.Now(), nil),
+		},
+	})
+	if err != nil {
+		return ErrAuditLogCreation.Wrap("", err)
+	}
+
+	return nil
+}
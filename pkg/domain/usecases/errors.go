@@ -0,0 +1,36 @@
+package usecases
+
+import domainerr "go-clean-api/pkg/domain/errors"
+
+// Error codes are stable machine-readable identifiers of the use case errors sent to the clients.
+// They are part of the API contract and must not be changed.
+const (
+	CodeUserNotFound            = "user_not_found"
+	CodeUserVersionMismatch     = "user_version_mismatch"
+	CodeEmailTaken              = "email_taken"
+	CodeEmailNotAllowed         = "email_not_allowed"
+	CodeInvalidCredentials      = "invalid_credentials"
+	CodeWeakPassword            = "weak_password"
+	CodeWebhookNotFound         = "webhook_not_found"
+	CodeWebhookDeliveryNotFound = "webhook_delivery_not_found"
+	CodeInvalidWebhookEvent     = "invalid_webhook_event"
+	CodeAPIKeyNotFound          = "api_key_not_found"
+	CodeInvalidAPIKey           = "invalid_api_key"
+	CodeInvalidAPIKeyScope      = "invalid_api_key_scope"
+	CodeInvalidAPIKeyExpiration = "invalid_api_key_expiration"
+	CodeAPIKeyScopeNotGranted   = "api_key_scope_not_granted"
+	CodeIdempotencyKeyInUse     = "idempotency_key_in_use"
+	CodeIdempotencyKeyReused    = "idempotency_key_reused"
+)
+
+var (
+	ErrUserNotFound            = domainerr.NewNotFound(CodeUserNotFound, "user not found")
+	ErrUserVersionMismatch     = domainerr.NewPreconditionFailed(CodeUserVersionMismatch, "user has been modified")
+	ErrEmailTaken              = domainerr.NewConflict(CodeEmailTaken, "email already taken")
+	ErrEmailNotAllowed         = domainerr.NewValidation(CodeEmailNotAllowed, "email domain is not allowed")
+	ErrInvalidCredentials      = domainerr.NewUnauthorized(CodeInvalidCredentials, "invalid credentials")
+	ErrWeakPassword            = domainerr.NewValidation(CodeWeakPassword, "password does not match the password policy")
+	ErrWebhookNotFound         = domainerr.NewNotFound(CodeWebhookNotFound, "webhook not found")
+	ErrWebhookDeliveryNotFound = domainerr.NewNotFound(CodeWebhookDeliveryNotFound, "webhook delivery not found")
+	ErrAPIKeyNotFound          = domainerr.NewNotFound(CodeAPIKeyNotFound, "API key not found")
+)
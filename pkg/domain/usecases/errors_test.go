@@ -0,0 +1,58 @@
+package usecases
+
+import (
+	"errors"
+	domainerr "go-clean-api/pkg/domain/errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUseCaseErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantedKind  domainerr.Kind
+		wantedCode  string
+		wantedMatch error
+	}{
+		{
+			name:        "User not found",
+			err:         ErrUserNotFound.Wrap("user_uc:GetByID", errors.New("no row")),
+			wantedKind:  domainerr.KindNotFound,
+			wantedCode:  CodeUserNotFound,
+			wantedMatch: domainerr.ErrNotFound,
+		},
+		{
+			name:        "Invalid credentials",
+			err:         ErrInvalidCredentials.Wrap("user_uc:GetAccessToken", ErrInvalidPassword),
+			wantedKind:  domainerr.KindUnauthorized,
+			wantedCode:  CodeInvalidCredentials,
+			wantedMatch: ErrInvalidPassword,
+		},
+		{
+			name:        "Email taken",
+			err:         ErrEmailTaken.Wrap("user_uc:Create", nil),
+			wantedKind:  domainerr.KindConflict,
+			wantedCode:  CodeEmailTaken,
+			wantedMatch: domainerr.ErrConflict,
+		},
+		{
+			name:        "Database error",
+			err:         domainerr.ErrDatabase.Wrap("user_uc:GetAll", errors.New("connection refused")),
+			wantedKind:  domainerr.KindInternal,
+			wantedCode:  domainerr.CodeDatabase,
+			wantedMatch: domainerr.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var e *domainerr.Error
+			assert.True(t, errors.As(tt.err, &e))
+			assert.Equal(t, tt.wantedKind, e.Kind)
+			assert.Equal(t, tt.wantedCode, e.Code)
+			assert.ErrorIs(t, tt.err, tt.wantedMatch)
+		})
+	}
+}
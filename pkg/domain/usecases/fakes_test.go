@@ -0,0 +1,408 @@
+package usecases
+
+import (
+	"go-clean-api/pkg/domain/entities"
+	domainerr "go-clean-api/pkg/domain/errors"
+	"go-clean-api/pkg/domain/repositories"
+	vo "go-clean-api/pkg/domain/value_objects"
+	"strings"
+	"time"
+)
+
+// fakeUserRepository is an in-memory implementation of the User repository
+type fakeUserRepository struct {
+	repositories.User
+	users     []entities.User
+	errCount  error
+	errGet    error
+	errCreate error
+}
+
+func (r *fakeUserRepository) Create(req repositories.CreateUserRequest) (repositories.CreateUserResponse, error) {
+	if r.errCreate != nil {
+		return repositories.CreateUserResponse{}, r.errCreate
+	}
+	user := entities.User{ID: req.ID, Email: req.Email, Password: req.Password, Lastname: req.Lastname, Firstname: req.Firstname, CreatedAt: req.CreatedAt, UpdatedAt: req.UpdatedAt}
+	r.users = append(r.users, user)
+	return repositories.CreateUserResponse{User: user}, nil
+}
+
+func (r *fakeUserRepository) CountAll(req repositories.CountAllRequest) (repositories.CountAllResponse, error) {
+	if r.errCount != nil {
+		return repositories.CountAllResponse{}, r.errCount
+	}
+	return repositories.CountAllResponse{Total: int64(len(r.users))}, nil
+}
+
+func (r *fakeUserRepository) GetAll(req repositories.GetAllRequest) (repositories.GetAllResponse, error) {
+	if r.errGet != nil {
+		return repositories.GetAllResponse{}, r.errGet
+	}
+	return repositories.GetAllResponse{Users: r.users}, nil
+}
+
+func (r *fakeUserRepository) StreamAll(req repositories.StreamAllRequest) (repositories.StreamAllResponse, error) {
+	if r.errGet != nil {
+		return repositories.StreamAllResponse{}, r.errGet
+	}
+	for _, user := range r.users {
+		if err := req.Each(user); err != nil {
+			return repositories.StreamAllResponse{}, err
+		}
+	}
+	return repositories.StreamAllResponse{}, nil
+}
+
+func (r *fakeUserRepository) GetByID(req repositories.GetByIDRequest) (repositories.GetByIDResponse, error) {
+	for _, user := range r.users {
+		if user.ID.Value() == req.ID.Value() && (user.DeletedAt != nil) == req.Deleted {
+			return repositories.GetByIDResponse{User: user}, nil
+		}
+	}
+	return repositories.GetByIDResponse{}, domainerr.ErrNotFound
+}
+
+func (r *fakeUserRepository) Delete(req repositories.DeleteRestoreRequest) (repositories.DeleteRestoreResponse, error) {
+	for i, user := range r.users {
+		if user.ID.Value() == req.ID.Value() && user.DeletedAt == nil {
+			if req.Version != 0 && req.Version != user.Version {
+				return repositories.DeleteRestoreResponse{}, domainerr.ErrPreconditionFailed
+			}
+			now := vo.NewTime(time.Now(), nil)
+			r.users[i].DeletedAt = &now
+			r.users[i].Version++
+			return repositories.DeleteRestoreResponse{}, nil
+		}
+	}
+	return repositories.DeleteRestoreResponse{}, domainerr.ErrNotFound
+}
+
+func (r *fakeUserRepository) GetByEmail(req repositories.GetByEmailRequest) (repositories.GetByEmailResponse, error) {
+	for _, user := range r.users {
+		if user.Email.Value() == req.Email.Value() && user.DeletedAt == nil {
+			return repositories.GetByEmailResponse{ID: user.ID, Password: user.Password}, nil
+		}
+	}
+	return repositories.GetByEmailResponse{}, domainerr.ErrNotFound
+}
+
+func (r *fakeUserRepository) UpdatePassword(req repositories.UpdatePasswordRequest) (repositories.UpdatePasswordResponse, error) {
+	for i, user := range r.users {
+		if user.ID.Value() == req.ID.Value() && user.Password.Value() == req.OldPassword.Value() {
+			r.users[i].Password = req.Password
+			return repositories.UpdatePasswordResponse{}, nil
+		}
+	}
+	return repositories.UpdatePasswordResponse{}, domainerr.ErrNotFound
+}
+
+// fakePasswordHasher hashes the passwords with a prefix: "v2:" for the current algorithm, "v1:" for the legacy one
+type fakePasswordHasher struct{}
+
+func (fakePasswordHasher) Hash(password string) (string, error) {
+	return "v2:" + password, nil
+}
+
+func (fakePasswordHasher) Verify(hash, password string) (bool, error) {
+	return hash == "v1:"+password || hash == "v2:"+password, nil
+}
+
+func (fakePasswordHasher) NeedsRehash(hash string) bool {
+	return !strings.HasPrefix(hash, "v2:")
+}
+
+// fakeTokenGenerator generates a token containing the user ID
+type fakeTokenGenerator struct{}
+
+func (fakeTokenGenerator) Generate(userID entities.UserID) (entities.AccessToken, error) {
+	return entities.AccessToken{Token: "token-" + userID.String(), ExpiredAt: vo.NewTime(time.Now().Add(time.Hour), nil)}, nil
+}
+
+// fakeAuditLogRepository is an in-memory implementation of the AuditLog repository
+type fakeAuditLogRepository struct {
+	repositories.AuditLog
+	auditLogs []entities.AuditLog
+}
+
+func (r *fakeAuditLogRepository) Create(req repositories.CreateAuditLogRequest) (repositories.CreateAuditLogResponse, error) {
+	r.auditLogs = append(r.auditLogs, req.AuditLog)
+	return repositories.CreateAuditLogResponse{}, nil
+}
+
+// fakeOutboxRepository is an in-memory implementation of the Outbox repository
+type fakeOutboxRepository struct {
+	events []entities.OutboxEvent
+}
+
+func (r *fakeOutboxRepository) Add(req repositories.AddOutboxEventRequest) (repositories.AddOutboxEventResponse, error) {
+	r.events = append(r.events, req.OutboxEvent)
+	return repositories.AddOutboxEventResponse{}, nil
+}
+
+func (r *fakeOutboxRepository) GetPending(req repositories.GetPendingOutboxEventsRequest) (repositories.GetPendingOutboxEventsResponse, error) {
+	pending := make([]entities.OutboxEvent, 0)
+	for _, event := range r.events {
+		if event.DispatchedAt == nil && event.FailedAt == nil && !event.NextAttemptAt.Value().After(req.Now.Value()) {
+			pending = append(pending, event)
+		}
+		if len(pending) == req.Limit {
+			break
+		}
+	}
+	return repositories.GetPendingOutboxEventsResponse{Events: pending}, nil
+}
+
+func (r *fakeOutboxRepository) Lease(req repositories.LeaseOutboxEventsRequest) (repositories.LeaseOutboxEventsResponse, error) {
+	for _, id := range req.IDs {
+		for i, event := range r.events {
+			if event.ID.Value() == id.Value() {
+				r.events[i].NextAttemptAt = req.Until
+			}
+		}
+	}
+	return repositories.LeaseOutboxEventsResponse{}, nil
+}
+
+func (r *fakeOutboxRepository) MarkDispatched(req repositories.MarkOutboxEventDispatchedRequest) (repositories.MarkOutboxEventResponse, error) {
+	for i, event := range r.events {
+		if event.ID.Value() == req.ID.Value() {
+			r.events[i].Attempts++
+			r.events[i].DispatchedAt = &req.DispatchedAt
+		}
+	}
+	return repositories.MarkOutboxEventResponse{}, nil
+}
+
+func (r *fakeOutboxRepository) MarkFailed(req repositories.MarkOutboxEventFailedRequest) (repositories.MarkOutboxEventResponse, error) {
+	for i, event := range r.events {
+		if event.ID.Value() == req.ID.Value() {
+			r.events[i].Attempts = req.Attempts
+			r.events[i].LastError = req.LastError
+			r.events[i].NextAttemptAt = req.NextAttemptAt
+			r.events[i].FailedAt = req.FailedAt
+		}
+	}
+	return repositories.MarkOutboxEventResponse{}, nil
+}
+
+// fakeUnitOfWork runs the function with the fake repositories and records the result
+type fakeUnitOfWork struct {
+	user      *fakeUserRepository
+	auditLog  *fakeAuditLogRepository
+	outbox    *fakeOutboxRepository
+	committed bool
+	running   bool
+}
+
+// newFakeUnitOfWork returns a fake unit of work with empty repositories
+func newFakeUnitOfWork(user *fakeUserRepository) *fakeUnitOfWork {
+	return &fakeUnitOfWork{
+		user:     user,
+		auditLog: &fakeAuditLogRepository{},
+		outbox:   &fakeOutboxRepository{},
+	}
+}
+
+func (u *fakeUnitOfWork) User() repositories.User {
+	return u.user
+}
+
+func (u *fakeUnitOfWork) AuditLog() repositories.AuditLog {
+	return u.auditLog
+}
+
+func (u *fakeUnitOfWork) Outbox() repositories.Outbox {
+	return u.outbox
+}
+
+func (u *fakeUnitOfWork) Do(fn func(repos repositories.Repositories) error) error {
+	u.running = true
+	err := fn(u)
+	u.running = false
+	u.committed = err == nil
+	return err
+}
+
+// fakeWebhookRepository is an in-memory implementation of the Webhook repository
+type fakeWebhookRepository struct {
+	repositories.Webhook
+	webhooks   []entities.Webhook
+	deliveries []entities.WebhookDelivery
+}
+
+func (r *fakeWebhookRepository) find(id entities.WebhookID) int {
+	for i, webhook := range r.webhooks {
+		if webhook.ID.Value() == id.Value() {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *fakeWebhookRepository) Create(req repositories.CreateWebhookRequest) (repositories.CreateWebhookResponse, error) {
+	r.webhooks = append(r.webhooks, req.Webhook)
+	return repositories.CreateWebhookResponse{Webhook: req.Webhook}, nil
+}
+
+func (r *fakeWebhookRepository) GetByID(req repositories.GetWebhookByIDRequest) (repositories.GetWebhookByIDResponse, error) {
+	if i := r.find(req.ID); i >= 0 {
+		return repositories.GetWebhookByIDResponse{Webhook: r.webhooks[i]}, nil
+	}
+	return repositories.GetWebhookByIDResponse{}, domainerr.ErrNotFound
+}
+
+func (r *fakeWebhookRepository) GetEnabledByEvent(req repositories.GetEnabledWebhooksByEventRequest) (repositories.GetEnabledWebhooksByEventResponse, error) {
+	webhooks := make([]entities.Webhook, 0)
+	for _, webhook := range r.webhooks {
+		if webhook.Enabled && webhook.IsSubscribed(req.EventName) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	return repositories.GetEnabledWebhooksByEventResponse{Webhooks: webhooks}, nil
+}
+
+func (r *fakeWebhookRepository) RecordSuccess(req repositories.RecordWebhookSuccessRequest) (repositories.RecordWebhookResultResponse, error) {
+	if i := r.find(req.ID); i >= 0 {
+		r.webhooks[i].ConsecutiveFailures = 0
+	}
+	return repositories.RecordWebhookResultResponse{}, nil
+}
+
+func (r *fakeWebhookRepository) RecordFailure(req repositories.RecordWebhookFailureRequest) (repositories.RecordWebhookResultResponse, error) {
+	if i := r.find(req.ID); i >= 0 {
+		r.webhooks[i].ConsecutiveFailures++
+		if r.webhooks[i].Enabled && r.webhooks[i].ConsecutiveFailures >= req.DisableAfter {
+			r.webhooks[i].Enabled = false
+			r.webhooks[i].DisabledAt = &req.Now
+		}
+	}
+	return repositories.RecordWebhookResultResponse{}, nil
+}
+
+func (r *fakeWebhookRepository) CreateDelivery(req repositories.CreateWebhookDeliveryRequest) (repositories.CreateWebhookDeliveryResponse, error) {
+	r.deliveries = append(r.deliveries, req.WebhookDelivery)
+	return repositories.CreateWebhookDeliveryResponse{}, nil
+}
+
+func (r *fakeWebhookRepository) GetDeliveryByID(req repositories.GetWebhookDeliveryByIDRequest) (repositories.GetWebhookDeliveryByIDResponse, error) {
+	for _, delivery := range r.deliveries {
+		if delivery.ID.Value() == req.ID.Value() && delivery.WebhookID.Value() == req.WebhookID.Value() {
+			return repositories.GetWebhookDeliveryByIDResponse{WebhookDelivery: delivery}, nil
+		}
+	}
+	return repositories.GetWebhookDeliveryByIDResponse{}, domainerr.ErrNotFound
+}
+
+func (r *fakeWebhookRepository) GetLastDelivery(req repositories.GetLastWebhookDeliveryRequest) (repositories.GetLastWebhookDeliveryResponse, error) {
+	for i := len(r.deliveries) - 1; i >= 0; i-- {
+		delivery := r.deliveries[i]
+		if delivery.WebhookID.Value() == req.WebhookID.Value() && delivery.EventID == req.EventID {
+			return repositories.GetLastWebhookDeliveryResponse{WebhookDelivery: delivery}, nil
+		}
+	}
+	return repositories.GetLastWebhookDeliveryResponse{}, domainerr.ErrNotFound
+}
+
+// fakeAPIKeyRepository is an in-memory implementation of the APIKey repository
+type fakeAPIKeyRepository struct {
+	repositories.APIKey
+	apiKeys         []entities.APIKey
+	lastUsedUpdates int
+}
+
+func (r *fakeAPIKeyRepository) Create(req repositories.CreateAPIKeyRequest) (repositories.CreateAPIKeyResponse, error) {
+	r.apiKeys = append(r.apiKeys, req.APIKey)
+	return repositories.CreateAPIKeyResponse{}, nil
+}
+
+func (r *fakeAPIKeyRepository) GetByPrefix(req repositories.GetAPIKeyByPrefixRequest) (repositories.GetAPIKeyByPrefixResponse, error) {
+	for _, apiKey := range r.apiKeys {
+		if apiKey.Prefix == req.Prefix {
+			return repositories.GetAPIKeyByPrefixResponse{APIKey: apiKey}, nil
+		}
+	}
+	return repositories.GetAPIKeyByPrefixResponse{}, domainerr.ErrNotFound
+}
+
+func (r *fakeAPIKeyRepository) Revoke(req repositories.RevokeAPIKeyRequest) (repositories.RevokeAPIKeyResponse, error) {
+	for i, apiKey := range r.apiKeys {
+		if apiKey.ID.Value() == req.ID.Value() && apiKey.UserID.Value() == req.UserID.Value() && apiKey.RevokedAt == nil {
+			r.apiKeys[i].RevokedAt = &req.RevokedAt
+			return repositories.RevokeAPIKeyResponse{}, nil
+		}
+	}
+	return repositories.RevokeAPIKeyResponse{}, domainerr.ErrNotFound
+}
+
+func (r *fakeAPIKeyRepository) UpdateLastUsed(req repositories.UpdateAPIKeyLastUsedRequest) (repositories.UpdateAPIKeyLastUsedResponse, error) {
+	for i, apiKey := range r.apiKeys {
+		if apiKey.ID.Value() == req.ID.Value() {
+			r.apiKeys[i].LastUsedAt = &req.LastUsedAt
+			r.lastUsedUpdates++
+		}
+	}
+	return repositories.UpdateAPIKeyLastUsedResponse{}, nil
+}
+
+// fakeIdempotencyKeyRepository is an in-memory implementation of the IdempotencyKey repository
+type fakeIdempotencyKeyRepository struct {
+	repositories.IdempotencyKey
+	keys map[string]entities.IdempotencyKey
+
+	// beforeDelete is called before a deletion, to simulate a concurrent request
+	beforeDelete func()
+}
+
+func newFakeIdempotencyKeyRepository() *fakeIdempotencyKeyRepository {
+	return &fakeIdempotencyKeyRepository{keys: make(map[string]entities.IdempotencyKey)}
+}
+
+func (r *fakeIdempotencyKeyRepository) Create(req repositories.CreateIdempotencyKeyRequest) (repositories.CreateIdempotencyKeyResponse, error) {
+	if _, ok := r.keys[req.Key]; ok {
+		return repositories.CreateIdempotencyKeyResponse{}, domainerr.ErrConflict
+	}
+	r.keys[req.Key] = req.IdempotencyKey
+	return repositories.CreateIdempotencyKeyResponse{}, nil
+}
+
+func (r *fakeIdempotencyKeyRepository) GetByKey(req repositories.GetIdempotencyKeyRequest) (repositories.GetIdempotencyKeyResponse, error) {
+	key, ok := r.keys[req.Key]
+	if !ok {
+		return repositories.GetIdempotencyKeyResponse{}, domainerr.ErrNotFound
+	}
+	return repositories.GetIdempotencyKeyResponse{IdempotencyKey: key}, nil
+}
+
+func (r *fakeIdempotencyKeyRepository) Complete(req repositories.CompleteIdempotencyKeyRequest) (repositories.CompleteIdempotencyKeyResponse, error) {
+	key := r.keys[req.Key]
+	key.Status = entities.IdempotencyKeyStatusCompleted
+	key.ResponseStatus = req.ResponseStatus
+	key.ResponseHeaders = req.ResponseHeaders
+	key.ResponseBody = req.ResponseBody
+	r.keys[req.Key] = key
+	return repositories.CompleteIdempotencyKeyResponse{}, nil
+}
+
+func (r *fakeIdempotencyKeyRepository) Delete(req repositories.DeleteIdempotencyKeyRequest) (repositories.DeleteIdempotencyKeyResponse, error) {
+	if r.beforeDelete != nil {
+		r.beforeDelete()
+	}
+
+	key, ok := r.keys[req.Key]
+	if !ok || (req.CreatedAt != nil && !key.CreatedAt.Value().Equal(req.CreatedAt.Value())) {
+		return repositories.DeleteIdempotencyKeyResponse{}, nil
+	}
+	delete(r.keys, req.Key)
+	return repositories.DeleteIdempotencyKeyResponse{Deleted: 1}, nil
+}
+
+func (r *fakeIdempotencyKeyRepository) DeleteExpired(req repositories.DeleteExpiredIdempotencyKeysRequest) (repositories.DeleteExpiredIdempotencyKeysResponse, error) {
+	res := repositories.DeleteExpiredIdempotencyKeysResponse{}
+	for id, key := range r.keys {
+		if key.IsExpired(req.Now) {
+			delete(r.keys, id)
+			res.Deleted++
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,231 @@
+package usecases
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"go-clean-api/pkg/domain/entities"
+	domainerr "go-clean-api/pkg/domain/errors"
+	"go-clean-api/pkg/domain/repositories"
+	vo "go-clean-api/pkg/domain/value_objects"
+	"time"
+)
+
+const (
+	// IdempotencyDefaultTTL is the default lifetime of an idempotency key
+	IdempotencyDefaultTTL = 24 * time.Hour
+
+	// IdempotencyDefaultLockTimeout is the default delay after which a request still in progress is considered as lost
+	IdempotencyDefaultLockTimeout = time.Minute
+
+	// idempotencyMaxAttempts is the number of attempts to store a key when a concurrent request deletes it
+	idempotencyMaxAttempts = 3
+)
+
+var (
+	ErrIdempotencyKeyInUse   = domainerr.NewConflict(CodeIdempotencyKeyInUse, "a request with the same idempotency key is in progress")
+	ErrIdempotencyKeyReused  = domainerr.NewUnprocessable(CodeIdempotencyKeyReused, "idempotency key already used for another request")
+	ErrIdempotencyKeyStorage = domainerr.NewInternal("idempotency_key_storage_failed", "error when storing idempotency key")
+)
+
+// Idempotency is an interface for idempotency use cases.
+type Idempotency interface {
+	Begin(BeginIdempotencyRequest) (BeginIdempotencyResponse, error)
+	Complete(CompleteIdempotencyRequest) (CompleteIdempotencyResponse, error)
+	Release(ReleaseIdempotencyRequest) (ReleaseIdempotencyResponse, error)
+	DeleteExpired(DeleteExpiredIdempotencyKeysRequest) (DeleteExpiredIdempotencyKeysResponse, error)
+}
+
+// IdempotencyConfig is the configuration of the Idempotency use case
+type IdempotencyConfig struct {
+	// Lifetime of a key
+	TTL time.Duration
+
+	// Delay after which a request still in progress is considered as lost and can be retried
+	LockTimeout time.Duration
+}
+
+type idempotencyUseCase struct {
+	idempotencyKeyRepository repositories.IdempotencyKey
+	config                   IdempotencyConfig
+}
+
+// NewIdempotency returns a new Idempotency use case
+func NewIdempotency(idempotencyKeyRepository repositories.IdempotencyKey, config IdempotencyConfig) Idempotency {
+	if config.TTL <= 0 {
+		config.TTL = IdempotencyDefaultTTL
+	}
+	if config.LockTimeout <= 0 {
+		config.LockTimeout = IdempotencyDefaultLockTimeout
+	}
+	return &idempotencyUseCase{idempotencyKeyRepository, config}
+}
+
+//
+// ======== Begin ========
+//
+
+// BeginIdempotencyRequest is the data transfer object for the Begin method request.
+type BeginIdempotencyRequest struct {
+	// Key is the Idempotency-Key header sent by the client
+	Key string
+
+	// UserID scopes the key to a user (empty for anonymous requests)
+	UserID string
+
+	// Fingerprint identifies the request (method, path and body)
+	Fingerprint string
+}
+
+// BeginIdempotencyResponse is the data transfer object for the Begin method response.
+type BeginIdempotencyResponse struct {
+	// Key is the stored key to use for Complete and Release
+	Key string
+
+	// Replay is the completed request to replay, nil if the request must be processed
+	Replay *entities.IdempotencyKey
+}
+
+// Begin locks an idempotency key before processing a request.
+//
+// The first request with a key is processed. A duplicate request gets the stored response of the first one
+// once it is completed, ErrIdempotencyKeyInUse while it is in progress and ErrIdempotencyKeyReused if its
+// fingerprint is different. Expired keys and requests in progress for longer than the lock timeout are discarded.
+func (uc idempotencyUseCase) Begin(req BeginIdempotencyRequest) (res BeginIdempotencyResponse, err error) {
+	res.Key = idempotencyStorageKey(req.UserID, req.Key)
+
+	for range idempotencyMaxAttempts {
+		now := vo.NewTime(time.Now(), nil)
+		_, err = uc.idempotencyKeyRepository.Create(repositories.CreateIdempotencyKeyRequest{
+			IdempotencyKey: entities.IdempotencyKey{
+				Key:         res.Key,
+				Fingerprint: req.Fingerprint,
+				Status:      entities.IdempotencyKeyStatusInProgress,
+				CreatedAt:   now,
+				ExpiresAt:   vo.NewTime(now.Value().Add(uc.config.TTL), nil),
+			},
+		})
+		if err == nil {
+			return res, nil
+		}
+		if !errors.Is(err, domainerr.ErrConflict) {
+			return res, ErrIdempotencyKeyStorage.Wrap("idempotency_uc:Begin", err)
+		}
+
+		existing, err := uc.idempotencyKeyRepository.GetByKey(repositories.GetIdempotencyKeyRequest{Key: res.Key})
+		if errors.Is(err, domainerr.ErrNotFound) {
+			continue // Released in the meantime
+		} else if err != nil {
+			return res, ErrIdempotencyKeyStorage.Wrap("idempotency_uc:Begin", err)
+		}
+
+		lockExpiresAt := vo.NewTime(existing.CreatedAt.Value().Add(uc.config.LockTimeout), nil)
+		if existing.IsExpired(now) || (!existing.IsCompleted() && !lockExpiresAt.Value().After(now.Value())) {
+			// The key is deleted only if it is still the one read, another request may have replaced it.
+			// Nothing deleted means that the key has changed: it is read again.
+			if _, err := uc.idempotencyKeyRepository.Delete(repositories.DeleteIdempotencyKeyRequest{
+				Key:       res.Key,
+				CreatedAt: &existing.CreatedAt,
+			}); err != nil {
+				return res, ErrIdempotencyKeyStorage.Wrap("idempotency_uc:Begin", err)
+			}
+			continue
+		}
+
+		if existing.Fingerprint != req.Fingerprint {
+			return res, ErrIdempotencyKeyReused.Wrap("idempotency_uc:Begin", nil)
+		}
+		if !existing.IsCompleted() {
+			return res, ErrIdempotencyKeyInUse.Wrap("idempotency_uc:Begin", nil)
+		}
+
+		res.Replay = &existing.IdempotencyKey
+		return res, nil
+	}
+
+	return res, ErrIdempotencyKeyInUse.Wrap("idempotency_uc:Begin", nil)
+}
+
+//
+// ======== Complete ========
+//
+
+// CompleteIdempotencyRequest is the data transfer object for the Complete method request.
+type CompleteIdempotencyRequest struct {
+	Key             string
+	ResponseStatus  int
+	ResponseHeaders map[string][]string
+	ResponseBody    []byte
+}
+
+// CompleteIdempotencyResponse is the data transfer object for the Complete method response.
+type CompleteIdempotencyResponse struct{}
+
+// Complete stores the response of a request to replay it for the duplicate requests.
+func (uc idempotencyUseCase) Complete(req CompleteIdempotencyRequest) (res CompleteIdempotencyResponse, err error) {
+	_, err = uc.idempotencyKeyRepository.Complete(repositories.CompleteIdempotencyKeyRequest{
+		Key:             req.Key,
+		ResponseStatus:  req.ResponseStatus,
+		ResponseHeaders: req.ResponseHeaders,
+		ResponseBody:    req.ResponseBody,
+	})
+	if err != nil {
+		return res, ErrIdempotencyKeyStorage.Wrap("idempotency_uc:Complete", err)
+	}
+
+	return
+}
+
+//
+// ======== Release ========
+//
+
+// ReleaseIdempotencyRequest is the data transfer object for the Release method request.
+type ReleaseIdempotencyRequest struct {
+	Key string
+}
+
+// ReleaseIdempotencyResponse is the data transfer object for the Release method response.
+type ReleaseIdempotencyResponse struct{}
+
+// Release deletes the key of a request which has failed, so that it can be retried.
+func (uc idempotencyUseCase) Release(req ReleaseIdempotencyRequest) (res ReleaseIdempotencyResponse, err error) {
+	_, err = uc.idempotencyKeyRepository.Delete(repositories.DeleteIdempotencyKeyRequest{Key: req.Key})
+	if err != nil {
+		return res, ErrIdempotencyKeyStorage.Wrap("idempotency_uc:Release", err)
+	}
+
+	return
+}
+
+//
+// ======== DeleteExpired ========
+//
+
+// DeleteExpiredIdempotencyKeysRequest is the data transfer object for the DeleteExpired method request.
+type DeleteExpiredIdempotencyKeysRequest struct{}
+
+// DeleteExpiredIdempotencyKeysResponse is the data transfer object for the DeleteExpired method response.
+type DeleteExpiredIdempotencyKeysResponse struct {
+	Deleted int64
+}
+
+// DeleteExpired deletes the expired keys.
+func (uc idempotencyUseCase) DeleteExpired(req DeleteExpiredIdempotencyKeysRequest) (res DeleteExpiredIdempotencyKeysResponse, err error) {
+	deleted, err := uc.idempotencyKeyRepository.DeleteExpired(repositories.DeleteExpiredIdempotencyKeysRequest{
+		Now: vo.NewTime(time.Now(), nil),
+	})
+	if err != nil {
+		return res, domainerr.ErrDatabase.Wrap("idempotency_uc:DeleteExpired", err)
+	}
+	res.Deleted = deleted.Deleted
+
+	return
+}
+
+// idempotencyStorageKey returns the stored key: the SHA-256 hash of the user ID and the client key,
+// so that two users cannot share a key.
+func idempotencyStorageKey(userID, key string) string {
+	hash := sha256.Sum256([]byte(userID + ":" + key))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"go-clean-api/pkg/domain/entities"
+	vo "go-clean-api/pkg/domain/value_objects"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyBegin(t *testing.T) {
+	repository := newFakeIdempotencyKeyRepository()
+	uc := NewIdempotency(repository, IdempotencyConfig{})
+	req := BeginIdempotencyRequest{Key: "key", UserID: "user", Fingerprint: "fingerprint"}
+
+	// First request
+	res, err := uc.Begin(req)
+	assert.Nil(t, err)
+	assert.Nil(t, res.Replay)
+
+	// Duplicate request in progress
+	_, err = uc.Begin(req)
+	assert.ErrorIs(t, err, ErrIdempotencyKeyInUse)
+
+	// Same key with another request
+	_, err = uc.Begin(BeginIdempotencyRequest{Key: "key", UserID: "user", Fingerprint: "other"})
+	assert.ErrorIs(t, err, ErrIdempotencyKeyReused)
+
+	// Same key for another user
+	other, err := uc.Begin(BeginIdempotencyRequest{Key: "key", UserID: "other", Fingerprint: "other"})
+	assert.Nil(t, err)
+	assert.NotEqual(t, res.Key, other.Key)
+
+	// Duplicate request completed
+	_, err = uc.Complete(CompleteIdempotencyRequest{Key: res.Key, ResponseStatus: 201, ResponseBody: []byte(`{}`)})
+	assert.Nil(t, err)
+
+	replayed, err := uc.Begin(req)
+	assert.Nil(t, err)
+	if assert.NotNil(t, replayed.Replay) {
+		assert.Equal(t, 201, replayed.Replay.ResponseStatus)
+		assert.Equal(t, []byte(`{}`), replayed.Replay.ResponseBody)
+	}
+
+	// Released request can be retried
+	_, err = uc.Release(ReleaseIdempotencyRequest{Key: res.Key})
+	assert.Nil(t, err)
+
+	res, err = uc.Begin(req)
+	assert.Nil(t, err)
+	assert.Nil(t, res.Replay)
+}
+
+func TestIdempotencyBeginDiscardsExpiredKeys(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		key  entities.IdempotencyKey
+	}{
+		{
+			name: "Expired key",
+			key: entities.IdempotencyKey{
+				Status:    entities.IdempotencyKeyStatusCompleted,
+				CreatedAt: vo.NewTime(now.Add(-2*time.Hour), nil),
+				ExpiresAt: vo.NewTime(now.Add(-time.Hour), nil),
+			},
+		},
+		{
+			name: "Lost request in progress",
+			key: entities.IdempotencyKey{
+				Status:    entities.IdempotencyKeyStatusInProgress,
+				CreatedAt: vo.NewTime(now.Add(-2*time.Minute), nil),
+				ExpiresAt: vo.NewTime(now.Add(time.Hour), nil),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := newFakeIdempotencyKeyRepository()
+			uc := NewIdempotency(repository, IdempotencyConfig{LockTimeout: time.Minute})
+
+			tt.key.Key = idempotencyStorageKey("user", "key")
+			tt.key.Fingerprint = "fingerprint"
+			repository.keys[tt.key.Key] = tt.key
+
+			res, err := uc.Begin(BeginIdempotencyRequest{Key: "key", UserID: "user", Fingerprint: "fingerprint"})
+			assert.Nil(t, err)
+			assert.Nil(t, res.Replay)
+			assert.Equal(t, entities.IdempotencyKeyStatusInProgress, repository.keys[res.Key].Status)
+
+			deleted, err := uc.DeleteExpired(DeleteExpiredIdempotencyKeysRequest{})
+			assert.Nil(t, err)
+			assert.Equal(t, int64(0), deleted.Deleted)
+		})
+	}
+}
+
+func TestIdempotencyBeginKeepsReplacedKeys(t *testing.T) {
+	now := time.Now()
+	repository := newFakeIdempotencyKeyRepository()
+	uc := NewIdempotency(repository, IdempotencyConfig{LockTimeout: time.Minute})
+
+	key := idempotencyStorageKey("user", "key")
+	repository.keys[key] = entities.IdempotencyKey{
+		Key:         key,
+		Fingerprint: "fingerprint",
+		Status:      entities.IdempotencyKeyStatusInProgress,
+		CreatedAt:   vo.NewTime(now.Add(-2*time.Minute), nil),
+		ExpiresAt:   vo.NewTime(now.Add(time.Hour), nil),
+	}
+
+	// Another request discards the lost key and locks it again before the deletion
+	replaced := entities.IdempotencyKey{
+		Key:         key,
+		Fingerprint: "fingerprint",
+		Status:      entities.IdempotencyKeyStatusInProgress,
+		CreatedAt:   vo.NewTime(now, nil),
+		ExpiresAt:   vo.NewTime(now.Add(time.Hour), nil),
+	}
+	repository.beforeDelete = func() {
+		repository.keys[key] = replaced
+		repository.beforeDelete = nil
+	}
+
+	_, err := uc.Begin(BeginIdempotencyRequest{Key: "key", UserID: "user", Fingerprint: "fingerprint"})
+	assert.ErrorIs(t, err, ErrIdempotencyKeyInUse)
+	assert.Equal(t, replaced, repository.keys[key])
+}
@@ -0,0 +1,192 @@
+package usecases
+
+import (
+	"encoding/json"
+	"go-clean-api/pkg/domain/entities"
+	domainerr "go-clean-api/pkg/domain/errors"
+	"go-clean-api/pkg/domain/events"
+	"go-clean-api/pkg/domain/repositories"
+	"go-clean-api/pkg/domain/services"
+	vo "go-clean-api/pkg/domain/value_objects"
+	"time"
+)
+
+const (
+	// OutboxDefaultMaxAttempts is the default number of delivery attempts of an event
+	OutboxDefaultMaxAttempts = 10
+
+	// OutboxDefaultLeaseDuration is the default duration during which the claimed events are reserved to a dispatcher
+	OutboxDefaultLeaseDuration = 5 * time.Minute
+
+	// outboxRetryBaseDelay is the delay before the second attempt, doubled for each new attempt
+	outboxRetryBaseDelay = 5 * time.Second
+
+	// outboxRetryMaxDelay is the maximum delay between two attempts
+	outboxRetryMaxDelay = time.Hour
+)
+
+var (
+	ErrEventRaising = domainerr.NewInternal("event_raising_failed", "error when raising event")
+)
+
+// Outbox is an interface for outbox use cases.
+type Outbox interface {
+	Dispatch(DispatchOutboxRequest) (DispatchOutboxResponse, error)
+}
+
+type outboxUseCase struct {
+	unitOfWork    repositories.UnitOfWork
+	publisher     services.EventPublisher
+	maxAttempts   int
+	leaseDuration time.Duration
+}
+
+// NewOutbox returns a new Outbox use case.
+// The lease duration must be longer than the publication of a batch of events.
+func NewOutbox(unitOfWork repositories.UnitOfWork, publisher services.EventPublisher, maxAttempts int, leaseDuration time.Duration) Outbox {
+	if maxAttempts <= 0 {
+		maxAttempts = OutboxDefaultMaxAttempts
+	}
+	if leaseDuration <= 0 {
+		leaseDuration = OutboxDefaultLeaseDuration
+	}
+	return &outboxUseCase{unitOfWork, publisher, maxAttempts, leaseDuration}
+}
+
+//
+// ======== Dispatch ========
+//
+
+// DispatchOutboxRequest is the data transfer object for the Dispatch method request.
+type DispatchOutboxRequest struct {
+	Limit int
+}
+
+// DispatchOutboxResponse is the data transfer object for the Dispatch method response.
+type DispatchOutboxResponse struct {
+	Dispatched int
+	Failed     int
+}
+
+// Dispatch delivers a batch of pending events to the publisher.
+//
+// The pending events are claimed in a short transaction which leases them, so several dispatchers can run concurrently.
+// They are then published outside of any transaction and the result of each publication is recorded in its own transaction.
+// An event is marked as dispatched only after a successful delivery (at-least-once semantics),
+// otherwise it is retried later with an exponential backoff until the max number of attempts is reached.
+// A claimed event which is not marked (after a crash for example) is pending again at the end of its lease.
+func (uc outboxUseCase) Dispatch(req DispatchOutboxRequest) (res DispatchOutboxResponse, err error) {
+	now := vo.NewTime(time.Now(), nil)
+
+	// Claim
+	var pending []entities.OutboxEvent
+	errUoW := uc.unitOfWork.Do(func(repos repositories.Repositories) error {
+		res, err := repos.Outbox().GetPending(repositories.GetPendingOutboxEventsRequest{
+			Limit: req.Limit,
+			Now:   now,
+		})
+		if err != nil {
+			return err
+		}
+		pending = res.Events
+
+		ids := make([]entities.OutboxEventID, len(pending))
+		for i, event := range pending {
+			ids[i] = event.ID
+		}
+		_, err = repos.Outbox().Lease(repositories.LeaseOutboxEventsRequest{
+			IDs:   ids,
+			Until: vo.NewTime(now.Value().Add(uc.leaseDuration), nil),
+		})
+		return err
+	})
+	if errUoW != nil {
+		err = domainerr.ErrDatabase.Wrap("outbox_uc:Dispatch", errUoW)
+		return
+	}
+
+	// Publication
+	for _, event := range pending {
+		errPublish := uc.publisher.Publish(event)
+
+		errUoW := uc.unitOfWork.Do(func(repos repositories.Repositories) error {
+			if errPublish == nil {
+				_, err := repos.Outbox().MarkDispatched(repositories.MarkOutboxEventDispatchedRequest{
+					ID:           event.ID,
+					DispatchedAt: vo.NewTime(time.Now(), nil),
+				})
+				return err
+			}
+
+			attempts := event.Attempts + 1
+			var failedAt *vo.Time
+			if attempts >= uc.maxAttempts {
+				failedAt = &now
+			}
+			_, err := repos.Outbox().MarkFailed(repositories.MarkOutboxEventFailedRequest{
+				ID:            event.ID,
+				Attempts:      attempts,
+				LastError:     errPublish.Error(),
+				NextAttemptAt: vo.NewTime(time.Now().Add(outboxRetryDelay(attempts)), nil),
+				FailedAt:      failedAt,
+			})
+			return err
+		})
+		if errUoW != nil {
+			err = domainerr.ErrDatabase.Wrap("outbox_uc:Dispatch", errUoW)
+			return
+		}
+
+		if errPublish == nil {
+			res.Dispatched++
+		} else {
+			res.Failed++
+		}
+	}
+
+	return
+}
+
+// outboxRetryDelay returns the delay before the next attempt after n failed attempts.
+func outboxRetryDelay(attempts int) time.Duration {
+	delay := outboxRetryBaseDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= outboxRetryMaxDelay {
+			return outboxRetryMaxDelay
+		}
+	}
+	return delay
+}
+
+//
+// ======== Raising ========
+//
+
+// raiseEvent adds a domain event to the outbox with the repositories of the unit of work,
+// so it is stored in the same transaction as the change which raised it.
+// The request ID of the actor is propagated to the publishers.
+func raiseEvent(repos repositories.Repositories, actor entities.Actor, event events.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return ErrEventRaising.Wrap("", err)
+	}
+
+	now := vo.NewTime(time.Now(), nil)
+	_, err = repos.Outbox().Add(repositories.AddOutboxEventRequest{
+		OutboxEvent: entities.OutboxEvent{
+			ID:            vo.NewID(),
+			Name:          string(event.Name()),
+			AggregateID:   event.AggregateID(),
+			Payload:       payload,
+			RequestID:     actor.RequestID,
+			OccurredAt:    now,
+			NextAttemptAt: now,
+		},
+	})
+	if err != nil {
+		return ErrEventRaising.Wrap("", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package usecases
+
+import (
+	"errors"
+	"go-clean-api/pkg/domain/entities"
+	"go-clean-api/pkg/domain/repositories"
+	vo "go-clean-api/pkg/domain/value_objects"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakePublisher records the published events and fails if err is not nil
+type fakePublisher struct {
+	published []entities.OutboxEvent
+	err       error
+}
+
+func (p *fakePublisher) Publish(event entities.OutboxEvent) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.published = append(p.published, event)
+	return nil
+}
+
+func newPendingEvent() entities.OutboxEvent {
+	now := vo.NewTime(time.Now().Add(-time.Second), nil)
+	return entities.OutboxEvent{
+		ID:            vo.NewID(),
+		Name:          "user.created",
+		Payload:       []byte(`{}`),
+		OccurredAt:    now,
+		NextAttemptAt: now,
+	}
+}
+
+func TestOutboxDispatch(t *testing.T) {
+	uow := newFakeUnitOfWork(&fakeUserRepository{})
+	uow.outbox.events = []entities.OutboxEvent{newPendingEvent(), newPendingEvent(), newPendingEvent()}
+	publisher := &fakePublisher{}
+	uc := NewOutbox(uow, publisher, 3, 0)
+
+	res, err := uc.Dispatch(DispatchOutboxRequest{Limit: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, res.Dispatched)
+	assert.Equal(t, 0, res.Failed)
+	assert.Equal(t, 2, len(publisher.published))
+
+	res, err = uc.Dispatch(DispatchOutboxRequest{Limit: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, res.Dispatched)
+	assert.Equal(t, 3, len(publisher.published))
+
+	// Nothing left to dispatch
+	res, err = uc.Dispatch(DispatchOutboxRequest{Limit: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, res.Dispatched)
+}
+
+func TestOutboxDispatchWithFailures(t *testing.T) {
+	uow := newFakeUnitOfWork(&fakeUserRepository{})
+	uow.outbox.events = []entities.OutboxEvent{newPendingEvent()}
+	publisher := &fakePublisher{err: errors.New("publisher unavailable")}
+	uc := NewOutbox(uow, publisher, 2, 0)
+
+	// First failure: the event is retried later
+	res, err := uc.Dispatch(DispatchOutboxRequest{Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, res.Failed)
+
+	event := uow.outbox.events[0]
+	assert.Equal(t, 1, event.Attempts)
+	assert.Equal(t, "publisher unavailable", event.LastError)
+	assert.Nil(t, event.FailedAt)
+	assert.True(t, event.NextAttemptAt.Value().After(time.Now()))
+
+	// The event is not pending before the next attempt date
+	res, err = uc.Dispatch(DispatchOutboxRequest{Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, res.Failed)
+
+	// Last attempt: the event is marked as failed
+	uow.outbox.events[0].NextAttemptAt = vo.NewTime(time.Now().Add(-time.Second), nil)
+	res, err = uc.Dispatch(DispatchOutboxRequest{Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, res.Failed)
+	assert.Equal(t, 2, uow.outbox.events[0].Attempts)
+	assert.NotNil(t, uow.outbox.events[0].FailedAt)
+}
+
+// outsideTxPublisher checks that the events are published outside of a transaction and while they are leased
+type outsideTxPublisher struct {
+	t   *testing.T
+	uow *fakeUnitOfWork
+}
+
+func (p *outsideTxPublisher) Publish(event entities.OutboxEvent) error {
+	assert.False(p.t, p.uow.running)
+
+	pending, err := p.uow.outbox.GetPending(repositories.GetPendingOutboxEventsRequest{Limit: 10, Now: vo.NewTime(time.Now(), nil)})
+	assert.Nil(p.t, err)
+	assert.Empty(p.t, pending.Events)
+	return nil
+}
+
+func TestOutboxDispatchOutsideTransaction(t *testing.T) {
+	uow := newFakeUnitOfWork(&fakeUserRepository{})
+	uow.outbox.events = []entities.OutboxEvent{newPendingEvent(), newPendingEvent()}
+	uc := NewOutbox(uow, &outsideTxPublisher{t: t, uow: uow}, 3, time.Minute)
+
+	res, err := uc.Dispatch(DispatchOutboxRequest{Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, res.Dispatched)
+	for _, event := range uow.outbox.events {
+		assert.NotNil(t, event.DispatchedAt)
+	}
+}
+
+func TestOutboxRetryDelay(t *testing.T) {
+	assert.Equal(t, 5*time.Second, outboxRetryDelay(1))
+	assert.Equal(t, 10*time.Second, outboxRetryDelay(2))
+	assert.Equal(t, 40*time.Second, outboxRetryDelay(4))
+	assert.Equal(t, time.Hour, outboxRetryDelay(20))
+}
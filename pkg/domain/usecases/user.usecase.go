@@ -2,20 +2,22 @@ package usecases
 
 import (
 	"errors"
-	"fmt"
 	domainerr "go-clean-api/pkg/domain/errors"
 	"go-clean-api/pkg/domain/entities"
+	"go-clean-api/pkg/domain/events"
 	"go-clean-api/pkg/domain/repositories"
 	"go-clean-api/pkg/domain/services"
 	vo "go-clean-api/pkg/domain/value_objects"
+	"iter"
+	"slices"
 	"time"
 )
 
 var (
-	ErrInvalidPassword     = errors.New("invalid password")
-	ErrHashPassword        = errors.New("error when hashing password")
-	ErrAccessTokenCreation = errors.New("error when creating access token")
-	ErrUserCreation        = errors.New("error when creating user")
+	ErrInvalidPassword     = domainerr.NewValidation("invalid_password", "invalid password")
+	ErrHashPassword        = domainerr.NewInternal("password_hashing_failed", "error when hashing password")
+	ErrAccessTokenCreation = domainerr.NewInternal("access_token_creation_failed", "error when creating access token")
+	ErrUserCreation        = domainerr.NewInternal("user_creation_failed", "error when creating user")
 )
 
 // User is an interface for user use cases.
@@ -24,6 +26,7 @@ type User interface {
 	Create(CreateUserRequest) (CreateUserResponse, error)
 	GetByID(GetUserByIDRequest) (GetUserByIDResponse, error)
 	GetAll(GetAllUsersRequest) (GetAllUsersResponse, error)
+	Stream(StreamUsersRequest) (StreamUsersResponse, error)
 	Delete(DeleteRestoreUserRequest) (DeleteRestoreUserResponse, error)
 	Restore(DeleteRestoreUserRequest) (DeleteRestoreUserResponse, error)
 }
@@ -31,11 +34,16 @@ type User interface {
 type userUseCase struct {
 	tokenGenerator services.TokenGenerator
 	userRepository repositories.User
+	unitOfWork     repositories.UnitOfWork
+	passwordHasher services.PasswordHasher
+	passwordPolicy vo.PasswordPolicy
+	emailPolicy    vo.EmailPolicy
 }
 
-// NewUser returns a new User use case
-func NewUser(userRepository repositories.User, tokenGenerator services.TokenGenerator) User {
-	return &userUseCase{tokenGenerator, userRepository}
+// NewUser returns a new User use case.
+// The password and email policies are checked for the new users only.
+func NewUser(userRepository repositories.User, unitOfWork repositories.UnitOfWork, tokenGenerator services.TokenGenerator, passwordHasher services.PasswordHasher, passwordPolicy vo.PasswordPolicy, emailPolicy vo.EmailPolicy) User {
+	return &userUseCase{tokenGenerator, userRepository, unitOfWork, passwordHasher, passwordPolicy, emailPolicy}
 }
 
 //
@@ -59,23 +67,31 @@ func (uc userUseCase) GetAccessToken(req GetAccessTokenRequest) (res GetAccessTo
 	userRepo, errRepo := uc.userRepository.GetByEmail(repositories.GetByEmailRequest{Email: req.Email})
 	if errRepo != nil {
 		if errors.Is(errRepo, domainerr.ErrNotFound) {
-			err = fmt.Errorf("[user_uc:GetAccessToken %w: %s]", domainerr.ErrNotFound, errRepo)
+			err = ErrInvalidCredentials.Wrap("user_uc:GetAccessToken", errRepo)
 		} else {
-			err = fmt.Errorf("[user_uc:GetAccessToken %w: %s]", domainerr.ErrDatabase, errRepo)
+			err = domainerr.ErrDatabase.Wrap("user_uc:GetAccessToken", errRepo)
 		}
 		return
 	}
 
 	// Compare the password
-	if userRepo.Password.Verify(req.Password.Value()) != nil {
-		err = fmt.Errorf("[user_uc:GetAccessToken %w]", ErrInvalidPassword)
+	valid, errVerify := uc.passwordHasher.Verify(userRepo.Password.Value(), req.Password.Value())
+	if errVerify != nil {
+		err = ErrInvalidCredentials.Wrap("user_uc:GetAccessToken", errVerify)
 		return
 	}
+	if !valid {
+		err = ErrInvalidCredentials.Wrap("user_uc:GetAccessToken", ErrInvalidPassword)
+		return
+	}
+
+	// Upgrade a legacy hash (other algorithm or parameters)
+	uc.rehashPassword(userRepo, req.Password)
 
 	// Generate a token
 	accessToken, errToken := uc.tokenGenerator.Generate(userRepo.ID)
 	if errToken != nil {
-		err = fmt.Errorf("[user_uc:GetAccessToken %w: %s]", ErrAccessTokenCreation, errToken)
+		err = ErrAccessTokenCreation.Wrap("user_uc:GetAccessToken", errToken)
 		return
 	}
 
@@ -84,6 +100,29 @@ func (uc userUseCase) GetAccessToken(req GetAccessTokenRequest) (res GetAccessTo
 	return
 }
 
+// rehashPassword replaces the hash of a user password if it does not use the configured algorithm and parameters.
+// It is done on a best effort basis: the legacy hash stays valid and is rehashed at the next login if it fails.
+func (uc userUseCase) rehashPassword(user repositories.GetByEmailResponse, password vo.Password) {
+	if !uc.passwordHasher.NeedsRehash(user.Password.Value()) {
+		return
+	}
+
+	hashedPassword, err := uc.passwordHasher.Hash(password.Value())
+	if err != nil {
+		return
+	}
+	newPassword, err := vo.NewPassword(hashedPassword)
+	if err != nil {
+		return
+	}
+
+	uc.userRepository.UpdatePassword(repositories.UpdatePasswordRequest{
+		ID:          user.ID,
+		OldPassword: user.Password,
+		Password:    newPassword,
+	})
+}
+
 //
 // ======== Create ========
 //
@@ -93,6 +132,10 @@ type CreateUserRequest struct {
 	Password  vo.Password
 	Lastname  string
 	Firstname string
+	Actor     entities.Actor
+
+	// Locale of the messages of the password policy errors (default locale if empty)
+	Locale string
 }
 
 type CreateUserResponse struct {
@@ -101,37 +144,63 @@ type CreateUserResponse struct {
 
 // Create a new user.
 func (uc userUseCase) Create(req CreateUserRequest) (res CreateUserResponse, err error) {
+	// Check the email domain
+	if errs := uc.emailPolicy.Validate(req.Email); len(errs) > 0 {
+		err = ErrEmailNotAllowed.Wrap("user_uc:Create", &errs).With("errors", errs)
+		return
+	}
+
+	// Check the password policy
+	if errs := uc.passwordPolicy.ValidateLocale(req.Password, req.Locale); len(errs) > 0 {
+		err = ErrWeakPassword.Wrap("user_uc:Create", &errs).With("errors", errs)
+		return
+	}
+
 	// Hash password
-	hashedPassword, errHash := req.Password.HashUserPassword()
+	hashedPassword, errHash := uc.passwordHasher.Hash(req.Password.Value())
 	if errHash != nil {
-		err = fmt.Errorf("[user_uc:Create %w: %s]", ErrHashPassword, errHash)
+		err = ErrHashPassword.Wrap("user_uc:Create", errHash)
 		return
 	}
 	password, errPassword := vo.NewPassword(hashedPassword)
 	if errPassword != nil {
-		err = fmt.Errorf("[user_uc:Create %w: %s]", ErrInvalidPassword, errPassword)
+		err = ErrHashPassword.Wrap("user_uc:Create", errPassword)
 		return
 	}
 
-	// Add user to the database
+	// Add user to the database and record the action
 	now := vo.NewTime(time.Now(), nil)
-	respoRes, errRepo := uc.userRepository.Create(repositories.CreateUserRequest{
-		ID:        vo.NewID(),
-		Email:     req.Email,
-		Password:  password,
-		Lastname:  req.Lastname,
-		Firstname: req.Firstname,
-		CreatedAt: now,
-		UpdatedAt: now,
+	errUoW := uc.unitOfWork.Do(func(repos repositories.Repositories) error {
+		respoRes, errRepo := repos.User().Create(repositories.CreateUserRequest{
+			ID:        vo.NewID(),
+			Email:     req.Email,
+			Password:  password,
+			Lastname:  req.Lastname,
+			Firstname: req.Firstname,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+		if errRepo != nil {
+			return ErrUserCreation.Wrap("", errRepo)
+		}
+		res.User = respoRes.User
+
+		if err := recordUserAudit(repos, req.Actor, entities.AuditActionUserCreated, nil, &res.User); err != nil {
+			return err
+		}
+
+		return raiseEvent(repos, req.Actor, events.NewUserCreated(res.User))
 	})
-	if errRepo != nil {
-		err = fmt.Errorf("[user_uc:Create %w: %s]", ErrUserCreation, errRepo)
-		return
+	if errUoW != nil {
+		if errors.Is(errUoW, domainerr.ErrConflict) {
+			err = ErrEmailTaken.Wrap("user_uc:Create", errUoW)
+		} else {
+			err = ErrUserCreation.Wrap("user_uc:Create", errUoW)
+		}
+		return CreateUserResponse{}, err
 	}
 
-	return CreateUserResponse{
-		User: respoRes.User,
-	}, nil
+	return
 }
 
 //
@@ -153,9 +222,9 @@ func (uc userUseCase) GetByID(req GetUserByIDRequest) (GetUserByIDResponse, erro
 	res, err := uc.userRepository.GetByID(repositories.GetByIDRequest{ID: req.ID})
 	if err != nil {
 		if errors.Is(err, domainerr.ErrNotFound) {
-			return GetUserByIDResponse{}, fmt.Errorf("[user_uc:GetByID %w: %s]", domainerr.ErrNotFound, err)
+			return GetUserByIDResponse{}, ErrUserNotFound.Wrap("user_uc:GetByID", err).With("id", req.ID.String())
 		}
-		return GetUserByIDResponse{}, fmt.Errorf("[user_uc:GetByID %w: %s]", domainerr.ErrDatabase, err)
+		return GetUserByIDResponse{}, domainerr.ErrDatabase.Wrap("user_uc:GetByID", err)
 	}
 
 	return GetUserByIDResponse{
@@ -180,25 +249,35 @@ type GetAllUsersResponse struct {
 }
 
 // GetAll returns all users (pagination).
+// The total and the users are read in the same transaction to get consistent results.
 func (uc userUseCase) GetAll(req GetAllUsersRequest) (res GetAllUsersResponse, err error) {
-	// Get total users
-	resTotal, errTotal := uc.userRepository.CountAll(repositories.CountAllRequest{Deleted: req.Deleted})
-	if errTotal != nil {
-		err = fmt.Errorf("[user_uc:GetAll %w: %s]", domainerr.ErrDatabase, errTotal)
-		return
-	}
-	total := resTotal.Total
-
+	var total int64
 	users := []entities.User{}
-	if total > 0 {
-		// Get users
-		resUsers, errUsers := uc.userRepository.GetAll(repositories.GetAllRequest{Pagination: req.Pagination, Deleted: req.Deleted})
-		if errUsers != nil {
-			err = fmt.Errorf("[user_uc:GetAll %w: %s]", domainerr.ErrDatabase, errUsers)
-			return
+
+	errUoW := uc.unitOfWork.Do(func(repos repositories.Repositories) error {
+		// Get total users
+		resTotal, errTotal := repos.User().CountAll(repositories.CountAllRequest{Deleted: req.Deleted})
+		if errTotal != nil {
+			return errTotal
+		}
+		total = resTotal.Total
+
+		users = []entities.User{}
+		if total > 0 {
+			// Get users
+			resUsers, errUsers := repos.User().GetAll(repositories.GetAllRequest{Pagination: req.Pagination, Deleted: req.Deleted})
+			if errUsers != nil {
+				return errUsers
+			}
+
+			users = resUsers.Users
 		}
 
-		users = resUsers.Users
+		return nil
+	})
+	if errUoW != nil {
+		err = domainerr.ErrDatabase.Wrap("user_uc:GetAll", errUoW)
+		return
 	}
 
 	return GetAllUsersResponse{
@@ -207,13 +286,70 @@ func (uc userUseCase) GetAll(req GetAllUsersRequest) (res GetAllUsersResponse, e
 	}, nil
 }
 
+//
+// ======== Stream ========
+//
+
+// errUsersStreamStopped stops the reading of the users when their iteration is stopped
+var errUsersStreamStopped = errors.New("users stream stopped")
+
+// StreamUsersRequest is the data transfer object for the Stream method request.
+type StreamUsersRequest struct {
+	Pagination vo.Pagination
+	Deleted    bool
+}
+
+// StreamUsersResponse is the data transfer object for the Stream method response.
+// Users reads the users from the repository while they are iterated, it yields an error and stops if the reading fails.
+type StreamUsersResponse struct {
+	Users iter.Seq2[entities.User, error]
+	Total int64
+}
+
+// Stream returns all users (pagination) without loading them in memory.
+// Unlike GetAll, the total and the users are not read in the same transaction: no transaction is held
+// while the users are iterated.
+func (uc userUseCase) Stream(req StreamUsersRequest) (res StreamUsersResponse, err error) {
+	resTotal, err := uc.userRepository.CountAll(repositories.CountAllRequest{Deleted: req.Deleted})
+	if err != nil {
+		err = domainerr.ErrDatabase.Wrap("user_uc:Stream", err)
+		return
+	}
+
+	res.Total = resTotal.Total
+	res.Users = func(yield func(entities.User, error) bool) {
+		if res.Total == 0 {
+			return
+		}
+
+		_, errRepo := uc.userRepository.StreamAll(repositories.StreamAllRequest{
+			Pagination: req.Pagination,
+			Deleted:    req.Deleted,
+			Each: func(user entities.User) error {
+				if !yield(user, nil) {
+					return errUsersStreamStopped
+				}
+				return nil
+			},
+		})
+		if errRepo != nil && !errors.Is(errRepo, errUsersStreamStopped) {
+			yield(entities.User{}, domainerr.ErrDatabase.Wrap("user_uc:Stream", errRepo))
+		}
+	}
+
+	return
+}
+
 //
 // ======== Delete / Restore ========
 //
 
 // DeleteRestoreUserRequest is the data transfer object for the DeleteD method request.
+// If Versions is not empty, the user must have one of these versions (optimistic concurrency control).
 type DeleteRestoreUserRequest struct {
-	ID entities.UserID
+	ID       entities.UserID
+	Versions []int
+	Actor    entities.Actor
 }
 
 // DeleteRestoreUserResponse is the data transfer object for the DeleteD method response.
@@ -221,12 +357,40 @@ type DeleteRestoreUserResponse struct{}
 
 // Delete a user by its ID.
 func (uc userUseCase) Delete(req DeleteRestoreUserRequest) (DeleteRestoreUserResponse, error) {
-	_, err := uc.userRepository.Delete(repositories.DeleteRestoreRequest{ID: req.ID})
+	err := uc.unitOfWork.Do(func(repos repositories.Repositories) error {
+		before, err := repos.User().GetByID(repositories.GetByIDRequest{ID: req.ID})
+		if err != nil {
+			return err
+		}
+
+		version, err := checkUserVersion(before.User, req.Versions)
+		if err != nil {
+			return err
+		}
+
+		if _, err = repos.User().Delete(repositories.DeleteRestoreRequest{ID: req.ID, Version: version}); err != nil {
+			return err
+		}
+
+		after, err := repos.User().GetByID(repositories.GetByIDRequest{ID: req.ID, Deleted: true})
+		if err != nil {
+			return err
+		}
+
+		if err := recordUserAudit(repos, req.Actor, entities.AuditActionUserDeleted, &before.User, &after.User); err != nil {
+			return err
+		}
+
+		return raiseEvent(repos, req.Actor, events.NewUserDeleted(req.ID))
+	})
 	if err != nil {
 		if errors.Is(err, domainerr.ErrNotFound) {
-			return DeleteRestoreUserResponse{}, fmt.Errorf("[user_uc:Delete %w: %s]", domainerr.ErrNotFound, err)
+			return DeleteRestoreUserResponse{}, ErrUserNotFound.Wrap("user_uc:Delete", err).With("id", req.ID.String())
+		}
+		if errors.Is(err, domainerr.ErrPreconditionFailed) {
+			return DeleteRestoreUserResponse{}, ErrUserVersionMismatch.Wrap("user_uc:Delete", err).With("id", req.ID.String())
 		}
-		return DeleteRestoreUserResponse{}, fmt.Errorf("[user_uc:Delete %w: %s]", domainerr.ErrDatabase, err)
+		return DeleteRestoreUserResponse{}, domainerr.ErrDatabase.Wrap("user_uc:Delete", err)
 	}
 
 	return DeleteRestoreUserResponse{}, nil
@@ -234,13 +398,53 @@ func (uc userUseCase) Delete(req DeleteRestoreUserRequest) (DeleteRestoreUserRes
 
 // Restore a user by its ID.
 func (uc userUseCase) Restore(req DeleteRestoreUserRequest) (DeleteRestoreUserResponse, error) {
-	_, err := uc.userRepository.Restore(repositories.DeleteRestoreRequest{ID: req.ID})
+	err := uc.unitOfWork.Do(func(repos repositories.Repositories) error {
+		before, err := repos.User().GetByID(repositories.GetByIDRequest{ID: req.ID, Deleted: true})
+		if err != nil {
+			return err
+		}
+
+		version, err := checkUserVersion(before.User, req.Versions)
+		if err != nil {
+			return err
+		}
+
+		if _, err = repos.User().Restore(repositories.DeleteRestoreRequest{ID: req.ID, Version: version}); err != nil {
+			return err
+		}
+
+		after, err := repos.User().GetByID(repositories.GetByIDRequest{ID: req.ID})
+		if err != nil {
+			return err
+		}
+
+		if err := recordUserAudit(repos, req.Actor, entities.AuditActionUserRestored, &before.User, &after.User); err != nil {
+			return err
+		}
+
+		return raiseEvent(repos, req.Actor, events.NewUserRestored(req.ID))
+	})
 	if err != nil {
 		if errors.Is(err, domainerr.ErrNotFound) {
-			return DeleteRestoreUserResponse{}, fmt.Errorf("[user_uc:Restore %w: %s]", domainerr.ErrNotFound, err)
+			return DeleteRestoreUserResponse{}, ErrUserNotFound.Wrap("user_uc:Restore", err).With("id", req.ID.String())
+		}
+		if errors.Is(err, domainerr.ErrPreconditionFailed) {
+			return DeleteRestoreUserResponse{}, ErrUserVersionMismatch.Wrap("user_uc:Restore", err).With("id", req.ID.String())
 		}
-		return DeleteRestoreUserResponse{}, fmt.Errorf("[user_uc:Restore %w: %s]", domainerr.ErrDatabase, err)
+		return DeleteRestoreUserResponse{}, domainerr.ErrDatabase.Wrap("user_uc:Restore", err)
 	}
 
 	return DeleteRestoreUserResponse{}, nil
 }
+
+// checkUserVersion checks that the user has one of the expected versions and returns
+// the version which must not change until the update (0 if there is no expected version).
+func checkUserVersion(user entities.User, versions []int) (int, error) {
+	if len(versions) == 0 {
+		return 0, nil
+	}
+	if !slices.Contains(versions, user.Version) {
+		return 0, domainerr.ErrPreconditionFailed
+	}
+	return user.Version, nil
+}
@@ -1 +1,277 @@
 package usecases
+
+import (
+	"errors"
+	"fmt"
+	"go-clean-api/pkg/domain/entities"
+	domainerr "go-clean-api/pkg/domain/errors"
+	"go-clean-api/pkg/domain/validation"
+	vo "go-clean-api/pkg/domain/value_objects"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAll(t *testing.T) {
+	users := []entities.User{{ID: vo.NewID()}, {ID: vo.NewID()}}
+	pagination := vo.NewPagination(1, 50, 0)
+
+	tests := []struct {
+		name          string
+		repository    *fakeUserRepository
+		wantedTotal   int64
+		wantedUsers   int
+		wantedErr     error
+		wantCommitted bool
+	}{
+		{
+			name:          "Users found",
+			repository:    &fakeUserRepository{users: users},
+			wantedTotal:   2,
+			wantedUsers:   2,
+			wantCommitted: true,
+		},
+		{
+			name:          "No user",
+			repository:    &fakeUserRepository{},
+			wantedTotal:   0,
+			wantedUsers:   0,
+			wantCommitted: true,
+		},
+		{
+			name:          "Error when counting users",
+			repository:    &fakeUserRepository{users: users, errCount: errors.New("count error")},
+			wantedErr:     domainerr.ErrDatabase,
+			wantCommitted: false,
+		},
+		{
+			name:          "Error when getting users",
+			repository:    &fakeUserRepository{users: users, errGet: errors.New("get error")},
+			wantedErr:     domainerr.ErrDatabase,
+			wantCommitted: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uow := newFakeUnitOfWork(tt.repository)
+			uc := NewUser(tt.repository, uow, nil, fakePasswordHasher{}, vo.PasswordPolicy{}, vo.EmailPolicy{})
+
+			res, err := uc.GetAll(GetAllUsersRequest{Pagination: pagination})
+
+			assert.Equal(t, tt.wantCommitted, uow.committed)
+			if tt.wantedErr != nil {
+				assert.ErrorIs(t, err, tt.wantedErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantedTotal, res.Total)
+			assert.Equal(t, tt.wantedUsers, len(res.Data))
+		})
+	}
+}
+
+func TestStream(t *testing.T) {
+	users := []entities.User{{ID: vo.NewID()}, {ID: vo.NewID()}}
+	pagination := vo.NewPagination(1, 50, 0)
+
+	// Users found
+	uc := NewUser(&fakeUserRepository{users: users}, nil, nil, fakePasswordHasher{}, vo.PasswordPolicy{}, vo.EmailPolicy{})
+	res, err := uc.Stream(StreamUsersRequest{Pagination: pagination})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), res.Total)
+
+	var streamed []entities.User
+	for user, err := range res.Users {
+		assert.Nil(t, err)
+		streamed = append(streamed, user)
+	}
+	assert.Equal(t, users, streamed)
+
+	// The reading stops with the iteration
+	for range res.Users {
+		break
+	}
+
+	// Error when counting users
+	uc = NewUser(&fakeUserRepository{users: users, errCount: errors.New("count error")}, nil, nil, fakePasswordHasher{}, vo.PasswordPolicy{}, vo.EmailPolicy{})
+	_, err = uc.Stream(StreamUsersRequest{Pagination: pagination})
+	assert.ErrorIs(t, err, domainerr.ErrDatabase)
+
+	// Error when getting users
+	uc = NewUser(&fakeUserRepository{users: users, errGet: errors.New("get error")}, nil, nil, fakePasswordHasher{}, vo.PasswordPolicy{}, vo.EmailPolicy{})
+	res, err = uc.Stream(StreamUsersRequest{Pagination: pagination})
+	assert.Nil(t, err)
+	for _, err := range res.Users {
+		assert.ErrorIs(t, err, domainerr.ErrDatabase)
+	}
+}
+
+func TestDeleteRecordsAuditLog(t *testing.T) {
+	email, _ := vo.NewEmail("john.doe@test.com")
+	user := entities.User{ID: vo.NewID(), Email: email, Lastname: "Doe", Firstname: "John"}
+	actorID := vo.NewID()
+	actor := entities.Actor{ID: actorID.String(), IP: "127.0.0.1", RequestID: "request-id"}
+
+	repository := &fakeUserRepository{users: []entities.User{user}}
+	uow := newFakeUnitOfWork(repository)
+	auditLogs := uow.auditLog
+	uc := NewUser(repository, uow, nil, fakePasswordHasher{}, vo.PasswordPolicy{}, vo.EmailPolicy{})
+
+	_, err := uc.Delete(DeleteRestoreUserRequest{ID: user.ID, Actor: actor})
+	assert.Nil(t, err)
+	assert.True(t, uow.committed)
+	assert.Equal(t, 1, len(auditLogs.auditLogs))
+
+	auditLog := auditLogs.auditLogs[0]
+	assert.Equal(t, entities.AuditActionUserDeleted, auditLog.Action)
+	assert.Equal(t, actor.ID, auditLog.ActorID)
+	assert.Equal(t, actor.IP, auditLog.IP)
+	assert.Equal(t, actor.RequestID, auditLog.RequestID)
+	assert.Equal(t, user.ID.String(), auditLog.TargetID)
+	assert.Contains(t, string(auditLog.Before), `"deleted_at":null`)
+	assert.NotContains(t, string(auditLog.After), `"deleted_at":null`)
+
+	// An event is raised in the same unit of work
+	assert.Equal(t, 1, len(uow.outbox.events))
+	assert.Equal(t, "user.deleted", uow.outbox.events[0].Name)
+	assert.Equal(t, user.ID.String(), uow.outbox.events[0].AggregateID)
+	assert.Equal(t, actor.RequestID, uow.outbox.events[0].RequestID)
+
+	// The user is already deleted
+	_, err = uc.Delete(DeleteRestoreUserRequest{ID: user.ID, Actor: actor})
+	assert.ErrorIs(t, err, domainerr.ErrNotFound)
+	assert.False(t, uow.committed)
+	assert.Equal(t, 1, len(auditLogs.auditLogs))
+}
+
+func TestDeleteWithVersions(t *testing.T) {
+	tests := []struct {
+		name      string
+		versions  []int
+		wantedErr error
+	}{
+		{name: "No expected version", versions: nil},
+		{name: "Matching version", versions: []int{1, 2}},
+		{name: "Version mismatch", versions: []int{1}, wantedErr: ErrUserVersionMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := entities.User{ID: vo.NewID(), Version: 2}
+			repository := &fakeUserRepository{users: []entities.User{user}}
+			uow := newFakeUnitOfWork(repository)
+			uc := NewUser(repository, uow, nil, fakePasswordHasher{}, vo.PasswordPolicy{}, vo.EmailPolicy{})
+
+			_, err := uc.Delete(DeleteRestoreUserRequest{ID: user.ID, Versions: tt.versions})
+
+			if tt.wantedErr != nil {
+				assert.ErrorIs(t, err, tt.wantedErr)
+				assert.Equal(t, domainerr.KindPreconditionFailed, domainerr.KindOf(err))
+				assert.False(t, uow.committed)
+				assert.Equal(t, 2, repository.users[0].Version)
+				return
+			}
+			assert.Nil(t, err)
+			assert.True(t, uow.committed)
+			assert.Equal(t, 3, repository.users[0].Version)
+		})
+	}
+}
+
+func TestCreateEmailTaken(t *testing.T) {
+	email, _ := vo.NewEmail("john.doe@test.com")
+	password, _ := vo.NewPassword("00000000")
+	repository := &fakeUserRepository{errCreate: fmt.Errorf("[user_fake:Create %w]", domainerr.ErrConflict)}
+	uow := newFakeUnitOfWork(repository)
+	uc := NewUser(repository, uow, nil, fakePasswordHasher{}, vo.PasswordPolicy{}, vo.EmailPolicy{})
+
+	_, err := uc.Create(CreateUserRequest{Email: email, Password: password})
+
+	assert.ErrorIs(t, err, ErrEmailTaken)
+	assert.False(t, uow.committed)
+	assert.Equal(t, domainerr.KindConflict, domainerr.KindOf(err))
+}
+
+func TestCreateWeakPassword(t *testing.T) {
+	email, _ := vo.NewEmail("john.doe@test.com")
+	password, _ := vo.NewPassword("password")
+	repository := &fakeUserRepository{}
+	uow := newFakeUnitOfWork(repository)
+	policy := vo.PasswordPolicy{MinLength: 8, RequireDigit: true}.WithDenylist([]string{"password"})
+	uc := NewUser(repository, uow, nil, fakePasswordHasher{}, policy, vo.EmailPolicy{})
+
+	_, err := uc.Create(CreateUserRequest{Email: email, Password: password})
+
+	assert.ErrorIs(t, err, ErrWeakPassword)
+	assert.Equal(t, domainerr.KindValidation, domainerr.KindOf(err))
+	assert.Equal(t, 0, len(repository.users))
+
+	// The messages are in the locale of the request
+	_, err = uc.Create(CreateUserRequest{Email: email, Password: password, Locale: validation.LocaleFR})
+	var domainErr *domainerr.Error
+	assert.True(t, errors.As(err, &domainErr))
+	assert.Equal(t, "le mot de passe doit contenir un chiffre", domainErr.Context["errors"].(validation.ValidatorErrors)[0].Message)
+
+	// The hash of a valid password is stored
+	password, _ = vo.NewPassword("correct horse 42")
+	res, err := uc.Create(CreateUserRequest{Email: email, Password: password})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "v2:correct horse 42", res.Password.Value())
+}
+
+func TestCreateEmailNotAllowed(t *testing.T) {
+	email, _ := vo.NewEmail("john.doe@mailinator.com")
+	password, _ := vo.NewPassword("00000000")
+	repository := &fakeUserRepository{}
+	uow := newFakeUnitOfWork(repository)
+	policy := vo.EmailPolicy{}.WithDisposableDomains([]string{"mailinator.com"})
+	uc := NewUser(repository, uow, nil, fakePasswordHasher{}, vo.PasswordPolicy{}, policy)
+
+	_, err := uc.Create(CreateUserRequest{Email: email, Password: password})
+
+	assert.ErrorIs(t, err, ErrEmailNotAllowed)
+	assert.Equal(t, domainerr.KindValidation, domainerr.KindOf(err))
+	assert.Equal(t, 0, len(repository.users))
+}
+
+func TestGetAccessTokenRehashesLegacyPassword(t *testing.T) {
+	email, _ := vo.NewEmail("john.doe@test.com")
+	hash, _ := vo.NewPassword("v1:00000000")
+	user := entities.User{ID: vo.NewID(), Email: email, Password: hash}
+
+	tests := []struct {
+		name       string
+		password   string
+		wantedErr  error
+		wantedHash string
+	}{
+		{name: "Invalid password", password: "11111111", wantedErr: ErrInvalidCredentials, wantedHash: "v1:00000000"},
+		{name: "Legacy hash rehashed", password: "00000000", wantedHash: "v2:00000000"},
+	}
+
+	repository := &fakeUserRepository{users: []entities.User{user}}
+	uc := NewUser(repository, newFakeUnitOfWork(repository), fakeTokenGenerator{}, fakePasswordHasher{}, vo.PasswordPolicy{}, vo.EmailPolicy{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			password, _ := vo.NewPassword(tt.password)
+
+			res, err := uc.GetAccessToken(GetAccessTokenRequest{Email: email, Password: password})
+
+			assert.Equal(t, tt.wantedHash, repository.users[0].Password.Value())
+			if tt.wantedErr != nil {
+				assert.ErrorIs(t, err, tt.wantedErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, "token-"+user.ID.String(), res.Token.Token)
+		})
+	}
+
+	// The new hash is verified at the next login
+	password, _ := vo.NewPassword("00000000")
+	_, err := uc.GetAccessToken(GetAccessTokenRequest{Email: email, Password: password})
+	assert.Nil(t, err)
+}
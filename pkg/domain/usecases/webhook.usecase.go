@@ -0,0 +1,541 @@
+package usecases
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go-clean-api/pkg/domain/entities"
+	domainerr "go-clean-api/pkg/domain/errors"
+	"go-clean-api/pkg/domain/events"
+	"go-clean-api/pkg/domain/repositories"
+	"go-clean-api/pkg/domain/services"
+	vo "go-clean-api/pkg/domain/value_objects"
+	"time"
+)
+
+const (
+	// WebhookDefaultMaxAttempts is the default number of attempts to deliver an event to a webhook
+	WebhookDefaultMaxAttempts = 3
+
+	// WebhookDefaultRetryDelay is the default delay before the second attempt, doubled for each new attempt
+	WebhookDefaultRetryDelay = time.Second
+
+	// WebhookMaxRetryDelay is the maximum delay between two attempts
+	WebhookMaxRetryDelay = 24 * time.Hour
+
+	// WebhookDefaultDisableAfterFailures is the default number of consecutive failed deliveries
+	// after which a webhook is disabled
+	WebhookDefaultDisableAfterFailures = 5
+
+	// webhookSecretPrefix is the prefix of the generated webhook secrets
+	webhookSecretPrefix = "whsec_"
+)
+
+var (
+	ErrInvalidWebhookEvent    = domainerr.NewValidation(CodeInvalidWebhookEvent, "invalid webhook event")
+	ErrWebhookSecretCreation  = domainerr.NewInternal("webhook_secret_creation_failed", "error when creating webhook secret")
+	ErrWebhookDeliveryFailure = domainerr.NewInternal("webhook_delivery_failed", "webhook delivery failure")
+)
+
+// WebhookConfig is the configuration of the webhook deliveries
+type WebhookConfig struct {
+	// Number of attempts to deliver an event to a webhook
+	MaxAttempts int
+
+	// Delay before the second attempt, doubled for each new attempt (at most WebhookMaxRetryDelay).
+	// The attempts are made when the outbox dispatches the event again, so the delays are also bounded by its backoff.
+	RetryDelay time.Duration
+
+	// Number of consecutive failed deliveries after which a webhook is disabled
+	DisableAfterFailures int
+}
+
+// Webhook is an interface for webhook use cases.
+type Webhook interface {
+	Create(CreateWebhookRequest) (CreateWebhookResponse, error)
+	GetByID(GetWebhookByIDRequest) (GetWebhookByIDResponse, error)
+	GetAll(GetAllWebhooksRequest) (GetAllWebhooksResponse, error)
+	Delete(DeleteWebhookRequest) (DeleteWebhookResponse, error)
+	Enable(EnableWebhookRequest) (EnableWebhookResponse, error)
+	GetDeliveries(GetWebhookDeliveriesRequest) (GetWebhookDeliveriesResponse, error)
+	Redeliver(RedeliverWebhookRequest) (RedeliverWebhookResponse, error)
+	Deliver(DeliverWebhookEventRequest) (DeliverWebhookEventResponse, error)
+}
+
+type webhookUseCase struct {
+	webhookRepository repositories.Webhook
+	sender            services.WebhookSender
+	config            WebhookConfig
+}
+
+// NewWebhook returns a new Webhook use case
+func NewWebhook(webhookRepository repositories.Webhook, sender services.WebhookSender, config WebhookConfig) Webhook {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = WebhookDefaultMaxAttempts
+	}
+	if config.RetryDelay <= 0 {
+		config.RetryDelay = WebhookDefaultRetryDelay
+	}
+	if config.DisableAfterFailures <= 0 {
+		config.DisableAfterFailures = WebhookDefaultDisableAfterFailures
+	}
+	return &webhookUseCase{webhookRepository, sender, config}
+}
+
+//
+// ======== Create ========
+//
+
+// CreateWebhookRequest is the data transfer object for the Create method request.
+// A secret is generated if it is empty.
+type CreateWebhookRequest struct {
+	URL    string
+	Secret string
+	Events []string
+}
+
+// CreateWebhookResponse is the data transfer object for the Create method response.
+type CreateWebhookResponse struct {
+	entities.Webhook
+}
+
+// Create registers a new webhook.
+func (uc webhookUseCase) Create(req CreateWebhookRequest) (res CreateWebhookResponse, err error) {
+	for _, event := range req.Events {
+		if !events.IsValidName(event) {
+			err = ErrInvalidWebhookEvent.Wrap("webhook_uc:Create", nil).With("event", event)
+			return
+		}
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		secret, err = newWebhookSecret()
+		if err != nil {
+			err = ErrWebhookSecretCreation.Wrap("webhook_uc:Create", err)
+			return
+		}
+	}
+
+	now := vo.NewTime(time.Now(), nil)
+	webhook, errRepo := uc.webhookRepository.Create(repositories.CreateWebhookRequest{
+		Webhook: entities.Webhook{
+			ID:        vo.NewID(),
+			URL:       req.URL,
+			Secret:    secret,
+			Events:    req.Events,
+			Enabled:   true,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+	})
+	if errRepo != nil {
+		err = domainerr.ErrDatabase.Wrap("webhook_uc:Create", errRepo)
+		return
+	}
+	res.Webhook = webhook.Webhook
+
+	return
+}
+
+//
+// ======== GetByID ========
+//
+
+// GetWebhookByIDRequest is the data transfer object for the GetByID method request.
+type GetWebhookByIDRequest struct {
+	ID entities.WebhookID
+}
+
+// GetWebhookByIDResponse is the data transfer object for the GetByID method response.
+type GetWebhookByIDResponse struct {
+	entities.Webhook
+}
+
+// GetByID returns a webhook by its ID.
+func (uc webhookUseCase) GetByID(req GetWebhookByIDRequest) (res GetWebhookByIDResponse, err error) {
+	webhook, errRepo := uc.webhookRepository.GetByID(repositories.GetWebhookByIDRequest{ID: req.ID})
+	if errRepo != nil {
+		err = wrapWebhookRepositoryError("GetByID", ErrWebhookNotFound.With("id", req.ID.String()), errRepo)
+		return
+	}
+	res.Webhook = webhook.Webhook
+
+	return
+}
+
+//
+// ======== GetAll ========
+//
+
+// GetAllWebhooksRequest is the data transfer object for the GetAll method request.
+type GetAllWebhooksRequest struct {
+	Pagination vo.Pagination
+}
+
+// GetAllWebhooksResponse is the data transfer object for the GetAll method response.
+type GetAllWebhooksResponse struct {
+	Data  []entities.Webhook
+	Total int64
+}
+
+// GetAll returns all webhooks (pagination).
+func (uc webhookUseCase) GetAll(req GetAllWebhooksRequest) (res GetAllWebhooksResponse, err error) {
+	total, errRepo := uc.webhookRepository.CountAll(repositories.CountAllWebhooksRequest{})
+	if errRepo != nil {
+		err = domainerr.ErrDatabase.Wrap("webhook_uc:GetAll", errRepo)
+		return
+	}
+
+	res.Data = []entities.Webhook{}
+	res.Total = total.Total
+	if total.Total == 0 {
+		return
+	}
+
+	webhooks, errRepo := uc.webhookRepository.GetAll(repositories.GetAllWebhooksRequest{Pagination: req.Pagination})
+	if errRepo != nil {
+		err = domainerr.ErrDatabase.Wrap("webhook_uc:GetAll", errRepo)
+		return
+	}
+	res.Data = webhooks.Webhooks
+
+	return
+}
+
+//
+// ======== Delete / Enable ========
+//
+
+// DeleteWebhookRequest is the data transfer object for the Delete method request.
+type DeleteWebhookRequest struct {
+	ID entities.WebhookID
+}
+
+// DeleteWebhookResponse is the data transfer object for the Delete method response.
+type DeleteWebhookResponse struct{}
+
+// Delete deletes a webhook and its deliveries.
+func (uc webhookUseCase) Delete(req DeleteWebhookRequest) (res DeleteWebhookResponse, err error) {
+	if _, errRepo := uc.webhookRepository.Delete(repositories.DeleteWebhookRequest{ID: req.ID}); errRepo != nil {
+		err = wrapWebhookRepositoryError("Delete", ErrWebhookNotFound.With("id", req.ID.String()), errRepo)
+	}
+
+	return
+}
+
+// EnableWebhookRequest is the data transfer object for the Enable method request.
+type EnableWebhookRequest struct {
+	ID entities.WebhookID
+}
+
+// EnableWebhookResponse is the data transfer object for the Enable method response.
+type EnableWebhookResponse struct {
+	entities.Webhook
+}
+
+// Enable enables again a webhook which has been disabled after too many failed deliveries.
+func (uc webhookUseCase) Enable(req EnableWebhookRequest) (res EnableWebhookResponse, err error) {
+	if _, errRepo := uc.webhookRepository.GetByID(repositories.GetWebhookByIDRequest{ID: req.ID}); errRepo != nil {
+		err = wrapWebhookRepositoryError("Enable", ErrWebhookNotFound.With("id", req.ID.String()), errRepo)
+		return
+	}
+
+	_, errRepo := uc.webhookRepository.Enable(repositories.EnableWebhookRequest{
+		ID:        req.ID,
+		UpdatedAt: vo.NewTime(time.Now(), nil),
+	})
+	if errRepo != nil {
+		err = domainerr.ErrDatabase.Wrap("webhook_uc:Enable", errRepo)
+		return
+	}
+
+	webhook, errRepo := uc.webhookRepository.GetByID(repositories.GetWebhookByIDRequest{ID: req.ID})
+	if errRepo != nil {
+		err = wrapWebhookRepositoryError("Enable", ErrWebhookNotFound.With("id", req.ID.String()), errRepo)
+		return
+	}
+	res.Webhook = webhook.Webhook
+
+	return
+}
+
+//
+// ======== GetDeliveries ========
+//
+
+// GetWebhookDeliveriesRequest is the data transfer object for the GetDeliveries method request.
+type GetWebhookDeliveriesRequest struct {
+	WebhookID  entities.WebhookID
+	Pagination vo.Pagination
+}
+
+// GetWebhookDeliveriesResponse is the data transfer object for the GetDeliveries method response.
+type GetWebhookDeliveriesResponse struct {
+	Data  []entities.WebhookDelivery
+	Total int64
+}
+
+// GetDeliveries returns the delivery log of a webhook, most recent first (pagination).
+func (uc webhookUseCase) GetDeliveries(req GetWebhookDeliveriesRequest) (res GetWebhookDeliveriesResponse, err error) {
+	if _, errRepo := uc.webhookRepository.GetByID(repositories.GetWebhookByIDRequest{ID: req.WebhookID}); errRepo != nil {
+		err = wrapWebhookRepositoryError("GetDeliveries", ErrWebhookNotFound.With("id", req.WebhookID.String()), errRepo)
+		return
+	}
+
+	total, errRepo := uc.webhookRepository.CountDeliveries(repositories.CountWebhookDeliveriesRequest{WebhookID: req.WebhookID})
+	if errRepo != nil {
+		err = domainerr.ErrDatabase.Wrap("webhook_uc:GetDeliveries", errRepo)
+		return
+	}
+
+	res.Data = []entities.WebhookDelivery{}
+	res.Total = total.Total
+	if total.Total == 0 {
+		return
+	}
+
+	deliveries, errRepo := uc.webhookRepository.GetDeliveries(repositories.GetWebhookDeliveriesRequest{
+		WebhookID:  req.WebhookID,
+		Pagination: req.Pagination,
+	})
+	if errRepo != nil {
+		err = domainerr.ErrDatabase.Wrap("webhook_uc:GetDeliveries", errRepo)
+		return
+	}
+	res.Data = deliveries.Deliveries
+
+	return
+}
+
+//
+// ======== Redeliver ========
+//
+
+// RedeliverWebhookRequest is the data transfer object for the Redeliver method request.
+type RedeliverWebhookRequest struct {
+	WebhookID  entities.WebhookID
+	DeliveryID entities.WebhookDeliveryID
+	RequestID  string // Propagated to the webhook
+}
+
+// RedeliverWebhookResponse is the data transfer object for the Redeliver method response.
+type RedeliverWebhookResponse struct {
+	entities.WebhookDelivery
+}
+
+// Redeliver sends again the payload of a previous delivery, once and without retry.
+// It ends the automatic retries of the event for this webhook.
+// The new delivery is returned even if it failed.
+func (uc webhookUseCase) Redeliver(req RedeliverWebhookRequest) (res RedeliverWebhookResponse, err error) {
+	webhook, errRepo := uc.webhookRepository.GetByID(repositories.GetWebhookByIDRequest{ID: req.WebhookID})
+	if errRepo != nil {
+		err = wrapWebhookRepositoryError("Redeliver", ErrWebhookNotFound.With("id", req.WebhookID.String()), errRepo)
+		return
+	}
+
+	previous, errRepo := uc.webhookRepository.GetDeliveryByID(repositories.GetWebhookDeliveryByIDRequest{
+		WebhookID: req.WebhookID,
+		ID:        req.DeliveryID,
+	})
+	if errRepo != nil {
+		err = wrapWebhookRepositoryError("Redeliver", ErrWebhookDeliveryNotFound.With("id", req.DeliveryID.String()), errRepo)
+		return
+	}
+
+	delivery, errSend := uc.send(webhook.Webhook, previous.EventID, previous.EventName, req.RequestID, previous.Payload, 1, nil)
+	if errSend != nil {
+		err = domainerr.ErrDatabase.Wrap("webhook_uc:Redeliver", errSend)
+		return
+	}
+
+	if err = uc.recordResult(webhook.Webhook, delivery.Success); err != nil {
+		err = domainerr.ErrDatabase.Wrap("webhook_uc:Redeliver", err)
+		return
+	}
+	res.WebhookDelivery = delivery
+
+	return
+}
+
+//
+// ======== Deliver ========
+//
+
+// DeliverWebhookEventRequest is the data transfer object for the Deliver method request.
+type DeliverWebhookEventRequest struct {
+	Event entities.OutboxEvent
+	Body  []byte // JSON body sent to the webhooks
+}
+
+// DeliverWebhookEventResponse is the data transfer object for the Deliver method response.
+type DeliverWebhookEventResponse struct {
+	Delivered int
+	Pending   int // Failed deliveries which will be retried
+	Failed    int // Failed deliveries which will not be retried
+}
+
+// Deliver sends an event to all enabled webhooks which subscribed to it.
+//
+// Each call makes at most one attempt per webhook and every attempt is recorded in the delivery log.
+// A failed delivery stores the date of its next attempt (exponential backoff) until the max number
+// of attempts is reached, it is retried when Deliver is called again for the event after this date
+// (the webhooks which already received the event are skipped). A webhook is disabled after too many
+// consecutive failed deliveries.
+//
+// An error is returned while a delivery is pending, so that the outbox keeps the event and calls Deliver again later.
+// A delivery which failed for good is recorded and no longer retried, it can be sent again with Redeliver.
+func (uc webhookUseCase) Deliver(req DeliverWebhookEventRequest) (res DeliverWebhookEventResponse, err error) {
+	webhooks, errRepo := uc.webhookRepository.GetEnabledByEvent(repositories.GetEnabledWebhooksByEventRequest{
+		EventName: req.Event.Name,
+	})
+	if errRepo != nil {
+		err = domainerr.ErrDatabase.Wrap("webhook_uc:Deliver", errRepo)
+		return
+	}
+
+	now := time.Now()
+	for _, webhook := range webhooks.Webhooks {
+		attempt := 1
+		last, errRepo := uc.webhookRepository.GetLastDelivery(repositories.GetLastWebhookDeliveryRequest{
+			WebhookID: webhook.ID,
+			EventID:   req.Event.ID.String(),
+		})
+		switch {
+		case errors.Is(errRepo, domainerr.ErrNotFound):
+			// First attempt
+		case errRepo != nil:
+			err = domainerr.ErrDatabase.Wrap("webhook_uc:Deliver", errRepo)
+			return
+		case last.Success:
+			res.Delivered++
+			continue
+		case last.NextAttemptAt == nil:
+			res.Failed++
+			continue
+		case last.NextAttemptAt.Value().After(now):
+			res.Pending++
+			continue
+		default:
+			attempt = last.Attempt + 1
+		}
+
+		var nextAttemptAt *vo.Time
+		if attempt < uc.config.MaxAttempts {
+			t := vo.NewTime(now.Add(uc.retryDelay(attempt)), nil)
+			nextAttemptAt = &t
+		}
+
+		delivery, errSend := uc.send(webhook, req.Event.ID.String(), req.Event.Name, req.Event.RequestID, req.Body, attempt, nextAttemptAt)
+		if errSend != nil {
+			err = domainerr.ErrDatabase.Wrap("webhook_uc:Deliver", errSend)
+			return
+		}
+
+		// A delivery counts in the consecutive failures of the webhook once it is not retried anymore
+		if delivery.Success || delivery.NextAttemptAt == nil {
+			if err = uc.recordResult(webhook, delivery.Success); err != nil {
+				err = domainerr.ErrDatabase.Wrap("webhook_uc:Deliver", err)
+				return
+			}
+		}
+
+		switch {
+		case delivery.Success:
+			res.Delivered++
+		case delivery.NextAttemptAt != nil:
+			res.Pending++
+		default:
+			res.Failed++
+		}
+	}
+
+	if res.Pending > 0 {
+		err = ErrWebhookDeliveryFailure.Wrap("webhook_uc:Deliver", nil).
+			With("event_id", req.Event.ID.String()).
+			With("pending", res.Pending).
+			With("failed", res.Failed)
+	}
+
+	return
+}
+
+// send makes one delivery attempt and records it in the delivery log.
+// The date of the next attempt is only recorded if the attempt fails.
+// The returned error is only a repository error, a failed request gives an unsuccessful delivery.
+func (uc webhookUseCase) send(webhook entities.Webhook, eventID, eventName, requestID string, body []byte, attempt int, nextAttemptAt *vo.Time) (entities.WebhookDelivery, error) {
+	delivery := entities.WebhookDelivery{
+		ID:        vo.NewID(),
+		WebhookID: webhook.ID,
+		EventID:   eventID,
+		EventName: eventName,
+		Payload:   body,
+		Attempt:   attempt,
+		CreatedAt: vo.NewTime(time.Now(), nil),
+	}
+
+	resSend, errSend := uc.sender.Send(services.WebhookRequest{
+		URL:        webhook.URL,
+		Secret:     webhook.Secret,
+		DeliveryID: delivery.ID.String(),
+		EventID:    eventID,
+		EventName:  eventName,
+		RequestID:  requestID,
+		Body:       body,
+	})
+	delivery.StatusCode = resSend.StatusCode
+	delivery.Duration = resSend.Duration
+	delivery.Success = errSend == nil
+	if errSend != nil {
+		delivery.Error = fmt.Errorf("%w: %w", ErrWebhookDeliveryFailure, errSend).Error()
+		delivery.NextAttemptAt = nextAttemptAt
+	}
+
+	if _, err := uc.webhookRepository.CreateDelivery(repositories.CreateWebhookDeliveryRequest{WebhookDelivery: delivery}); err != nil {
+		return delivery, err
+	}
+
+	return delivery, nil
+}
+
+// recordResult updates the number of consecutive failed deliveries of a webhook.
+func (uc webhookUseCase) recordResult(webhook entities.Webhook, success bool) error {
+	if success {
+		_, err := uc.webhookRepository.RecordSuccess(repositories.RecordWebhookSuccessRequest{ID: webhook.ID})
+		return err
+	}
+
+	_, err := uc.webhookRepository.RecordFailure(repositories.RecordWebhookFailureRequest{
+		ID:           webhook.ID,
+		DisableAfter: uc.config.DisableAfterFailures,
+		Now:          vo.NewTime(time.Now(), nil),
+	})
+	return err
+}
+
+// retryDelay returns the delay before the next attempt after n failed attempts, at most WebhookMaxRetryDelay.
+func (uc webhookUseCase) retryDelay(attempts int) time.Duration {
+	delay := uc.config.RetryDelay
+	for i := 1; i < attempts && delay < WebhookMaxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, WebhookMaxRetryDelay)
+}
+
+// newWebhookSecret generates a random secret used to sign the webhook requests.
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return webhookSecretPrefix + hex.EncodeToString(b), nil
+}
+
+// wrapWebhookRepositoryError wraps a repository error with the not found error or ErrDatabase.
+func wrapWebhookRepositoryError(method string, notFound *domainerr.Error, err error) error {
+	if errors.Is(err, domainerr.ErrNotFound) {
+		return notFound.Wrap("webhook_uc:"+method, err)
+	}
+	return domainerr.ErrDatabase.Wrap("webhook_uc:"+method, err)
+}
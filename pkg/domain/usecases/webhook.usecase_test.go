@@ -0,0 +1,197 @@
+package usecases
+
+import (
+	"errors"
+	"go-clean-api/pkg/domain/entities"
+	domainerr "go-clean-api/pkg/domain/errors"
+	"go-clean-api/pkg/domain/services"
+	vo "go-clean-api/pkg/domain/value_objects"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeWebhookSender records the requests and fails for the URLs in failures
+// (the number of failures before a success, -1 to always fail)
+type fakeWebhookSender struct {
+	requests []services.WebhookRequest
+	failures map[string]int
+}
+
+func (s *fakeWebhookSender) Send(req services.WebhookRequest) (services.WebhookResponse, error) {
+	s.requests = append(s.requests, req)
+	if n, ok := s.failures[req.URL]; ok && n != 0 {
+		if n > 0 {
+			s.failures[req.URL] = n - 1
+		}
+		return services.WebhookResponse{StatusCode: 500}, errors.New("internal server error")
+	}
+	return services.WebhookResponse{StatusCode: 204}, nil
+}
+
+func newTestWebhook(url string, events ...string) entities.Webhook {
+	return entities.Webhook{ID: vo.NewID(), URL: url, Secret: "secret", Events: events, Enabled: true}
+}
+
+func newTestWebhookUseCase(repository *fakeWebhookRepository, sender *fakeWebhookSender) Webhook {
+	return NewWebhook(repository, sender, WebhookConfig{
+		MaxAttempts:          3,
+		RetryDelay:           time.Millisecond,
+		DisableAfterFailures: 2,
+	})
+}
+
+func TestWebhookCreate(t *testing.T) {
+	repository := &fakeWebhookRepository{}
+	uc := newTestWebhookUseCase(repository, &fakeWebhookSender{})
+
+	res, err := uc.Create(CreateWebhookRequest{URL: "https://example.com", Events: []string{"user.created"}})
+	assert.Nil(t, err)
+	assert.True(t, res.Enabled)
+	assert.True(t, strings.HasPrefix(res.Secret, webhookSecretPrefix))
+	assert.Equal(t, 1, len(repository.webhooks))
+
+	_, err = uc.Create(CreateWebhookRequest{URL: "https://example.com", Events: []string{"user.unknown"}})
+	assert.ErrorIs(t, err, ErrInvalidWebhookEvent)
+	assert.Equal(t, 1, len(repository.webhooks))
+}
+
+// dueDeliveries makes the scheduled attempts of the deliveries due
+func dueDeliveries(repository *fakeWebhookRepository) {
+	past := vo.NewTime(time.Now().Add(-time.Second), nil)
+	for i, delivery := range repository.deliveries {
+		if delivery.NextAttemptAt != nil {
+			repository.deliveries[i].NextAttemptAt = &past
+		}
+	}
+}
+
+func TestWebhookDeliver(t *testing.T) {
+	subscribed := newTestWebhook("https://ok.test", "user.created")
+	flaky := newTestWebhook("https://flaky.test", "user.created", "user.deleted")
+	down := newTestWebhook("https://down.test", "user.created")
+	other := newTestWebhook("https://other.test", "user.deleted")
+
+	repository := &fakeWebhookRepository{webhooks: []entities.Webhook{subscribed, flaky, down, other}}
+	sender := &fakeWebhookSender{failures: map[string]int{"https://flaky.test": 2, "https://down.test": -1}}
+	uc := newTestWebhookUseCase(repository, sender)
+
+	// First attempt: one request per subscribed webhook, none for other
+	event := entities.OutboxEvent{ID: vo.NewID(), Name: "user.created", RequestID: "request-id"}
+	res, err := uc.Deliver(DeliverWebhookEventRequest{Event: event, Body: []byte(`{}`)})
+	assert.ErrorIs(t, err, ErrWebhookDeliveryFailure)
+	assert.Equal(t, DeliverWebhookEventResponse{Delivered: 1, Pending: 2}, res)
+	assert.Equal(t, 3, len(sender.requests))
+	assert.Equal(t, 3, len(repository.deliveries))
+	for _, req := range sender.requests {
+		assert.Equal(t, event.ID.String(), req.EventID)
+		assert.Equal(t, "request-id", req.RequestID)
+		assert.Equal(t, "secret", req.Secret)
+		assert.NotEqual(t, "https://other.test", req.URL)
+	}
+
+	failed := repository.deliveries[2]
+	assert.False(t, failed.Success)
+	assert.Equal(t, 1, failed.Attempt)
+	assert.NotNil(t, failed.NextAttemptAt)
+	assert.True(t, failed.NextAttemptAt.Value().After(time.Now()))
+	assert.Nil(t, repository.deliveries[0].NextAttemptAt)
+
+	// The retries are not due yet
+	res, err = uc.Deliver(DeliverWebhookEventRequest{Event: event, Body: []byte(`{}`)})
+	assert.ErrorIs(t, err, ErrWebhookDeliveryFailure)
+	assert.Equal(t, DeliverWebhookEventResponse{Delivered: 1, Pending: 2}, res)
+	assert.Equal(t, 3, len(sender.requests))
+
+	// Second attempt: the delivered webhook is skipped
+	dueDeliveries(repository)
+	res, err = uc.Deliver(DeliverWebhookEventRequest{Event: event, Body: []byte(`{}`)})
+	assert.ErrorIs(t, err, ErrWebhookDeliveryFailure)
+	assert.Equal(t, DeliverWebhookEventResponse{Delivered: 1, Pending: 2}, res)
+	assert.Equal(t, 5, len(sender.requests))
+	assert.Equal(t, 2, repository.deliveries[4].Attempt)
+
+	// Last attempt: flaky is delivered, down failed for good
+	dueDeliveries(repository)
+	res, err = uc.Deliver(DeliverWebhookEventRequest{Event: event, Body: []byte(`{}`)})
+	assert.Nil(t, err)
+	assert.Equal(t, DeliverWebhookEventResponse{Delivered: 2, Failed: 1}, res)
+	assert.Equal(t, 7, len(sender.requests))
+	assert.Nil(t, repository.deliveries[6].NextAttemptAt)
+
+	assert.Equal(t, 0, repository.webhooks[1].ConsecutiveFailures)
+	assert.Equal(t, 1, repository.webhooks[2].ConsecutiveFailures)
+	assert.True(t, repository.webhooks[2].Enabled)
+
+	// No more attempts
+	res, err = uc.Deliver(DeliverWebhookEventRequest{Event: event, Body: []byte(`{}`)})
+	assert.Nil(t, err)
+	assert.Equal(t, DeliverWebhookEventResponse{Delivered: 2, Failed: 1}, res)
+	assert.Equal(t, 7, len(sender.requests))
+
+	// The second failed delivery disables the webhook
+	next := entities.OutboxEvent{ID: vo.NewID(), Name: "user.created"}
+	for range 2 {
+		dueDeliveries(repository)
+		_, err = uc.Deliver(DeliverWebhookEventRequest{Event: next, Body: []byte(`{}`)})
+		assert.ErrorIs(t, err, ErrWebhookDeliveryFailure)
+	}
+	dueDeliveries(repository)
+	res, err = uc.Deliver(DeliverWebhookEventRequest{Event: next, Body: []byte(`{}`)})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, res.Failed)
+	assert.False(t, repository.webhooks[2].Enabled)
+	assert.NotNil(t, repository.webhooks[2].DisabledAt)
+
+	// A disabled webhook does not receive events anymore
+	sender.requests = nil
+	_, err = uc.Deliver(DeliverWebhookEventRequest{Event: entities.OutboxEvent{ID: vo.NewID(), Name: "user.created"}, Body: []byte(`{}`)})
+	assert.Nil(t, err)
+	for _, req := range sender.requests {
+		assert.NotEqual(t, "https://down.test", req.URL)
+	}
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	uc := webhookUseCase{config: WebhookConfig{RetryDelay: time.Second}}
+
+	assert.Equal(t, time.Second, uc.retryDelay(1))
+	assert.Equal(t, 4*time.Second, uc.retryDelay(3))
+	assert.Equal(t, WebhookMaxRetryDelay, uc.retryDelay(100))
+}
+
+func TestWebhookRedeliver(t *testing.T) {
+	webhook := newTestWebhook("https://down.test", "user.created")
+	repository := &fakeWebhookRepository{webhooks: []entities.Webhook{webhook}}
+	sender := &fakeWebhookSender{failures: map[string]int{"https://down.test": 1}}
+	uc := newTestWebhookUseCase(repository, sender)
+
+	event := entities.OutboxEvent{ID: vo.NewID(), Name: "user.created"}
+	res, err := uc.Deliver(DeliverWebhookEventRequest{Event: event, Body: []byte(`{"id":"1"}`)})
+	assert.ErrorIs(t, err, ErrWebhookDeliveryFailure)
+	assert.Equal(t, 1, res.Pending)
+
+	failed := repository.deliveries[len(repository.deliveries)-1]
+	assert.False(t, failed.Success)
+	assert.Equal(t, 500, failed.StatusCode)
+
+	resRedeliver, err := uc.Redeliver(RedeliverWebhookRequest{WebhookID: webhook.ID, DeliveryID: failed.ID, RequestID: "redeliver-request-id"})
+	assert.Nil(t, err)
+	assert.True(t, resRedeliver.Success)
+	assert.Equal(t, "redeliver-request-id", sender.requests[len(sender.requests)-1].RequestID)
+	assert.Equal(t, event.ID.String(), resRedeliver.EventID)
+	assert.Equal(t, `{"id":"1"}`, string(resRedeliver.Payload))
+	assert.Equal(t, 0, repository.webhooks[0].ConsecutiveFailures)
+
+	// The redelivery ends the automatic retries
+	dueDeliveries(repository)
+	res, err = uc.Deliver(DeliverWebhookEventRequest{Event: event, Body: []byte(`{"id":"1"}`)})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, res.Delivered)
+	assert.Equal(t, 2, len(sender.requests))
+
+	_, err = uc.Redeliver(RedeliverWebhookRequest{WebhookID: webhook.ID, DeliveryID: vo.NewID()})
+	assert.ErrorIs(t, err, domainerr.ErrNotFound)
+}
@@ -2,15 +2,110 @@ package validation
 
 import (
 	"encoding/json"
+	"reflect"
+	"strings"
 
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/fr"
+	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	fr_translations "github.com/go-playground/validator/v10/translations/fr"
 )
 
+// Supported locales of the error messages
+const (
+	LocaleEN = "en"
+	LocaleFR = "fr"
+
+	// DefaultLocale is the locale of the messages when no supported locale is requested
+	DefaultLocale = LocaleEN
+)
+
+// invalidKey is the key of the message of the tags without translation
+const invalidKey = "invalid"
+
+// Locales lists the supported locales by order of preference
+var Locales = []string{LocaleEN, LocaleFR}
+
+// messages are the translations of the error messages which are not reported by the validator (see Translate)
+var messages = map[string]map[string]string{
+	LocaleEN: {
+		"password_min":       "password must be at least {0} characters in length",
+		"password_max":       "password must be a maximum of {0} bytes in length",
+		"password_uppercase": "password must contain an uppercase letter",
+		"password_lowercase": "password must contain a lowercase letter",
+		"password_digit":     "password must contain a digit",
+		"password_symbol":    "password must contain a symbol",
+		"password_denylist":  "password is too common",
+	},
+	LocaleFR: {
+		"password_min":       "le mot de passe doit contenir au moins {0} caractères",
+		"password_max":       "le mot de passe doit contenir au maximum {0} octets",
+		"password_uppercase": "le mot de passe doit contenir une lettre majuscule",
+		"password_lowercase": "le mot de passe doit contenir une lettre minuscule",
+		"password_digit":     "le mot de passe doit contenir un chiffre",
+		"password_symbol":    "le mot de passe doit contenir un symbole",
+		"password_denylist":  "le mot de passe est trop courant",
+	},
+}
+
+var (
+	// translators translates the error messages in the supported locales
+	translators = ut.New(en.New(), en.New(), fr.New())
+
+	// validate is shared by all validations as it caches the structs metadata
+	validate = newValidator()
+)
+
+// newValidator returns a validator naming the struct fields by their JSON name (their Go name otherwise)
+// with the translations of the error messages
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			return f.Name
+		}
+		return name
+	})
+
+	enTrans, _ := translators.GetTranslator(LocaleEN)
+	if err := en_translations.RegisterDefaultTranslations(v, enTrans); err != nil {
+		panic(err)
+	}
+	if err := enTrans.Add(invalidKey, "{0} is invalid", false); err != nil {
+		panic(err)
+	}
+	addMessages(enTrans, messages[LocaleEN])
+
+	frTrans, _ := translators.GetTranslator(LocaleFR)
+	if err := fr_translations.RegisterDefaultTranslations(v, frTrans); err != nil {
+		panic(err)
+	}
+	if err := frTrans.Add(invalidKey, "{0} n'est pas valide", false); err != nil {
+		panic(err)
+	}
+	addMessages(frTrans, messages[LocaleFR])
+
+	return v
+}
+
+// addMessages adds the messages to a translator
+func addMessages(trans ut.Translator, messages map[string]string) {
+	for key, text := range messages {
+		if err := trans.Add(key, text, false); err != nil {
+			panic(err)
+		}
+	}
+}
+
 // ValidatorError represents error validation struct.
 type ValidatorError struct {
-	Field string `json:"field" xml:"field"`
-	Tag   string `json:"tag" xml:"tag"`
-	Value string `json:"value" xml:"value"`
+	Field   string `json:"field" xml:"field"`
+	Tag     string `json:"tag" xml:"tag"`
+	Value   string `json:"value" xml:"value"`
+	Message string `json:"message,omitempty" xml:"message,omitempty"`
 }
 
 // ValidatorErrors is a slice of ValidatorError.
@@ -25,16 +120,22 @@ func (ve *ValidatorErrors) Error() string {
 }
 
 // ValidateStruct checks if a struct is valid and returns an array of errors
-// if it is not valid.
-func ValidateStruct(s any) (errors ValidatorErrors) {
-	validate := validator.New()
+// if it is not valid. Fields are named by their JSON name and messages are in English.
+func ValidateStruct(s any) ValidatorErrors {
+	return ValidateStructLocale(s, DefaultLocale)
+}
+
+// ValidateStructLocale checks if a struct is valid and returns an array of errors
+// with messages in the locale (in the default locale if it is not supported).
+func ValidateStructLocale(s any, locale string) (errors ValidatorErrors) {
 	errs := validate.Struct(s)
 	if errs != nil {
 		for _, err := range errs.(validator.ValidationErrors) {
 			errors = append(errors, ValidatorError{
-				Field: err.Field(),
-				Tag:   err.Tag(),
-				Value: err.Param(),
+				Field:   fieldPath(err),
+				Tag:     err.Tag(),
+				Value:   err.Param(),
+				Message: message(err, locale),
 			})
 		}
 	}
@@ -42,18 +143,56 @@ func ValidateStruct(s any) (errors ValidatorErrors) {
 }
 
 // ValidateVar checks if a variable is valid and returns an array of errors
-// if it is not valid.
+// if it is not valid. Messages are in English.
 func ValidateVar(v any, field, tag string) (errors ValidatorErrors) {
-	validate := validator.New()
-	errs := validate.Var(v, tag)
+	errs := validate.VarWithKey(field, v, tag)
 	if errs != nil {
 		for _, err := range errs.(validator.ValidationErrors) {
 			errors = append(errors, ValidatorError{
-				Field: field,
-				Tag:   err.Tag(),
-				Value: err.Param(),
+				Field:   field,
+				Tag:     err.Tag(),
+				Value:   err.Param(),
+				Message: message(err, DefaultLocale),
 			})
 		}
 	}
 	return
 }
+
+// message returns the error message of a field in a locale.
+// A tag without translation gets a generic message.
+func message(err validator.FieldError, locale string) string {
+	trans, found := translators.GetTranslator(locale)
+	if !found {
+		trans, _ = translators.GetTranslator(DefaultLocale)
+	}
+	if msg := err.Translate(trans); msg != err.Error() {
+		return msg
+	}
+
+	msg, _ := trans.T(invalidKey, err.Field())
+	return msg
+}
+
+// Translate returns the message of a key in a locale (in the default locale if it is not supported),
+// with the params replacing the {0}, {1}... placeholders.
+func Translate(locale, key string, params ...string) string {
+	trans, found := translators.GetTranslator(locale)
+	if !found {
+		trans, _ = translators.GetTranslator(DefaultLocale)
+	}
+	msg, err := trans.T(key, params...)
+	if err != nil {
+		return key
+	}
+	return msg
+}
+
+// fieldPath returns the path of an invalid field without the struct name (e.g. "events[0]")
+func fieldPath(err validator.FieldError) string {
+	_, path, ok := strings.Cut(err.Namespace(), ".")
+	if !ok {
+		return err.Field()
+	}
+	return path
+}
@@ -0,0 +1,78 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateStructLocale(t *testing.T) {
+	type request struct {
+		Name   string   `json:"name" validate:"required"`
+		URL    string   `json:"url,omitempty" validate:"omitempty,http_url"`
+		Events []string `json:"events" validate:"required,dive,required"`
+		Secret string   `json:"-" validate:"max=3"`
+		Other  int      `validate:"min=1"`
+	}
+
+	invalid := request{URL: "example", Events: []string{""}, Secret: "secret"}
+
+	tests := []struct {
+		name   string
+		value  request
+		locale string
+		wanted ValidatorErrors
+	}{
+		{
+			name:   "Valid struct",
+			value:  request{Name: "hook", URL: "https://example.com", Events: []string{"user.created"}, Other: 1},
+			locale: LocaleEN,
+			wanted: nil,
+		},
+		{
+			name:   "English",
+			value:  invalid,
+			locale: LocaleEN,
+			wanted: ValidatorErrors{
+				{Field: "name", Tag: "required", Value: "", Message: "name is a required field"},
+				{Field: "url", Tag: "http_url", Value: "", Message: "url is invalid"},
+				{Field: "events[0]", Tag: "required", Value: "", Message: "events[0] is a required field"},
+				{Field: "Secret", Tag: "max", Value: "3", Message: "Secret must be a maximum of 3 characters in length"},
+				{Field: "Other", Tag: "min", Value: "1", Message: "Other must be 1 or greater"},
+			},
+		},
+		{
+			name:   "French",
+			value:  invalid,
+			locale: LocaleFR,
+			wanted: ValidatorErrors{
+				{Field: "name", Tag: "required", Value: "", Message: "name est un champ obligatoire"},
+				{Field: "url", Tag: "http_url", Value: "", Message: "url n'est pas valide"},
+				{Field: "events[0]", Tag: "required", Value: "", Message: "events[0] est un champ obligatoire"},
+				{Field: "Secret", Tag: "max", Value: "3", Message: "Secret doit faire une taille maximum de 3 caractères"},
+				{Field: "Other", Tag: "min", Value: "1", Message: "Other doit être égal à 1 ou plus"},
+			},
+		},
+		{
+			name:   "Unsupported locale",
+			value:  request{Events: []string{"user.created"}, Other: 1},
+			locale: "de",
+			wanted: ValidatorErrors{
+				{Field: "name", Tag: "required", Value: "", Message: "name is a required field"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wanted, ValidateStructLocale(tt.value, tt.locale))
+		})
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	assert.Equal(t, "password must be at least 12 characters in length", Translate(LocaleEN, "password_min", "12"))
+	assert.Equal(t, "le mot de passe doit contenir au moins 12 caractères", Translate(LocaleFR, "password_min", "12"))
+	assert.Equal(t, "password is too common", Translate("de", "password_denylist"))
+	assert.Equal(t, "unknown_key", Translate(LocaleEN, "unknown_key"))
+}
@@ -2,9 +2,13 @@ package values_objects
 
 import (
 	"go-clean-api/pkg/domain/validation"
+	"strings"
+
+	"golang.org/x/net/idna"
 )
 
-// Email represents an email value object
+// Email represents an email value object.
+// The email is canonical: trimmed, in lower case and with an ASCII (punycode) domain.
 type Email struct {
 	value string `validate:"required,email"`
 }
@@ -18,9 +22,25 @@ func (e *Email) Value() string {
 	return e.value
 }
 
-// NewEmail creates a new email
+// Domain returns the domain of the email (in punycode for the internationalized domains)
+func (e *Email) Domain() string {
+	_, domain, _ := cutEmail(e.value)
+	return domain
+}
+
+// NewEmail creates a new email from its canonical form
 func NewEmail(value string) (Email, error) {
-	e := Email{value: value}
+	canonical, ok := CanonicalEmail(value)
+	if !ok {
+		return Email{}, &validation.ValidatorErrors{{
+			Field:   "email",
+			Tag:     "email",
+			Value:   "",
+			Message: "email must be a valid email address",
+		}}
+	}
+
+	e := Email{value: canonical}
 
 	err := e.Validate()
 	if err != nil {
@@ -30,7 +50,44 @@ func NewEmail(value string) (Email, error) {
 	return e, nil
 }
 
+// CanonicalEmail returns the canonical form of an email: without surrounding spaces, in lower case
+// and with the internationalized domain converted to punycode.
+// It returns false if the domain cannot be converted.
+func CanonicalEmail(value string) (string, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	local, domain, found := cutEmail(value)
+	if !found || domain == "" {
+		return value, true
+	}
+
+	domain, err := CanonicalDomain(domain)
+	if err != nil {
+		return "", false
+	}
+
+	return local + "@" + domain, true
+}
+
+// CanonicalDomain returns the domain in lower case and in punycode
+func CanonicalDomain(domain string) (string, error) {
+	domain, err := idna.Lookup.ToASCII(strings.TrimSpace(domain))
+	if err != nil {
+		return "", err
+	}
+	return strings.ToLower(domain), nil
+}
+
 // Validate checks if a struct is valid and returns an array of errors
 func (e *Email) Validate() validation.ValidatorErrors {
 	return validation.ValidateVar(e.value, "email", "required,email")
 }
+
+// cutEmail splits an email around its last @
+func cutEmail(value string) (local, domain string, found bool) {
+	i := strings.LastIndexByte(value, '@')
+	if i < 0 {
+		return value, "", false
+	}
+	return value[:i], value[i+1:], true
+}
@@ -0,0 +1,89 @@
+package values_objects
+
+import (
+	"go-clean-api/pkg/domain/validation"
+	"strings"
+)
+
+// EmailPolicy represents the rules on the domains of the new emails.
+// A domain of a list also matches its subdomains.
+type EmailPolicy struct {
+	// allowed contains the only allowed domains (all the domains if empty)
+	allowed map[string]struct{}
+
+	// blocked contains the forbidden domains
+	blocked map[string]struct{}
+
+	// disposable contains the domains of the disposable email providers
+	disposable map[string]struct{}
+}
+
+// WithAllowedDomains returns a copy of the policy only allowing the domains of the list
+func (p EmailPolicy) WithAllowedDomains(domains []string) EmailPolicy {
+	p.allowed = domainSet(domains)
+	return p
+}
+
+// WithBlockedDomains returns a copy of the policy forbidding the domains of the list
+func (p EmailPolicy) WithBlockedDomains(domains []string) EmailPolicy {
+	p.blocked = domainSet(domains)
+	return p
+}
+
+// WithDisposableDomains returns a copy of the policy forbidding the disposable email domains of the list
+func (p EmailPolicy) WithDisposableDomains(domains []string) EmailPolicy {
+	p.disposable = domainSet(domains)
+	return p
+}
+
+// Validate checks if an email matches the policy and returns an array of errors
+// if it does not.
+func (p EmailPolicy) Validate(email Email) (errors validation.ValidatorErrors) {
+	domain := email.Domain()
+
+	if len(p.allowed) > 0 && !matchDomain(p.allowed, domain) {
+		errors = append(errors, emailPolicyError("allowed_domain", domain, "email domain is not allowed"))
+	}
+	if matchDomain(p.blocked, domain) {
+		errors = append(errors, emailPolicyError("blocked_domain", domain, "email domain is blocked"))
+	}
+	if matchDomain(p.disposable, domain) {
+		errors = append(errors, emailPolicyError("disposable", domain, "disposable email addresses are not allowed"))
+	}
+
+	return
+}
+
+// domainSet returns the set of the canonical domains of the list
+func domainSet(domains []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(domains))
+	for _, domain := range domains {
+		canonical, err := CanonicalDomain(domain)
+		if err != nil {
+			canonical = strings.ToLower(strings.TrimSpace(domain))
+		}
+		if canonical != "" {
+			set[canonical] = struct{}{}
+		}
+	}
+	return set
+}
+
+// matchDomain checks if the domain or one of its parent domains is in the set
+func matchDomain(set map[string]struct{}, domain string) bool {
+	for domain != "" {
+		if _, ok := set[domain]; ok {
+			return true
+		}
+		_, parent, found := strings.Cut(domain, ".")
+		if !found {
+			return false
+		}
+		domain = parent
+	}
+	return false
+}
+
+func emailPolicyError(tag, value, message string) validation.ValidatorError {
+	return validation.ValidatorError{Field: "email", Tag: tag, Value: value, Message: message}
+}
@@ -0,0 +1,71 @@
+package values_objects
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       EmailPolicy
+		email        string
+		wantedErrors []string
+	}{
+		{
+			name:   "Empty policy",
+			policy: EmailPolicy{},
+			email:  "john@test.com",
+		},
+		{
+			name:   "Allowed domain",
+			policy: EmailPolicy{}.WithAllowedDomains([]string{"Test.com", "example.org"}),
+			email:  "john@test.com",
+		},
+		{
+			name:   "Allowed subdomain",
+			policy: EmailPolicy{}.WithAllowedDomains([]string{"test.com"}),
+			email:  "john@mail.test.com",
+		},
+		{
+			name:         "Not allowed domain",
+			policy:       EmailPolicy{}.WithAllowedDomains([]string{"test.com"}),
+			email:        "john@attest.com",
+			wantedErrors: []string{"allowed_domain"},
+		},
+		{
+			name:         "Blocked domain",
+			policy:       EmailPolicy{}.WithBlockedDomains([]string{"test.com"}),
+			email:        "john@mail.test.com",
+			wantedErrors: []string{"blocked_domain"},
+		},
+		{
+			name:         "Blocked internationalized domain",
+			policy:       EmailPolicy{}.WithBlockedDomains([]string{"éxample.fr"}),
+			email:        "jean@ÉXAMPLE.fr",
+			wantedErrors: []string{"blocked_domain"},
+		},
+		{
+			name:         "Disposable domain",
+			policy:       EmailPolicy{}.WithAllowedDomains([]string{"yopmail.com"}).WithDisposableDomains([]string{"yopmail.com"}),
+			email:        "john@yopmail.com",
+			wantedErrors: []string{"disposable"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			email, err := NewEmail(tt.email)
+			assert.Nil(t, err)
+
+			var tags []string
+			for _, e := range tt.policy.Validate(email) {
+				assert.Equal(t, "email", e.Field)
+				assert.Equal(t, email.Domain(), e.Value)
+				tags = append(tags, e.Tag)
+			}
+			assert.Equal(t, tt.wantedErrors, tags)
+		})
+	}
+}
@@ -16,15 +16,17 @@ func TestNewEmail(t *testing.T) {
 
 	var e1 validation.ValidatorErrors
 	e1 = append(e1, validation.ValidatorError{
-		Field: "email",
-		Tag:   "email",
-		Value: "",
+		Field:   "email",
+		Tag:     "email",
+		Value:   "",
+		Message: "email must be a valid email address",
 	})
 	var e2 validation.ValidatorErrors
 	e2 = append(e2, validation.ValidatorError{
-		Field: "email",
-		Tag:   "required",
-		Value: "",
+		Field:   "email",
+		Tag:     "required",
+		Value:   "",
+		Message: "email is a required field",
 	})
 
 	tests := []struct {
@@ -38,6 +40,20 @@ func TestNewEmail(t *testing.T) {
 				err:   nil,
 			},
 		},
+		{
+			value: " John.Doe@Test.COM ",
+			wanted: result{
+				email: Email{value: "john.doe@test.com"},
+				err:   nil,
+			},
+		},
+		{
+			value: "jean@Éxample.fr",
+			wanted: result{
+				email: Email{value: "jean@xn--xample-9ua.fr"},
+				err:   nil,
+			},
+		},
 		{
 			value: "bad",
 			wanted: result{
@@ -52,17 +68,27 @@ func TestNewEmail(t *testing.T) {
 				err:   &e2,
 			},
 		},
+		{
+			value: "john@exa mple.com",
+			wanted: result{
+				email: Email{},
+				err:   &e1,
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.value, func(t *testing.T) {
 			got, err := NewEmail(tt.value)
 
-			if err != nil {
-				assert.Equal(t, err, tt.wanted.err)
-			} else {
-				assert.Equal(t, got, tt.wanted.email)
-			}
+			assert.Equal(t, tt.wanted.err, err)
+			assert.Equal(t, tt.wanted.email, got)
 		})
 	}
 }
+
+func TestEmailDomain(t *testing.T) {
+	email, _ := NewEmail("john@Mail.Test.com")
+
+	assert.Equal(t, "mail.test.com", email.Domain())
+}
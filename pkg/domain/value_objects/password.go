@@ -2,11 +2,10 @@ package values_objects
 
 import (
 	"go-clean-api/pkg/domain/validation"
-
-	"golang.org/x/crypto/bcrypt"
 )
 
-// Password represents an password value object
+// Password represents a password value object, in clear text or hashed.
+// The rules of the new passwords are checked by a PasswordPolicy.
 type Password struct {
 	value string
 }
@@ -34,16 +33,5 @@ func NewPassword(value string) (Password, error) {
 
 // Validate checks if a struct is valid and returns an array of errors
 func (p *Password) Validate() validation.ValidatorErrors {
-	return validation.ValidateVar(p.value, "password", "required,min=8")
-}
-
-// HashUserPassword hashes a password
-func (p *Password) HashUserPassword() (string, error) {
-	passwordBytes, err := bcrypt.GenerateFromPassword([]byte(p.value), bcrypt.DefaultCost)
-	return string(passwordBytes), err
-}
-
-// Verify checks if the password is correct
-func (p *Password) Verify(hashedPassword string) error {
-	return bcrypt.CompareHashAndPassword([]byte(p.value), []byte(hashedPassword))
+	return validation.ValidateVar(p.value, "password", "required")
 }
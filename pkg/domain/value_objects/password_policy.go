@@ -0,0 +1,94 @@
+package values_objects
+
+import (
+	"go-clean-api/pkg/domain/validation"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// PasswordPolicy represents the rules of the new passwords
+type PasswordPolicy struct {
+	// MinLength is the minimum number of characters
+	MinLength int
+
+	// MaxLength is the maximum number of bytes (bcrypt ignores the bytes after the 72nd)
+	MaxLength int
+
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+
+	// denylist contains the forbidden passwords in lower case
+	denylist map[string]struct{}
+}
+
+// WithDenylist returns a copy of the policy forbidding the passwords of the list (case insensitive)
+func (p PasswordPolicy) WithDenylist(passwords []string) PasswordPolicy {
+	p.denylist = make(map[string]struct{}, len(passwords))
+	for _, password := range passwords {
+		p.denylist[strings.ToLower(password)] = struct{}{}
+	}
+	return p
+}
+
+// Validate checks if a password matches the policy and returns an array of errors
+// if it does not. Messages are in English.
+func (p PasswordPolicy) Validate(password Password) validation.ValidatorErrors {
+	return p.ValidateLocale(password, validation.DefaultLocale)
+}
+
+// ValidateLocale checks if a password matches the policy and returns an array of errors
+// with messages in the locale (in the default locale if it is not supported).
+func (p PasswordPolicy) ValidateLocale(password Password, locale string) (errors validation.ValidatorErrors) {
+	value := password.Value()
+
+	if p.MinLength > 0 && len([]rune(value)) < p.MinLength {
+		errors = append(errors, passwordPolicyError("min", strconv.Itoa(p.MinLength), locale))
+	}
+	if p.MaxLength > 0 && len(value) > p.MaxLength {
+		errors = append(errors, passwordPolicyError("max", strconv.Itoa(p.MaxLength), locale))
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range value {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+	if p.RequireUppercase && !upper {
+		errors = append(errors, passwordPolicyError("uppercase", "", locale))
+	}
+	if p.RequireLowercase && !lower {
+		errors = append(errors, passwordPolicyError("lowercase", "", locale))
+	}
+	if p.RequireDigit && !digit {
+		errors = append(errors, passwordPolicyError("digit", "", locale))
+	}
+	if p.RequireSymbol && !symbol {
+		errors = append(errors, passwordPolicyError("symbol", "", locale))
+	}
+
+	if _, ok := p.denylist[strings.ToLower(value)]; ok {
+		errors = append(errors, passwordPolicyError("denylist", "", locale))
+	}
+
+	return
+}
+
+// passwordPolicyError returns the error of a rule of the policy with its message in the locale
+func passwordPolicyError(tag, value, locale string) validation.ValidatorError {
+	var params []string
+	if value != "" {
+		params = append(params, value)
+	}
+	return validation.ValidatorError{Field: "password", Tag: tag, Value: value, Message: validation.Translate(locale, "password_"+tag, params...)}
+}
@@ -0,0 +1,70 @@
+package values_objects
+
+import (
+	"go-clean-api/pkg/domain/validation"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordPolicyValidate(t *testing.T) {
+	policy := PasswordPolicy{
+		MinLength:        10,
+		MaxLength:        72,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+	}.WithDenylist([]string{"Password123!"})
+
+	tests := []struct {
+		name       string
+		password   string
+		wantedTags []string
+	}{
+		{name: "Valid password", password: "Correct-Horse-42"},
+		{name: "Unicode letters", password: "Éléphant-rosé-7"},
+		{name: "Too short", password: "Ab1-", wantedTags: []string{"min"}},
+		{name: "Too long", password: "Ab1-" + string(make([]byte, 70)), wantedTags: []string{"max"}},
+		{name: "Missing classes", password: "onlylowercase", wantedTags: []string{"uppercase", "digit", "symbol"}},
+		{name: "Denylisted", password: "password123!", wantedTags: []string{"uppercase", "denylist"}},
+		{name: "Denylisted case insensitive", password: "PASSWORD123!", wantedTags: []string{"lowercase", "denylist"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			password, _ := NewPassword(tt.password)
+
+			errs := policy.Validate(password)
+
+			var tags []string
+			for _, err := range errs {
+				assert.Equal(t, "password", err.Field)
+				assert.NotEmpty(t, err.Message)
+				tags = append(tags, err.Tag)
+			}
+			assert.Equal(t, tt.wantedTags, tags)
+		})
+	}
+}
+
+func TestPasswordPolicyWithoutRules(t *testing.T) {
+	password, _ := NewPassword("a")
+
+	assert.Equal(t, validation.ValidatorErrors(nil), PasswordPolicy{}.Validate(password))
+}
+
+func TestPasswordPolicyValidateLocale(t *testing.T) {
+	policy := PasswordPolicy{MinLength: 10, RequireDigit: true}
+	password, _ := NewPassword("short")
+
+	assert.Equal(t, validation.ValidatorErrors{
+		{Field: "password", Tag: "min", Value: "10", Message: "password must be at least 10 characters in length"},
+		{Field: "password", Tag: "digit", Value: "", Message: "password must contain a digit"},
+	}, policy.Validate(password))
+
+	assert.Equal(t, validation.ValidatorErrors{
+		{Field: "password", Tag: "min", Value: "10", Message: "le mot de passe doit contenir au moins 10 caractères"},
+		{Field: "password", Tag: "digit", Value: "", Message: "le mot de passe doit contenir un chiffre"},
+	}, policy.ValidateLocale(password, validation.LocaleFR))
+}
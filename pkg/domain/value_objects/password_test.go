@@ -15,17 +15,12 @@ func TestNewPassword(t *testing.T) {
 		err      error
 	}
 
-	var e1 validation.ValidatorErrors
-	e1 = append(e1, validation.ValidatorError{
-		Field: "password",
-		Tag:   "min",
-		Value: "8",
-	})
 	var e2 validation.ValidatorErrors
 	e2 = append(e2, validation.ValidatorError{
-		Field: "password",
-		Tag:   "required",
-		Value: "",
+		Field:   "password",
+		Tag:     "required",
+		Value:   "",
+		Message: "password is a required field",
 	})
 
 	tests := []struct {
@@ -42,8 +37,8 @@ func TestNewPassword(t *testing.T) {
 		{
 			value: "bad",
 			wanted: result{
-				password: Password{},
-				err:      &e1,
+				password: Password{value: "bad"},
+				err:      nil,
 			},
 		},
 		{
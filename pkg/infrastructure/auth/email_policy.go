@@ -0,0 +1,25 @@
+package auth
+
+import (
+	"go-clean-api/pkg"
+	"go-clean-api/pkg/apperr"
+	vo "go-clean-api/pkg/domain/value_objects"
+)
+
+// NewEmailPolicy creates the email domains policy of the configuration.
+// The disposable domains file contains a domain by line, empty lines and lines starting with # are ignored.
+func NewEmailPolicy(cfg pkg.ConfigEmail) (vo.EmailPolicy, error) {
+	policy := vo.EmailPolicy{}.
+		WithAllowedDomains(cfg.AllowedDomains).
+		WithBlockedDomains(cfg.BlockedDomains)
+	if cfg.DisposableDomainsPath == "" {
+		return policy, nil
+	}
+
+	domains, err := readList(cfg.DisposableDomainsPath)
+	if err != nil {
+		return policy, apperr.NewAppErr(err, "error when reading the disposable email domains", nil, nil)
+	}
+
+	return policy.WithDisposableDomains(domains), nil
+}
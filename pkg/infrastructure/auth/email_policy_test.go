@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"go-clean-api/pkg"
+	vo "go-clean-api/pkg/domain/value_objects"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEmailPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "disposable.txt")
+	assert.Nil(t, os.WriteFile(path, []byte("# Disposable domains\nyopmail.com\n\n  Mailinator.com  \n"), 0o600))
+
+	policy, err := NewEmailPolicy(pkg.ConfigEmail{BlockedDomains: []string{"test.com"}, DisposableDomainsPath: path})
+	assert.Nil(t, err)
+
+	for email, wantedErrors := range map[string]int{"john@yopmail.com": 1, "john@mailinator.com": 1, "john@test.com": 1, "john@example.com": 0} {
+		e, _ := vo.NewEmail(email)
+		assert.Equal(t, wantedErrors, len(policy.Validate(e)), email)
+	}
+
+	_, err = NewEmailPolicy(pkg.ConfigEmail{DisposableDomainsPath: filepath.Join(t.TempDir(), "missing.txt")})
+	assert.NotNil(t, err)
+}
+
+func TestDisposableEmailDomainsAsset(t *testing.T) {
+	policy, err := NewEmailPolicy(pkg.ConfigEmail{DisposableDomainsPath: "../../../assets/disposable_email_domains.txt"})
+	assert.Nil(t, err)
+
+	e, _ := vo.NewEmail("john@mailinator.com")
+	assert.Equal(t, 1, len(policy.Validate(e)))
+}
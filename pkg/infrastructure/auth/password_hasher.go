@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go-clean-api/pkg"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Hashing algorithms of the passwords
+const (
+	HasherBcrypt   = "bcrypt"
+	HasherArgon2ID = "argon2id"
+)
+
+const (
+	// argon2SaltLength is the length of the argon2id salts (in bytes)
+	argon2SaltLength = 16
+
+	// argon2KeyLength is the length of the argon2id hashes (in bytes)
+	argon2KeyLength = 32
+)
+
+// ErrUnknownPasswordHash is returned when the algorithm of a password hash is not supported
+var ErrUnknownPasswordHash = errors.New("unknown password hash format")
+
+// argon2Params are the parameters of an argon2id hash
+type argon2Params struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+}
+
+// PasswordHasher implements services.PasswordHasher with bcrypt and argon2id.
+// The hashes of both algorithms are verified, the new ones use the configured algorithm.
+type PasswordHasher struct {
+	algorithm  string
+	bcryptCost int
+	argon2     argon2Params
+}
+
+// NewPasswordHasher creates a new PasswordHasher.
+func NewPasswordHasher(cfg pkg.ConfigPassword) *PasswordHasher {
+	return &PasswordHasher{
+		algorithm:  cfg.Hasher,
+		bcryptCost: cfg.BcryptCost,
+		argon2: argon2Params{
+			Memory:      cfg.Argon2Memory,
+			Iterations:  cfg.Argon2Iterations,
+			Parallelism: cfg.Argon2Parallelism,
+		},
+	}
+}
+
+// Hash returns the hash of a password with the configured algorithm.
+// argon2id hashes are encoded in the PHC string format: $argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<hash>
+func (h *PasswordHasher) Hash(password string) (string, error) {
+	if h.algorithm == HasherArgon2ID {
+		salt := make([]byte, argon2SaltLength)
+		if _, err := rand.Read(salt); err != nil {
+			return "", err
+		}
+		key := argon2.IDKey([]byte(password), salt, h.argon2.Iterations, h.argon2.Memory, h.argon2.Parallelism, argon2KeyLength)
+
+		return fmt.Sprintf(
+			"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+			argon2.Version,
+			h.argon2.Memory,
+			h.argon2.Iterations,
+			h.argon2.Parallelism,
+			base64.RawStdEncoding.EncodeToString(salt),
+			base64.RawStdEncoding.EncodeToString(key),
+		), nil
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.bcryptCost)
+	return string(hash), err
+}
+
+// Verify returns true if the password matches the hash (bcrypt or argon2id)
+func (h *PasswordHasher) Verify(hash, password string) (bool, error) {
+	switch {
+	case isBcryptHash(hash):
+		err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+		return err == nil, err
+	case strings.HasPrefix(hash, "$argon2id$"):
+		params, salt, key, err := decodeArgon2Hash(hash)
+		if err != nil {
+			return false, err
+		}
+		other := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+
+		return subtle.ConstantTimeCompare(key, other) == 1, nil
+	}
+
+	return false, ErrUnknownPasswordHash
+}
+
+// NeedsRehash returns true if the hash does not use the configured algorithm and parameters
+func (h *PasswordHasher) NeedsRehash(hash string) bool {
+	if h.algorithm == HasherArgon2ID {
+		params, _, key, err := decodeArgon2Hash(hash)
+		return err != nil || params != h.argon2 || len(key) != argon2KeyLength
+	}
+
+	if !isBcryptHash(hash) {
+		return true
+	}
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != h.bcryptCost
+}
+
+// isBcryptHash returns true if the hash is a bcrypt hash
+func isBcryptHash(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+// decodeArgon2Hash returns the parameters, the salt and the key of an argon2id hash
+func decodeArgon2Hash(hash string) (params argon2Params, salt, key []byte, err error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != HasherArgon2ID {
+		return params, nil, nil, ErrUnknownPasswordHash
+	}
+
+	var version int
+	if _, err = fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return params, nil, nil, fmt.Errorf("%w: %w", ErrUnknownPasswordHash, err)
+	}
+	if version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("%w: unsupported argon2 version %d", ErrUnknownPasswordHash, version)
+	}
+
+	if _, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, fmt.Errorf("%w: %w", ErrUnknownPasswordHash, err)
+	}
+
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return params, nil, nil, fmt.Errorf("%w: %w", ErrUnknownPasswordHash, err)
+	}
+	if key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil {
+		return params, nil, nil, fmt.Errorf("%w: %w", ErrUnknownPasswordHash, err)
+	}
+
+	return params, salt, key, nil
+}
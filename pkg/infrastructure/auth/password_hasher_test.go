@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"go-clean-api/pkg"
+	vo "go-clean-api/pkg/domain/value_objects"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordHasher(t *testing.T) {
+	bcryptHasher := NewPasswordHasher(pkg.ConfigPassword{Hasher: HasherBcrypt, BcryptCost: 4})
+	argon2Hasher := NewPasswordHasher(pkg.ConfigPassword{Hasher: HasherArgon2ID, Argon2Memory: 1024, Argon2Iterations: 1, Argon2Parallelism: 1})
+
+	for name, hasher := range map[string]*PasswordHasher{HasherBcrypt: bcryptHasher, HasherArgon2ID: argon2Hasher} {
+		t.Run(name, func(t *testing.T) {
+			hash, err := hasher.Hash("correct horse")
+			assert.Nil(t, err)
+			assert.True(t, strings.HasPrefix(hash, map[string]string{HasherBcrypt: "$2a$04$", HasherArgon2ID: "$argon2id$v=19$m=1024,t=1,p=1$"}[name]))
+
+			ok, err := hasher.Verify(hash, "correct horse")
+			assert.Nil(t, err)
+			assert.True(t, ok)
+
+			ok, err = hasher.Verify(hash, "wrong horse")
+			assert.Nil(t, err)
+			assert.False(t, ok)
+
+			assert.False(t, hasher.NeedsRehash(hash))
+		})
+	}
+}
+
+func TestPasswordHasherLegacyHashes(t *testing.T) {
+	bcryptHasher := NewPasswordHasher(pkg.ConfigPassword{Hasher: HasherBcrypt, BcryptCost: 4})
+	argon2Hasher := NewPasswordHasher(pkg.ConfigPassword{Hasher: HasherArgon2ID, Argon2Memory: 1024, Argon2Iterations: 1, Argon2Parallelism: 1})
+
+	bcryptHash, _ := bcryptHasher.Hash("correct horse")
+	argon2Hash, _ := argon2Hasher.Hash("correct horse")
+
+	// Hashes of the other algorithm are verified and must be rehashed
+	ok, err := argon2Hasher.Verify(bcryptHash, "correct horse")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.True(t, argon2Hasher.NeedsRehash(bcryptHash))
+
+	ok, err = bcryptHasher.Verify(argon2Hash, "correct horse")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.True(t, bcryptHasher.NeedsRehash(argon2Hash))
+
+	// Other parameters
+	assert.True(t, NewPasswordHasher(pkg.ConfigPassword{Hasher: HasherBcrypt, BcryptCost: 5}).NeedsRehash(bcryptHash))
+	assert.True(t, NewPasswordHasher(pkg.ConfigPassword{Hasher: HasherArgon2ID, Argon2Memory: 2048, Argon2Iterations: 1, Argon2Parallelism: 1}).NeedsRehash(argon2Hash))
+
+	// Unknown or invalid hashes
+	_, err = bcryptHasher.Verify("plain text", "plain text")
+	assert.ErrorIs(t, err, ErrUnknownPasswordHash)
+	_, err = argon2Hasher.Verify("$argon2id$v=19$m=1024,t=1$salt$key", "correct horse")
+	assert.ErrorIs(t, err, ErrUnknownPasswordHash)
+}
+
+func TestNewPasswordPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "denylist.txt")
+	assert.Nil(t, os.WriteFile(path, []byte("# Common passwords\n123456\n\n  Password1  \n"), 0o600))
+
+	policy, err := NewPasswordPolicy(pkg.ConfigPassword{MinLength: 6, DenylistPath: path})
+	assert.Nil(t, err)
+
+	for password, wantedErrors := range map[string]int{"123456": 1, "password1": 1, "qwerty": 0} {
+		p, _ := vo.NewPassword(password)
+		assert.Equal(t, wantedErrors, len(policy.Validate(p)), password)
+	}
+
+	_, err = NewPasswordPolicy(pkg.ConfigPassword{DenylistPath: filepath.Join(t.TempDir(), "missing.txt")})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"bufio"
+	"os"
+	"strings"
+
+	"go-clean-api/pkg"
+	"go-clean-api/pkg/apperr"
+	vo "go-clean-api/pkg/domain/value_objects"
+)
+
+// NewPasswordPolicy creates the password policy of the configuration.
+// The denylist file contains a password by line, empty lines and lines starting with # are ignored.
+func NewPasswordPolicy(cfg pkg.ConfigPassword) (vo.PasswordPolicy, error) {
+	policy := vo.PasswordPolicy{
+		MinLength:        cfg.MinLength,
+		MaxLength:        cfg.MaxLength,
+		RequireUppercase: cfg.RequireUppercase,
+		RequireLowercase: cfg.RequireLowercase,
+		RequireDigit:     cfg.RequireDigit,
+		RequireSymbol:    cfg.RequireSymbol,
+	}
+	if cfg.DenylistPath == "" {
+		return policy, nil
+	}
+
+	denylist, err := readList(cfg.DenylistPath)
+	if err != nil {
+		return policy, apperr.NewAppErr(err, "error when reading the password denylist", nil, nil)
+	}
+
+	return policy.WithDenylist(denylist), nil
+}
+
+// readList returns the trimmed lines of a file, without the empty lines and the comments (lines starting with #)
+func readList(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var list []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		list = append(list, line)
+	}
+
+	return list, scanner.Err()
+}
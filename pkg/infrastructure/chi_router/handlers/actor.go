@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"fmt"
+	"go-clean-api/pkg/domain/entities"
+	"net"
+	"net/http"
+)
+
+// Actor returns the actor of the request: the authenticated user, the client IP and the request ID.
+func Actor(r *http.Request) entities.Actor {
+	actor := entities.Actor{
+		IP: clientIP(r),
+	}
+
+	if requestID := r.Context().Value(RequestIDKey("request_id")); requestID != nil {
+		actor.RequestID = fmt.Sprintf("%s", requestID)
+	}
+
+	if auth, ok := AuthFromContext(r.Context()); ok {
+		actor.ID = auth.UserID
+	}
+
+	return actor
+}
+
+// clientIP returns the client IP without port.
+// The RealIP middleware may have already replaced the remote address by a single IP.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,53 @@
+package admin
+
+import (
+	"errors"
+	"go-clean-api/pkg/infrastructure/logger"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+type LogLevelResponse struct {
+	Level           string `json:"level" xml:"level"`
+	ConfiguredLevel string `json:"configured_level" xml:"configured_level"`
+	RevertAt        string `json:"revert_at,omitempty" xml:"revert_at,omitempty"`
+}
+
+func (r LogLevelResponse) FromStatus(status logger.LevelStatus) LogLevelResponse {
+	revertAt := ""
+	if !status.RevertAt.IsZero() {
+		revertAt = status.RevertAt.UTC().Format(time.RFC3339)
+	}
+
+	return LogLevelResponse{
+		Level:           status.Level.String(),
+		ConfiguredLevel: status.Configured.String(),
+		RevertAt:        revertAt,
+	}
+}
+
+type SetLogLevelRequest struct {
+	Level       string `json:"level" xml:"level" form:"level" validate:"required,oneof=debug info warn error fatal panic"`
+	RevertAfter string `json:"revert_after,omitempty" xml:"revert_after,omitempty" form:"revert_after"`
+}
+
+// ToLevel returns the level and the delay before the revert.
+// Without revert_after, the default delay is used, 0 disables the revert.
+func (r SetLogLevelRequest) ToLevel(defaultRevertAfter time.Duration) (zapcore.Level, time.Duration, error) {
+	level, err := logger.ParseLevel(r.Level)
+	if err != nil {
+		return level, 0, err
+	}
+
+	if r.RevertAfter == "" {
+		return level, defaultRevertAfter, nil
+	}
+
+	revertAfter, err := time.ParseDuration(r.RevertAfter)
+	if err != nil || revertAfter < 0 {
+		return level, 0, errors.New("revert_after must be a positive duration (e.g. 10m)")
+	}
+
+	return level, revertAfter, nil
+}
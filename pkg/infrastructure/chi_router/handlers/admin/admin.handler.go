@@ -0,0 +1,59 @@
+package admin
+
+import (
+	"go-clean-api/pkg/infrastructure/chi_router/handlers"
+	"go-clean-api/pkg/infrastructure/chi_router/httputil"
+	"go-clean-api/pkg/infrastructure/logger"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Handler handles administration requests
+type Handler struct {
+	router      chi.Router
+	logger      logger.CustomLogger
+	level       *logger.Level
+	revertAfter time.Duration
+}
+
+// NewHandler returns a new Handler.
+// revertAfter is the default delay before reverting a log level change.
+func NewHandler(r chi.Router, l logger.CustomLogger, level *logger.Level, revertAfter time.Duration) Handler {
+	return Handler{
+		router:      r,
+		logger:      l,
+		level:       level,
+		revertAfter: revertAfter,
+	}
+}
+
+// Routes adds administration routes
+func (h *Handler) Routes() {
+	h.router.Get("/log-level", handlers.WrapError(h.getLogLevel, h.logger))
+	h.router.Put("/log-level", handlers.WrapError(h.setLogLevel, h.logger))
+	h.router.Delete("/log-level", handlers.WrapError(h.resetLogLevel, h.logger))
+}
+
+func (h *Handler) getLogLevel(w http.ResponseWriter, r *http.Request) error {
+	return httputil.JSON(w, LogLevelResponse{}.FromStatus(h.level.Status()))
+}
+
+func (h *Handler) setLogLevel(w http.ResponseWriter, r *http.Request) error {
+	body, err := httputil.Bind[SetLogLevelRequest](w, r)
+	if err != nil {
+		return err
+	}
+
+	level, revertAfter, err := body.ToLevel(h.revertAfter)
+	if err != nil {
+		return httputil.Err400(w, err, "Invalid parameters", err.Error())
+	}
+
+	return httputil.JSON(w, LogLevelResponse{}.FromStatus(h.level.Set(level, revertAfter)))
+}
+
+func (h *Handler) resetLogLevel(w http.ResponseWriter, r *http.Request) error {
+	return httputil.JSON(w, LogLevelResponse{}.FromStatus(h.level.Reset()))
+}
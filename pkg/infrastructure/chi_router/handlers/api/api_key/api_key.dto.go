@@ -0,0 +1,138 @@
+package api_key
+
+import (
+	"go-clean-api/pkg/domain/entities"
+	"go-clean-api/pkg/domain/usecases"
+	vo "go-clean-api/pkg/domain/value_objects"
+)
+
+type APIKeyResponse struct {
+	ID         string   `json:"id" xml:"id"`
+	Name       string   `json:"name" xml:"name"`
+	Prefix     string   `json:"prefix" xml:"prefix"`
+	Scopes     []string `json:"scopes" xml:"scopes"`
+	ExpiresAt  string   `json:"expires_at,omitempty" xml:"expires_at,omitempty"`
+	LastUsedAt string   `json:"last_used_at,omitempty" xml:"last_used_at,omitempty"`
+	CreatedAt  string   `json:"created_at" xml:"created_at"`
+	RevokedAt  string   `json:"revoked_at,omitempty" xml:"revoked_at,omitempty"`
+}
+
+func apiKeyResponseFromEntity(apiKey entities.APIKey) APIKeyResponse {
+	res := APIKeyResponse{
+		ID:        apiKey.ID.String(),
+		Name:      apiKey.Name,
+		Prefix:    apiKey.Prefix,
+		Scopes:    apiKey.Scopes,
+		CreatedAt: apiKey.CreatedAt.RFC3339(),
+	}
+	if res.Scopes == nil {
+		res.Scopes = []string{}
+	}
+	if apiKey.ExpiresAt != nil {
+		res.ExpiresAt = apiKey.ExpiresAt.RFC3339()
+	}
+	if apiKey.LastUsedAt != nil {
+		res.LastUsedAt = apiKey.LastUsedAt.RFC3339()
+	}
+	if apiKey.RevokedAt != nil {
+		res.RevokedAt = apiKey.RevokedAt.RFC3339()
+	}
+
+	return res
+}
+
+//
+// ======== Create ========
+//
+
+type CreateRequest struct {
+	Name      string   `json:"name" xml:"name" form:"name" validate:"required,max=127"`
+	Scopes    []string `json:"scopes,omitempty" xml:"scopes,omitempty" form:"scopes"`
+	ExpiresAt string   `json:"expires_at,omitempty" xml:"expires_at,omitempty" form:"expires_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+func (r CreateRequest) ToUseCase(userID string) (usecases.CreateAPIKeyRequest, error) {
+	id, err := vo.NewIDFrom(userID)
+	if err != nil {
+		return usecases.CreateAPIKeyRequest{}, err
+	}
+
+	req := usecases.CreateAPIKeyRequest{
+		UserID: id,
+		Name:   r.Name,
+		Scopes: r.Scopes,
+	}
+
+	if r.ExpiresAt != "" {
+		expiresAt, err := vo.ParseRFC3339(r.ExpiresAt, nil)
+		if err != nil {
+			return usecases.CreateAPIKeyRequest{}, err
+		}
+		req.ExpiresAt = &expiresAt
+	}
+
+	return req, nil
+}
+
+// CreateResponse is the only response containing the API key
+type CreateResponse struct {
+	APIKeyResponse
+	Key string `json:"key" xml:"key"`
+}
+
+func (r CreateResponse) FromEntity(res usecases.CreateAPIKeyResponse) CreateResponse {
+	r.APIKeyResponse = apiKeyResponseFromEntity(res.APIKey)
+	r.Key = res.Key
+
+	return r
+}
+
+//
+// ======== Get all ========
+//
+
+type GetAllResponse struct {
+	Data  []APIKeyResponse `json:"data" xml:"data"`
+	Page  int              `json:"page" xml:"page"`
+	Size  int              `json:"size" xml:"size"`
+	Total int64            `json:"total" xml:"total"`
+}
+
+func (r GetAllResponse) FromEntity(res usecases.GetAllAPIKeysResponse, pagination vo.Pagination) GetAllResponse {
+	r.Data = make([]APIKeyResponse, len(res.Data))
+	for i, apiKey := range res.Data {
+		r.Data[i] = apiKeyResponseFromEntity(apiKey)
+	}
+
+	r.Total = res.Total
+	r.Page = pagination.Page()
+	r.Size = pagination.Size()
+
+	return r
+}
+
+//
+// ======== Revoke ========
+//
+
+type RevokeRequest struct {
+	ID     string
+	UserID string
+}
+
+func (r RevokeRequest) ToUseCase() (usecases.RevokeAPIKeyRequest, error) {
+	id, err := vo.NewIDFrom(r.ID)
+	if err != nil {
+		return usecases.RevokeAPIKeyRequest{}, err
+	}
+
+	userID, err := vo.NewIDFrom(r.UserID)
+	if err != nil {
+		return usecases.RevokeAPIKeyRequest{}, err
+	}
+
+	return usecases.RevokeAPIKeyRequest{
+		ID:     id,
+		UserID: userID,
+	}, nil
+}
@@ -0,0 +1,98 @@
+package api_key
+
+import (
+	"go-clean-api/pkg/domain/entities"
+	"go-clean-api/pkg/domain/usecases"
+	vo "go-clean-api/pkg/domain/value_objects"
+	"go-clean-api/pkg/infrastructure/chi_router/handlers"
+	"go-clean-api/pkg/infrastructure/chi_router/httputil"
+	"go-clean-api/pkg/infrastructure/logger"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Handler handles API key requests
+type Handler struct {
+	router        chi.Router
+	apiKeyUseCase usecases.APIKey
+	logger        logger.CustomLogger
+}
+
+// NewHandler returns a new Handler
+func NewHandler(r chi.Router, l logger.CustomLogger, apiKeyUseCase usecases.APIKey) Handler {
+	return Handler{
+		router:        r,
+		apiKeyUseCase: apiKeyUseCase,
+		logger:        l,
+	}
+}
+
+// PrivateRoutes adds API keys private routes.
+// The API keys are those of the authenticated user.
+func (h *Handler) PrivateRoutes() {
+	h.router.Post("/", handlers.WrapError(h.create, h.logger))
+	h.router.Get("/", handlers.WrapError(h.getAll, h.logger))
+	h.router.Delete("/{id}", handlers.WrapError(h.revoke, h.logger))
+}
+
+func (h *Handler) create(w http.ResponseWriter, r *http.Request) error {
+	body, err := httputil.Bind[CreateRequest](w, r)
+	if err != nil {
+		return err
+	}
+
+	auth, _ := handlers.AuthFromContext(r.Context())
+	req, err := body.ToUseCase(auth.UserID)
+	if err != nil {
+		return httputil.Err400(w, err, "Invalid parameters", err)
+	}
+
+	// An API key without scope is granted all the scopes
+	if auth.APIKeyID != "" {
+		req.CreatorScopes = auth.Scopes
+		if len(req.CreatorScopes) == 0 {
+			req.CreatorScopes = entities.APIKeyScopes()
+		}
+	}
+
+	resUC, errUC := h.apiKeyUseCase.Create(req)
+	if errUC != nil {
+		return errUC
+	}
+
+	return httputil.Created(w, CreateResponse{}.FromEntity(resUC))
+}
+
+func (h *Handler) getAll(w http.ResponseWriter, r *http.Request) error {
+	auth, _ := handlers.AuthFromContext(r.Context())
+	userID, err := vo.NewIDFrom(auth.UserID)
+	if err != nil {
+		return httputil.Err400(w, err, "Invalid user", nil)
+	}
+	pagination := vo.PaginationFromQuery(r.URL.Query().Get("page"), r.URL.Query().Get("size"), "")
+
+	apiKeys, errUC := h.apiKeyUseCase.GetAll(usecases.GetAllAPIKeysRequest{
+		UserID:     userID,
+		Pagination: pagination,
+	})
+	if errUC != nil {
+		return errUC
+	}
+
+	return httputil.JSON(w, GetAllResponse{}.FromEntity(apiKeys, pagination))
+}
+
+func (h *Handler) revoke(w http.ResponseWriter, r *http.Request) error {
+	auth, _ := handlers.AuthFromContext(r.Context())
+	req, err := RevokeRequest{ID: chi.URLParam(r, "id"), UserID: auth.UserID}.ToUseCase()
+	if err != nil {
+		return httputil.Err400(w, err, "Invalid parameters", err)
+	}
+
+	if _, errUC := h.apiKeyUseCase.Revoke(req); errUC != nil {
+		return errUC
+	}
+
+	return httputil.NoContent(w)
+}
@@ -0,0 +1,98 @@
+package audit_log
+
+import (
+	"encoding/json"
+	"go-clean-api/pkg/domain/entities"
+	"go-clean-api/pkg/domain/repositories"
+	"go-clean-api/pkg/domain/usecases"
+	vo "go-clean-api/pkg/domain/value_objects"
+)
+
+type AuditLogResponse struct {
+	ID         string          `json:"id" xml:"id"`
+	ActorID    string          `json:"actor_id,omitempty" xml:"actor_id,omitempty"`
+	Action     string          `json:"action" xml:"action"`
+	TargetType string          `json:"target_type" xml:"target_type"`
+	TargetID   string          `json:"target_id" xml:"target_id"`
+	Before     json.RawMessage `json:"before,omitempty" xml:"-"`
+	After      json.RawMessage `json:"after,omitempty" xml:"-"`
+	IP         string          `json:"ip" xml:"ip"`
+	RequestID  string          `json:"request_id" xml:"request_id"`
+	CreatedAt  string          `json:"created_at" xml:"created_at"`
+}
+
+//
+// ======== Get all ========
+//
+
+type GetAllRequest struct {
+	Page       string
+	Size       string
+	ActorID    string
+	Action     string
+	TargetType string
+	TargetID   string
+	From       string // RFC3339
+	To         string // RFC3339
+}
+
+func (r GetAllRequest) ToUseCase() (usecases.GetAllAuditLogsRequest, error) {
+	filters := repositories.AuditLogFilters{
+		ActorID:    r.ActorID,
+		Action:     entities.AuditAction(r.Action),
+		TargetType: entities.AuditTargetType(r.TargetType),
+		TargetID:   r.TargetID,
+	}
+
+	if r.From != "" {
+		from, err := vo.ParseRFC3339(r.From, nil)
+		if err != nil {
+			return usecases.GetAllAuditLogsRequest{}, err
+		}
+		filters.From = &from
+	}
+
+	if r.To != "" {
+		to, err := vo.ParseRFC3339(r.To, nil)
+		if err != nil {
+			return usecases.GetAllAuditLogsRequest{}, err
+		}
+		filters.To = &to
+	}
+
+	return usecases.GetAllAuditLogsRequest{
+		Pagination: vo.PaginationFromQuery(r.Page, r.Size, ""),
+		Filters:    filters,
+	}, nil
+}
+
+type GetAllResponse struct {
+	Data  []AuditLogResponse `json:"data" xml:"data"`
+	Page  int                `json:"page" xml:"page"`
+	Size  int                `json:"size" xml:"size"`
+	Total int64              `json:"total" xml:"total"`
+}
+
+func (r GetAllResponse) FromEntity(res usecases.GetAllAuditLogsResponse, pagination vo.Pagination) GetAllResponse {
+	r.Data = make([]AuditLogResponse, len(res.Data))
+	for i, auditLog := range res.Data {
+		r.Data[i] = AuditLogResponse{
+			ID:         auditLog.ID.String(),
+			ActorID:    auditLog.ActorID,
+			Action:     string(auditLog.Action),
+			TargetType: string(auditLog.TargetType),
+			TargetID:   auditLog.TargetID,
+			Before:     auditLog.Before,
+			After:      auditLog.After,
+			IP:         auditLog.IP,
+			RequestID:  auditLog.RequestID,
+			CreatedAt:  auditLog.CreatedAt.RFC3339(),
+		}
+	}
+
+	r.Total = res.Total
+	r.Page = pagination.Page()
+	r.Size = pagination.Size()
+
+	return r
+}
@@ -0,0 +1,58 @@
+package audit_log
+
+import (
+	"go-clean-api/pkg/domain/usecases"
+	"go-clean-api/pkg/infrastructure/chi_router/handlers"
+	"go-clean-api/pkg/infrastructure/chi_router/httputil"
+	"go-clean-api/pkg/infrastructure/logger"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Handler handles audit log requests
+type Handler struct {
+	router          chi.Router
+	auditLogUseCase usecases.AuditLog
+	logger          logger.CustomLogger
+}
+
+// NewHandler returns a new Handler
+func NewHandler(r chi.Router, l logger.CustomLogger, auditLogUseCase usecases.AuditLog) Handler {
+	return Handler{
+		router:          r,
+		auditLogUseCase: auditLogUseCase,
+		logger:          l,
+	}
+}
+
+// PrivateRoutes adds audit logs private routes
+func (h *Handler) PrivateRoutes() {
+	h.router.Get("/", handlers.WrapError(h.getAll, h.logger))
+}
+
+func (h *Handler) getAll(w http.ResponseWriter, r *http.Request) error {
+	q := r.URL.Query()
+	req, err := GetAllRequest{
+		Page:       q.Get("page"),
+		Size:       q.Get("size"),
+		ActorID:    q.Get("actor_id"),
+		Action:     q.Get("action"),
+		TargetType: q.Get("target_type"),
+		TargetID:   q.Get("target_id"),
+		From:       q.Get("from"),
+		To:         q.Get("to"),
+	}.ToUseCase()
+	if err != nil {
+		return httputil.Err400(w, err, "Invalid parameters", err.Error())
+	}
+
+	auditLogs, errUC := h.auditLogUseCase.GetAll(req)
+	if errUC != nil {
+		return errUC
+	}
+
+	res := GetAllResponse{}.FromEntity(auditLogs, req.Pagination)
+
+	return httputil.JSON(w, res)
+}
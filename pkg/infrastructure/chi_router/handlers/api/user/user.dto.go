@@ -1,6 +1,7 @@
 package user
 
 import (
+	"go-clean-api/pkg/domain/entities"
 	"go-clean-api/pkg/domain/usecases"
 	vo "go-clean-api/pkg/domain/value_objects"
 )
@@ -13,6 +14,26 @@ type UserResponse struct {
 	CreatedAt string `json:"created_at" xml:"created_at"`
 	UpdatedAt string `json:"updated_at" xml:"updated_at"`
 	DeletedAt string `json:"deleted_at,omitempty" xml:"deleted_at,omitempty"`
+	Version   int    `json:"version" xml:"version"`
+}
+
+// NewUserResponse converts a user entity to response
+func NewUserResponse(user entities.User) UserResponse {
+	deletedAt := ""
+	if user.DeletedAt != nil {
+		deletedAt = user.DeletedAt.RFC3339()
+	}
+
+	return UserResponse{
+		ID:        user.ID.String(),
+		Email:     user.Email.Value(),
+		Lastname:  user.Lastname,
+		Firstname: user.Firstname,
+		CreatedAt: user.CreatedAt.RFC3339(),
+		UpdatedAt: user.UpdatedAt.RFC3339(),
+		DeletedAt: deletedAt,
+		Version:   user.Version,
+	}
 }
 
 //
@@ -20,8 +41,8 @@ type UserResponse struct {
 //
 
 type GetAccessTokenRequest struct {
-	Email    string `json:"email" xml:"email" form:"email"`
-	Password string `json:"password" xml:"password" form:"password"`
+	Email    string `json:"email" xml:"email" form:"email" validate:"required,email"`
+	Password string `json:"password" xml:"password" form:"password" validate:"required"`
 }
 
 // TODO: Add tests
@@ -52,10 +73,10 @@ type GetAccessTokenResponse struct {
 //
 
 type CreateRequest struct {
-	Email     string `json:"email" xml:"email" form:"email"`
-	Password  string `json:"password" xml:"password" form:"password"`
-	Lastname  string `json:"lastname" xml:"lastname" form:"lastname"`
-	Firstname string `json:"firstname" xml:"firstname" form:"firstname"`
+	Email     string `json:"email" xml:"email" form:"email" validate:"required,email,max=127"`
+	Password  string `json:"password" xml:"password" form:"password" validate:"required"`
+	Lastname  string `json:"lastname" xml:"lastname" form:"lastname" validate:"required,max=63"`
+	Firstname string `json:"firstname" xml:"firstname" form:"firstname" validate:"required,max=63"`
 }
 
 // TODO: Add tests
@@ -112,18 +133,7 @@ type GetByIDResponse struct {
 
 // TODO: Add tests
 func (r GetByIDResponse) FromEntity(res usecases.GetUserByIDResponse) GetByIDResponse {
-	deletedAt := ""
-	if res.DeletedAt != nil {
-		deletedAt = res.DeletedAt.RFC3339()
-	}
-
-	r.ID = res.ID.String()
-	r.Email = res.Email.Value()
-	r.Lastname = res.Lastname
-	r.Firstname = res.Firstname
-	r.CreatedAt = res.CreatedAt.RFC3339()
-	r.UpdatedAt = res.UpdatedAt.RFC3339()
-	r.DeletedAt = deletedAt
+	r.UserResponse = NewUserResponse(res.User)
 
 	return r
 }
@@ -139,24 +149,18 @@ type GetAllResponse struct {
 	Total int64          `json:"total" xml:"total"`
 }
 
+// GetAllMeta is the pagination sent with the streamed users of GetAllResponse
+type GetAllMeta struct {
+	Page  int   `json:"page"`
+	Size  int   `json:"size"`
+	Total int64 `json:"total"`
+}
+
 // TODO: Add tests
 func (r GetAllResponse) FromEntity(res usecases.GetAllUsersResponse, pagination vo.Pagination) GetAllResponse {
 	r.Data = make([]UserResponse, len(res.Data))
 	for i, user := range res.Data {
-		deletedAt := ""
-		if user.DeletedAt != nil {
-			deletedAt = user.DeletedAt.RFC3339()
-		}
-
-		r.Data[i] = UserResponse{
-			ID:        user.ID.String(),
-			Email:     user.Email.Value(),
-			Lastname:  user.Lastname,
-			Firstname: user.Firstname,
-			CreatedAt: user.CreatedAt.RFC3339(),
-			UpdatedAt: user.UpdatedAt.RFC3339(),
-			DeletedAt: deletedAt,
-		}
+		r.Data[i] = NewUserResponse(user)
 	}
 
 	r.Total = res.Total
@@ -171,7 +175,8 @@ func (r GetAllResponse) FromEntity(res usecases.GetAllUsersResponse, pagination
 //
 
 type DeleteRestoreRequest struct {
-	ID string
+	ID       string
+	Versions []int
 }
 
 func (r DeleteRestoreRequest) ToUseCase() (usecases.DeleteRestoreUserRequest, error) {
@@ -181,6 +186,7 @@ func (r DeleteRestoreRequest) ToUseCase() (usecases.DeleteRestoreUserRequest, er
 	}
 
 	return usecases.DeleteRestoreUserRequest{
-		ID: id,
+		ID:       id,
+		Versions: r.Versions,
 	}, nil
 }
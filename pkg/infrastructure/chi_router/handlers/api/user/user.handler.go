@@ -1,15 +1,16 @@
 package user
 
 import (
-	"encoding/json"
 	"errors"
-	domainerr "go-clean-api/pkg/domain/errors"
+	"go-clean-api/pkg/domain/entities"
 	"go-clean-api/pkg/domain/usecases"
 	vo "go-clean-api/pkg/domain/value_objects"
 	"go-clean-api/pkg/infrastructure/chi_router/handlers"
 	"go-clean-api/pkg/infrastructure/chi_router/httputil"
 	"go-clean-api/pkg/infrastructure/logger"
 	"net/http"
+	"slices"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
 )
@@ -46,9 +47,9 @@ func (h *Handler) PrivateRoutes() {
 }
 
 func (u *Handler) token(w http.ResponseWriter, r *http.Request) error {
-	var body GetAccessTokenRequest
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		return httputil.Err400(w, err, "Error when decoding the body", nil)
+	body, err := httputil.Bind[GetAccessTokenRequest](w, r)
+	if err != nil {
+		return err
 	}
 
 	req, err := body.ToUseCase()
@@ -58,15 +59,7 @@ func (u *Handler) token(w http.ResponseWriter, r *http.Request) error {
 
 	resUC, errUC := u.userUseCase.GetAccessToken(req)
 	if errUC != nil {
-		if errors.Is(errUC, domainerr.ErrNotFound) || errors.Is(errUC, usecases.ErrInvalidPassword) {
-			return httputil.Err401(w, errUC, "Unauthorized", nil)
-		} else if errors.Is(errUC, usecases.ErrAccessTokenCreation) {
-			return httputil.Err500(w, errUC, "Internal server error", "Error during token generation")
-		} else if errors.Is(errUC, domainerr.ErrDatabase) {
-			return httputil.Err500(w, errUC, "Internal server error", "Error during authentication")
-		} else {
-			return httputil.Err500(w, errUC, "Internal server error", "Unknown error")
-		}
+		return errUC
 	}
 
 	res := GetAccessTokenResponse{
@@ -78,19 +71,24 @@ func (u *Handler) token(w http.ResponseWriter, r *http.Request) error {
 }
 
 func (u *Handler) register(w http.ResponseWriter, r *http.Request) error {
-	var body CreateRequest
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		return httputil.Err400(w, err, "Error when decoding the body", nil)
+	body, err := httputil.Bind[CreateRequest](w, r)
+	if err != nil {
+		return err
 	}
 
 	req, err := body.ToUseCase()
 	if err != nil {
 		return httputil.Err400(w, err, "Invalid parameters", err)
 	}
+	req.Actor = handlers.Actor(r)
+	req.Locale = httputil.Locale(r)
 
 	resUC, errUC := u.userUseCase.Create(req)
 	if errUC != nil {
-		return httputil.Err500(w, errUC, "Internal server error", "Error during user creation")
+		if errors.Is(errUC, usecases.ErrWeakPassword) {
+			httputil.SetContentLanguage(w, req.Locale)
+		}
+		return errUC
 	}
 
 	res := CreateResponse{
@@ -118,14 +116,14 @@ func (u *Handler) getByID(w http.ResponseWriter, r *http.Request) error {
 
 	resUC, errUC := u.userUseCase.GetByID(req)
 	if errUC != nil {
-		if errors.Is(errUC, domainerr.ErrNotFound) {
-			return httputil.Err404(w, errUC, "No user found", nil)
-		} else if errors.Is(errUC, domainerr.ErrDatabase) {
-			return httputil.Err500(w, errUC, "Internal server error", "Error when getting user")
-		} else {
-			return httputil.Err500(w, errUC, "Internal server error", "Unknown error")
-		}
+		return errUC
+	}
+
+	etag := httputil.ETagWithFormat(httputil.ETag(strconv.Itoa(resUC.Version)), httputil.ResponseFormat(w))
+	if httputil.IsNotModified(r, etag) {
+		return httputil.NotModified(w, etag)
 	}
+	w.Header().Set(httputil.HeaderETag, etag)
 
 	res := GetByIDResponse{}.FromEntity(resUC)
 
@@ -137,17 +135,15 @@ func (u *Handler) GetAll(w http.ResponseWriter, r *http.Request) error {
 	s := r.URL.Query().Get("size")
 	pagination := vo.PaginationFromQuery(p, s, "")
 
-	users, errUC := u.userUseCase.GetAll(usecases.GetAllUsersRequest{
+	users, errUC := u.userUseCase.Stream(usecases.StreamUsersRequest{
 		Pagination: pagination,
 		Deleted:    false,
 	})
 	if errUC != nil {
-		return httputil.Err500(w, errUC, "Internal server error", "Error when getting users")
+		return errUC
 	}
 
-	res := GetAllResponse{}.FromEntity(users, pagination)
-
-	return httputil.JSON(w, res)
+	return streamUsers(w, users, pagination)
 }
 
 func (u *Handler) GetAllDeleted(w http.ResponseWriter, r *http.Request) error {
@@ -155,17 +151,15 @@ func (u *Handler) GetAllDeleted(w http.ResponseWriter, r *http.Request) error {
 	s := r.URL.Query().Get("size")
 	pagination := vo.PaginationFromQuery(p, s, "")
 
-	users, errUC := u.userUseCase.GetAll(usecases.GetAllUsersRequest{
+	users, errUC := u.userUseCase.Stream(usecases.StreamUsersRequest{
 		Pagination: pagination,
 		Deleted:    true,
 	})
 	if errUC != nil {
-		return httputil.Err500(w, errUC, "Internal server error", "Error when getting deleted users")
+		return errUC
 	}
 
-	res := GetAllResponse{}.FromEntity(users, pagination)
-
-	return httputil.JSON(w, res)
+	return streamUsers(w, users, pagination)
 }
 
 func (u *Handler) delete(w http.ResponseWriter, r *http.Request) error {
@@ -174,20 +168,20 @@ func (u *Handler) delete(w http.ResponseWriter, r *http.Request) error {
 		return httputil.Err400(w, nil, "ID is required", nil)
 	}
 
-	req, err := DeleteRestoreRequest{ID: id}.ToUseCase()
+	versions, ok := ifMatchVersions(r)
+	if !ok {
+		return usecases.ErrUserVersionMismatch.Wrap("user_handler:delete", nil).With("id", id)
+	}
+
+	req, err := DeleteRestoreRequest{ID: id, Versions: versions}.ToUseCase()
 	if err != nil {
 		return httputil.Err400(w, err, "Invalid parameters", err)
 	}
+	req.Actor = handlers.Actor(r)
 
 	_, errUC := u.userUseCase.Delete(req)
 	if errUC != nil {
-		if errors.Is(errUC, domainerr.ErrNotFound) {
-			return httputil.Err404(w, errUC, "No user found", nil)
-		} else if errors.Is(errUC, domainerr.ErrDatabase) {
-			return httputil.Err500(w, errUC, "Internal server error", "Error when deleting user")
-		} else {
-			return httputil.Err500(w, errUC, "Internal server error", "Unknown error")
-		}
+		return errUC
 	}
 
 	return httputil.NoContent(w)
@@ -199,21 +193,79 @@ func (u *Handler) restore(w http.ResponseWriter, r *http.Request) error {
 		return httputil.Err400(w, nil, "ID is required", nil)
 	}
 
-	req, err := DeleteRestoreRequest{ID: id}.ToUseCase()
+	versions, ok := ifMatchVersions(r)
+	if !ok {
+		return usecases.ErrUserVersionMismatch.Wrap("user_handler:restore", nil).With("id", id)
+	}
+
+	req, err := DeleteRestoreRequest{ID: id, Versions: versions}.ToUseCase()
 	if err != nil {
 		return httputil.Err400(w, err, "Invalid parameters", err)
 	}
+	req.Actor = handlers.Actor(r)
 
 	_, errUC := u.userUseCase.Restore(req)
 	if errUC != nil {
-		if errors.Is(errUC, domainerr.ErrNotFound) {
-			return httputil.Err404(w, errUC, "No user found", nil)
-		} else if errors.Is(errUC, domainerr.ErrDatabase) {
-			return httputil.Err500(w, errUC, "Internal server error", "Error when restoring user")
-		} else {
-			return httputil.Err500(w, errUC, "Internal server error", "Unknown error")
-		}
+		return errUC
 	}
 
 	return httputil.NoContent(w)
 }
+
+// streamUsers sends a page of users element by element, as they are read from the database
+func streamUsers(w http.ResponseWriter, users usecases.StreamUsersResponse, pagination vo.Pagination) error {
+	var errUsers error
+	items := func(yield func(entities.User) bool) {
+		for user, err := range users.Users {
+			if err != nil {
+				errUsers = err
+				return
+			}
+			if !yield(user) {
+				return
+			}
+		}
+	}
+
+	return httputil.Stream(w, http.StatusOK, httputil.StreamedList[UserResponse]{
+		Meta: GetAllMeta{Page: pagination.Page(), Size: pagination.Size(), Total: users.Total},
+		Items: func(yield func(UserResponse) bool) {
+			for user := range items {
+				if !yield(NewUserResponse(user)) {
+					return
+				}
+			}
+		},
+		Whole: func() any {
+			return GetAllResponse{}.FromEntity(usecases.GetAllUsersResponse{
+				Data:  slices.Collect(items),
+				Total: users.Total,
+			}, pagination)
+		},
+		Err: func() error {
+			return errUsers
+		},
+	})
+}
+
+// ifMatchVersions returns the user versions of the If-Match header (nil without condition).
+// It returns false if the header cannot match any version.
+func ifMatchVersions(r *http.Request) ([]int, bool) {
+	etags := httputil.ParseETags(r.Header.Get(httputil.HeaderIfMatch))
+	if len(etags) == 0 || etags[0] == "*" {
+		return nil, true
+	}
+
+	versions := make([]int, 0, len(etags))
+	for _, etag := range etags {
+		value, ok := httputil.StrongETagValue(etag)
+		if !ok {
+			continue
+		}
+		if version, err := strconv.Atoi(value); err == nil {
+			versions = append(versions, version)
+		}
+	}
+
+	return versions, len(versions) > 0
+}
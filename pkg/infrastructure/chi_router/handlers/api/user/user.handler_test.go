@@ -0,0 +1,39 @@
+package user
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIfMatchVersions(t *testing.T) {
+	tests := []struct {
+		name           string
+		ifMatch        string
+		wantedVersions []int
+		wantedOk       bool
+	}{
+		{name: "No header", ifMatch: "", wantedVersions: nil, wantedOk: true},
+		{name: "Wildcard", ifMatch: "*", wantedVersions: nil, wantedOk: true},
+		{name: "One version", ifMatch: `"3"`, wantedVersions: []int{3}, wantedOk: true},
+		{name: "Several versions", ifMatch: `"3", W/"4", "5"`, wantedVersions: []int{3, 5}, wantedOk: true},
+		{name: "Version in a format", ifMatch: `"3-xml-gzip"`, wantedVersions: []int{3}, wantedOk: true},
+		{name: "Weak ETag", ifMatch: `W/"3"`, wantedVersions: []int{}, wantedOk: false},
+		{name: "Invalid ETag", ifMatch: `"abc"`, wantedVersions: []int{}, wantedOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodDelete, "/", nil)
+			if tt.ifMatch != "" {
+				r.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			versions, ok := ifMatchVersions(r)
+			assert.Equal(t, tt.wantedOk, ok)
+			assert.Equal(t, tt.wantedVersions, versions)
+		})
+	}
+}
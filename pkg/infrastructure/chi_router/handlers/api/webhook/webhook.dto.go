@@ -0,0 +1,175 @@
+package webhook
+
+import (
+	"go-clean-api/pkg/domain/entities"
+	"go-clean-api/pkg/domain/usecases"
+	vo "go-clean-api/pkg/domain/value_objects"
+)
+
+type WebhookResponse struct {
+	ID                  string   `json:"id" xml:"id"`
+	URL                 string   `json:"url" xml:"url"`
+	Events              []string `json:"events" xml:"events"`
+	Enabled             bool     `json:"enabled" xml:"enabled"`
+	ConsecutiveFailures int      `json:"consecutive_failures" xml:"consecutive_failures"`
+	CreatedAt           string   `json:"created_at" xml:"created_at"`
+	UpdatedAt           string   `json:"updated_at" xml:"updated_at"`
+	DisabledAt          string   `json:"disabled_at,omitempty" xml:"disabled_at,omitempty"`
+}
+
+func webhookResponseFromEntity(webhook entities.Webhook) WebhookResponse {
+	disabledAt := ""
+	if webhook.DisabledAt != nil {
+		disabledAt = webhook.DisabledAt.RFC3339()
+	}
+
+	return WebhookResponse{
+		ID:                  webhook.ID.String(),
+		URL:                 webhook.URL,
+		Events:              webhook.Events,
+		Enabled:             webhook.Enabled,
+		ConsecutiveFailures: webhook.ConsecutiveFailures,
+		CreatedAt:           webhook.CreatedAt.RFC3339(),
+		UpdatedAt:           webhook.UpdatedAt.RFC3339(),
+		DisabledAt:          disabledAt,
+	}
+}
+
+type DeliveryResponse struct {
+	ID         string `json:"id" xml:"id"`
+	EventID    string `json:"event_id" xml:"event_id"`
+	EventName  string `json:"event_name" xml:"event_name"`
+	Attempt    int    `json:"attempt" xml:"attempt"`
+	StatusCode int    `json:"status_code,omitempty" xml:"status_code,omitempty"`
+	Success    bool   `json:"success" xml:"success"`
+	Error      string `json:"error,omitempty" xml:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms" xml:"duration_ms"`
+	CreatedAt  string `json:"created_at" xml:"created_at"`
+}
+
+func deliveryResponseFromEntity(delivery entities.WebhookDelivery) DeliveryResponse {
+	return DeliveryResponse{
+		ID:         delivery.ID.String(),
+		EventID:    delivery.EventID,
+		EventName:  delivery.EventName,
+		Attempt:    delivery.Attempt,
+		StatusCode: delivery.StatusCode,
+		Success:    delivery.Success,
+		Error:      delivery.Error,
+		DurationMs: delivery.Duration.Milliseconds(),
+		CreatedAt:  delivery.CreatedAt.RFC3339(),
+	}
+}
+
+//
+// ======== Create ========
+//
+
+type CreateRequest struct {
+	URL    string   `json:"url" xml:"url" form:"url" validate:"required,http_url,max=2048"`
+	Secret string   `json:"secret,omitempty" xml:"secret,omitempty" form:"secret" validate:"omitempty,min=16,max=255"`
+	Events []string `json:"events" xml:"events" form:"events" validate:"required,min=1,dive,required"`
+}
+
+func (r CreateRequest) ToUseCase() (usecases.CreateWebhookRequest, error) {
+	return usecases.CreateWebhookRequest{
+		URL:    r.URL,
+		Secret: r.Secret,
+		Events: r.Events,
+	}, nil
+}
+
+// CreateResponse is the only response containing the secret used to sign the requests
+type CreateResponse struct {
+	WebhookResponse
+	Secret string `json:"secret" xml:"secret"`
+}
+
+func (r CreateResponse) FromEntity(res usecases.CreateWebhookResponse) CreateResponse {
+	r.WebhookResponse = webhookResponseFromEntity(res.Webhook)
+	r.Secret = res.Secret
+
+	return r
+}
+
+//
+// ======== Get by ID / Delete / Enable ========
+//
+
+type IDRequest struct {
+	ID string
+}
+
+func (r IDRequest) ToID() (entities.WebhookID, error) {
+	return vo.NewIDFrom(r.ID)
+}
+
+//
+// ======== Get all ========
+//
+
+type GetAllResponse struct {
+	Data  []WebhookResponse `json:"data" xml:"data"`
+	Page  int               `json:"page" xml:"page"`
+	Size  int               `json:"size" xml:"size"`
+	Total int64             `json:"total" xml:"total"`
+}
+
+func (r GetAllResponse) FromEntity(res usecases.GetAllWebhooksResponse, pagination vo.Pagination) GetAllResponse {
+	r.Data = make([]WebhookResponse, len(res.Data))
+	for i, webhook := range res.Data {
+		r.Data[i] = webhookResponseFromEntity(webhook)
+	}
+
+	r.Total = res.Total
+	r.Page = pagination.Page()
+	r.Size = pagination.Size()
+
+	return r
+}
+
+//
+// ======== Deliveries ========
+//
+
+type GetDeliveriesResponse struct {
+	Data  []DeliveryResponse `json:"data" xml:"data"`
+	Page  int                `json:"page" xml:"page"`
+	Size  int                `json:"size" xml:"size"`
+	Total int64              `json:"total" xml:"total"`
+}
+
+func (r GetDeliveriesResponse) FromEntity(res usecases.GetWebhookDeliveriesResponse, pagination vo.Pagination) GetDeliveriesResponse {
+	r.Data = make([]DeliveryResponse, len(res.Data))
+	for i, delivery := range res.Data {
+		r.Data[i] = deliveryResponseFromEntity(delivery)
+	}
+
+	r.Total = res.Total
+	r.Page = pagination.Page()
+	r.Size = pagination.Size()
+
+	return r
+}
+
+type RedeliverRequest struct {
+	WebhookID  string
+	DeliveryID string
+}
+
+func (r RedeliverRequest) ToUseCase() (usecases.RedeliverWebhookRequest, error) {
+	webhookID, err := vo.NewIDFrom(r.WebhookID)
+	if err != nil {
+		return usecases.RedeliverWebhookRequest{}, err
+	}
+
+	deliveryID, err := vo.NewIDFrom(r.DeliveryID)
+	if err != nil {
+		return usecases.RedeliverWebhookRequest{}, err
+	}
+
+	return usecases.RedeliverWebhookRequest{
+		WebhookID:  webhookID,
+		DeliveryID: deliveryID,
+	}, nil
+}
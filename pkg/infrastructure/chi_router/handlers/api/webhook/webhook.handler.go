@@ -0,0 +1,146 @@
+package webhook
+
+import (
+	"go-clean-api/pkg/domain/usecases"
+	vo "go-clean-api/pkg/domain/value_objects"
+	"go-clean-api/pkg/infrastructure/chi_router/handlers"
+	"go-clean-api/pkg/infrastructure/chi_router/httputil"
+	"go-clean-api/pkg/infrastructure/logger"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Handler handles webhook requests
+type Handler struct {
+	router         chi.Router
+	webhookUseCase usecases.Webhook
+	logger         logger.CustomLogger
+}
+
+// NewHandler returns a new Handler
+func NewHandler(r chi.Router, l logger.CustomLogger, webhookUseCase usecases.Webhook) Handler {
+	return Handler{
+		router:         r,
+		webhookUseCase: webhookUseCase,
+		logger:         l,
+	}
+}
+
+// PrivateRoutes adds webhooks private routes
+func (h *Handler) PrivateRoutes() {
+	h.router.Post("/", handlers.WrapError(h.create, h.logger))
+	h.router.Get("/", handlers.WrapError(h.getAll, h.logger))
+	h.router.Get("/{id}", handlers.WrapError(h.getByID, h.logger))
+	h.router.Delete("/{id}", handlers.WrapError(h.delete, h.logger))
+	h.router.Patch("/{id}/enable", handlers.WrapError(h.enable, h.logger))
+	h.router.Get("/{id}/deliveries", handlers.WrapError(h.getDeliveries, h.logger))
+	h.router.Post("/{id}/deliveries/{delivery_id}/redeliver", handlers.WrapError(h.redeliver, h.logger))
+}
+
+func (h *Handler) create(w http.ResponseWriter, r *http.Request) error {
+	body, err := httputil.Bind[CreateRequest](w, r)
+	if err != nil {
+		return err
+	}
+
+	req, err := body.ToUseCase()
+	if err != nil {
+		return httputil.Err400(w, err, "Invalid parameters", err)
+	}
+
+	resUC, errUC := h.webhookUseCase.Create(req)
+	if errUC != nil {
+		return errUC
+	}
+
+	return httputil.Created(w, CreateResponse{}.FromEntity(resUC))
+}
+
+func (h *Handler) getAll(w http.ResponseWriter, r *http.Request) error {
+	pagination := vo.PaginationFromQuery(r.URL.Query().Get("page"), r.URL.Query().Get("size"), "")
+
+	webhooks, errUC := h.webhookUseCase.GetAll(usecases.GetAllWebhooksRequest{Pagination: pagination})
+	if errUC != nil {
+		return errUC
+	}
+
+	return httputil.JSON(w, GetAllResponse{}.FromEntity(webhooks, pagination))
+}
+
+func (h *Handler) getByID(w http.ResponseWriter, r *http.Request) error {
+	id, err := IDRequest{ID: chi.URLParam(r, "id")}.ToID()
+	if err != nil {
+		return httputil.Err400(w, err, "Invalid parameters", err)
+	}
+
+	resUC, errUC := h.webhookUseCase.GetByID(usecases.GetWebhookByIDRequest{ID: id})
+	if errUC != nil {
+		return errUC
+	}
+
+	return httputil.JSON(w, webhookResponseFromEntity(resUC.Webhook))
+}
+
+func (h *Handler) delete(w http.ResponseWriter, r *http.Request) error {
+	id, err := IDRequest{ID: chi.URLParam(r, "id")}.ToID()
+	if err != nil {
+		return httputil.Err400(w, err, "Invalid parameters", err)
+	}
+
+	if _, errUC := h.webhookUseCase.Delete(usecases.DeleteWebhookRequest{ID: id}); errUC != nil {
+		return errUC
+	}
+
+	return httputil.NoContent(w)
+}
+
+func (h *Handler) enable(w http.ResponseWriter, r *http.Request) error {
+	id, err := IDRequest{ID: chi.URLParam(r, "id")}.ToID()
+	if err != nil {
+		return httputil.Err400(w, err, "Invalid parameters", err)
+	}
+
+	resUC, errUC := h.webhookUseCase.Enable(usecases.EnableWebhookRequest{ID: id})
+	if errUC != nil {
+		return errUC
+	}
+
+	return httputil.JSON(w, webhookResponseFromEntity(resUC.Webhook))
+}
+
+func (h *Handler) getDeliveries(w http.ResponseWriter, r *http.Request) error {
+	id, err := IDRequest{ID: chi.URLParam(r, "id")}.ToID()
+	if err != nil {
+		return httputil.Err400(w, err, "Invalid parameters", err)
+	}
+	pagination := vo.PaginationFromQuery(r.URL.Query().Get("page"), r.URL.Query().Get("size"), "")
+
+	deliveries, errUC := h.webhookUseCase.GetDeliveries(usecases.GetWebhookDeliveriesRequest{
+		WebhookID:  id,
+		Pagination: pagination,
+	})
+	if errUC != nil {
+		return errUC
+	}
+
+	return httputil.JSON(w, GetDeliveriesResponse{}.FromEntity(deliveries, pagination))
+}
+
+func (h *Handler) redeliver(w http.ResponseWriter, r *http.Request) error {
+	req, err := RedeliverRequest{
+		WebhookID:  chi.URLParam(r, "id"),
+		DeliveryID: chi.URLParam(r, "delivery_id"),
+	}.ToUseCase()
+	if err != nil {
+		return httputil.Err400(w, err, "Invalid parameters", err)
+	}
+	req.RequestID = handlers.Actor(r).RequestID
+
+	resUC, errUC := h.webhookUseCase.Redeliver(req)
+	if errUC != nil {
+		return errUC
+	}
+
+	return httputil.JSON(w, deliveryResponseFromEntity(resUC.WebhookDelivery))
+}
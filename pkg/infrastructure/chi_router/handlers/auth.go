@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"context"
+	"go-clean-api/pkg/infrastructure/logger"
+	"slices"
+)
+
+// AuthKey is the key used to store the authenticated user in the context
+type AuthKey string
+
+// Auth represents the authenticated user of a request
+type Auth struct {
+	// ID of the authenticated user
+	UserID string
+
+	// ID of the API key, empty if the user is authenticated with a JWT
+	APIKeyID string
+
+	// Scopes of the API key, empty for a JWT or an API key without scope
+	Scopes []string
+}
+
+// HasScope returns true if the user is granted the scope.
+// Only an API key with scopes can be restricted.
+func (a Auth) HasScope(scope string) bool {
+	return a.APIKeyID == "" || len(a.Scopes) == 0 || slices.Contains(a.Scopes, scope)
+}
+
+// WithAuth returns a copy of the context with the authenticated user.
+// The user ID (and the API key ID) are added to the request-scoped logger.
+func WithAuth(ctx context.Context, auth Auth) context.Context {
+	fields := logger.Fields{logger.String("user_id", auth.UserID)}
+	if auth.APIKeyID != "" {
+		fields = append(fields, logger.String("api_key_id", auth.APIKeyID))
+	}
+	ctx = logger.WithContextFields(ctx, fields)
+
+	return context.WithValue(ctx, AuthKey("auth"), auth)
+}
+
+// AuthFromContext returns the authenticated user stored in the context
+func AuthFromContext(ctx context.Context) (Auth, bool) {
+	auth, ok := ctx.Value(AuthKey("auth")).(Auth)
+	return auth, ok
+}
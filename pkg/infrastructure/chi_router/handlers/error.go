@@ -1,34 +1,107 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
+	domainerr "go-clean-api/pkg/domain/errors"
+	"go-clean-api/pkg/infrastructure/chi_router/httputil"
 	"go-clean-api/pkg/infrastructure/logger"
 	"net/http"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 )
 
 // RequestIDKey is the key used to store the request ID in the context
 type RequestIDKey string
 
-// WrapError wraps the handlers error and logs it
+// kindStatuses maps the kinds of domain errors to HTTP status codes.
+// Validation errors return 422 like the invalid request bodies (see httputil.Bind).
+var kindStatuses = map[domainerr.Kind]int{
+	domainerr.KindInternal:           http.StatusInternalServerError,
+	domainerr.KindNotFound:           http.StatusNotFound,
+	domainerr.KindConflict:           http.StatusConflict,
+	domainerr.KindValidation:         http.StatusUnprocessableEntity,
+	domainerr.KindUnauthorized:       http.StatusUnauthorized,
+	domainerr.KindForbidden:          http.StatusForbidden,
+	domainerr.KindPreconditionFailed: http.StatusPreconditionFailed,
+	domainerr.KindUnprocessable:      http.StatusUnprocessableEntity,
+}
+
+// WrapError wraps the handlers error and logs it.
+// An error returned by a handler which has not sent a response is sent to the client (see NewHTTPError).
+// A truncated streamed response (see httputil.ErrResponseTruncated) is aborted with http.ErrAbortHandler.
+// The route pattern is added to the request-scoped logger passed to the handler in the context (see logger.FromContext).
 func WrapError(f func(w http.ResponseWriter, r *http.Request) error, l logger.CustomLogger) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
-		requestId := fmt.Sprintf("%s", r.Context().Value(RequestIDKey("request_id")))
+		rl := requestLogger(r, l)
+		r = r.WithContext(logger.NewContext(r.Context(), rl))
 		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
 
 		err := f(ww, r)
 
 		if err != nil {
-			fields := logger.Fields{
-				logger.NewField("request_id", "string", requestId),
+			// A truncated response must not end normally, the connection is closed instead
+			if errors.Is(err, httputil.ErrResponseTruncated) {
+				rl.Error(err.Error())
+				panic(http.ErrAbortHandler)
+			}
+
+			if ww.Status() == 0 {
+				SendError(ww, err)
 			}
 
 			if ww.Status() == http.StatusInternalServerError {
-				l.Error(err.Error(), fields)
+				rl.Error(err.Error())
 			} else {
-				l.Warn(err.Error(), fields)
+				rl.Warn(err.Error())
 			}
 		}
 	}
 }
+
+// requestLogger returns the request-scoped logger with the route pattern.
+// Without logger in the context, the request ID is added to l.
+func requestLogger(r *http.Request, l logger.CustomLogger) logger.CustomLogger {
+	rl := logger.FromContext(r.Context(), nil)
+	if rl == nil {
+		rl = l.With(logger.Fields{
+			logger.String("request_id", fmt.Sprintf("%s", r.Context().Value(RequestIDKey("request_id")))),
+		})
+	}
+
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		if route := rctx.RoutePattern(); route != "" {
+			rl = rl.With(logger.Fields{logger.String("route", route)})
+		}
+	}
+
+	return rl
+}
+
+// NewHTTPError maps an error to an HTTP error from the kind of its domain error.
+// The message and the context of internal errors are not sent to the client.
+func NewHTTPError(err error) *httputil.HTTPError {
+	var domainErr *domainerr.Error
+	if !errors.As(err, &domainErr) || domainErr.Kind == domainerr.KindInternal {
+		status := http.StatusInternalServerError
+		return httputil.NewHTTPError(status, domainerr.CodeInternal, http.StatusText(status), nil, err)
+	}
+
+	status, ok := kindStatuses[domainErr.Kind]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+
+	e := httputil.NewHTTPError(status, domainErr.Code, http.StatusText(status), domainErr.Message, err)
+	if len(domainErr.Context) > 0 {
+		e.Details = domainErr.Context
+	}
+
+	return e
+}
+
+// SendError sends an error to the client with the status matching its domain error.
+func SendError(w http.ResponseWriter, err error) error {
+	return NewHTTPError(err).SendError(w)
+}
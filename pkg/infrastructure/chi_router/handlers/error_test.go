@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	domainerr "go-clean-api/pkg/domain/errors"
+	"go-clean-api/pkg/infrastructure/chi_router/httputil"
+	"go-clean-api/pkg/infrastructure/logger"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWrapError(t *testing.T) {
+	errUserNotFound := domainerr.NewNotFound("user_not_found", "user not found")
+
+	tests := []struct {
+		name         string
+		err          error
+		wantedStatus int
+		wantedBody   string
+		wantedLevel  zapcore.Level
+	}{
+		{
+			name:         "Not found with context",
+			err:          errUserNotFound.Wrap("user_uc:GetByID", errors.New("no row")).With("id", "123"),
+			wantedStatus: http.StatusNotFound,
+			wantedBody:   `{"type":"urn:problem-type:user_not_found","title":"Not Found","status":404,"detail":"user not found","code":"user_not_found","details":{"id":"123"}}`,
+			wantedLevel:  zapcore.WarnLevel,
+		},
+		{
+			name:         "Conflict",
+			err:          domainerr.NewConflict("email_taken", "email already taken").Wrap("user_uc:Create", nil),
+			wantedStatus: http.StatusConflict,
+			wantedBody:   `{"type":"urn:problem-type:email_taken","title":"Conflict","status":409,"detail":"email already taken","code":"email_taken"}`,
+			wantedLevel:  zapcore.WarnLevel,
+		},
+		{
+			name:         "Precondition failed",
+			err:          domainerr.NewPreconditionFailed("user_version_mismatch", "user has been modified").Wrap("user_uc:Delete", nil),
+			wantedStatus: http.StatusPreconditionFailed,
+			wantedBody:   `{"type":"urn:problem-type:user_version_mismatch","title":"Precondition Failed","status":412,"detail":"user has been modified","code":"user_version_mismatch"}`,
+			wantedLevel:  zapcore.WarnLevel,
+		},
+		{
+			name:         "Validation",
+			err:          domainerr.NewValidation("weak_password", "password does not match the password policy").Wrap("user_uc:Create", nil),
+			wantedStatus: http.StatusUnprocessableEntity,
+			wantedBody:   `{"type":"urn:problem-type:weak_password","title":"Unprocessable Entity","status":422,"detail":"password does not match the password policy","code":"weak_password"}`,
+			wantedLevel:  zapcore.WarnLevel,
+		},
+		{
+			name:         "Unprocessable",
+			err:          domainerr.NewUnprocessable("idempotency_key_reused", "idempotency key already used for another request").Wrap("idempotency_uc:Begin", nil),
+			wantedStatus: http.StatusUnprocessableEntity,
+			wantedBody:   `{"type":"urn:problem-type:idempotency_key_reused","title":"Unprocessable Entity","status":422,"detail":"idempotency key already used for another request","code":"idempotency_key_reused"}`,
+			wantedLevel:  zapcore.WarnLevel,
+		},
+		{
+			name:         "Internal error details are hidden",
+			err:          domainerr.ErrDatabase.Wrap("user_uc:GetAll", errors.New("connection refused")),
+			wantedStatus: http.StatusInternalServerError,
+			wantedBody:   `{"type":"urn:problem-type:internal_error","title":"Internal Server Error","status":500,"code":"internal_error"}`,
+			wantedLevel:  zapcore.ErrorLevel,
+		},
+		{
+			name:         "Unknown error",
+			err:          errors.New("unknown"),
+			wantedStatus: http.StatusInternalServerError,
+			wantedBody:   `{"type":"urn:problem-type:internal_error","title":"Internal Server Error","status":500,"code":"internal_error"}`,
+			wantedLevel:  zapcore.ErrorLevel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := logger.NewMemoryLogger()
+			h := WrapError(func(w http.ResponseWriter, r *http.Request) error {
+				return tt.err
+			}, l)
+
+			rec := httptest.NewRecorder()
+			h(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			assert.Equal(t, tt.wantedStatus, rec.Code)
+			assert.Equal(t, httputil.MIMEApplicationProblemJSON, rec.Header().Get("Content-Type"))
+			assert.JSONEq(t, tt.wantedBody, rec.Body.String())
+			entries := l.Entries()
+			assert.Equal(t, 1, len(entries))
+			assert.Equal(t, tt.wantedLevel, entries[0].Level)
+		})
+	}
+}
+
+func TestWrapErrorAlreadySent(t *testing.T) {
+	l := logger.NewMemoryLogger()
+	h := WrapError(func(w http.ResponseWriter, r *http.Request) error {
+		return httputil.Err400(w, errors.New("invalid body"), "Error when decoding the body", nil)
+	}, l)
+
+	rec := httptest.NewRecorder()
+	h(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"code":"bad_request"`)
+	assert.Equal(t, []string{"invalid body"}, l.Messages(zapcore.WarnLevel))
+}
+
+func TestWrapErrorTruncatedResponse(t *testing.T) {
+	l := logger.NewMemoryLogger()
+	h := WrapError(func(w http.ResponseWriter, r *http.Request) error {
+		w.WriteHeader(http.StatusOK)
+		return fmt.Errorf("%w: %w", httputil.ErrResponseTruncated, errors.New("connection lost"))
+	}, l)
+
+	rec := httptest.NewRecorder()
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		h(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+	assert.Equal(t, []string{"response truncated: connection lost"}, l.Messages(zapcore.ErrorLevel))
+}
+
+func TestWrapErrorRequestLogger(t *testing.T) {
+	l := logger.NewMemoryLogger()
+	var handlerLogger logger.CustomLogger
+
+	r := chi.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := logger.NewContext(r.Context(), l.With(logger.Fields{logger.String("request_id", "123")}))
+			next.ServeHTTP(w, r.WithContext(WithAuth(ctx, Auth{UserID: "user-1"})))
+		})
+	})
+	r.Get("/users/{id}", WrapError(func(w http.ResponseWriter, r *http.Request) error {
+		handlerLogger = logger.FromContext(r.Context(), nil)
+		handlerLogger.Info("get user")
+		return errors.New("unknown")
+	}, logger.NewNopLogger()))
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/1", nil))
+
+	entries := l.Entries()
+	assert.Equal(t, 2, len(entries))
+	assert.Equal(t, logger.Entry{
+		Level:   zapcore.InfoLevel,
+		Message: "get user",
+		Fields:  map[string]any{"request_id": "123", "user_id": "user-1", "route": "/users/{id}"},
+	}, entries[0])
+	assert.Equal(t, logger.Entry{
+		Level:   zapcore.ErrorLevel,
+		Message: "unknown",
+		Fields:  map[string]any{"request_id": "123", "user_id": "user-1", "route": "/users/{id}"},
+	}, entries[1])
+}
+
+func TestWrapErrorWithoutRequestLogger(t *testing.T) {
+	l := logger.NewMemoryLogger()
+	h := WrapError(func(w http.ResponseWriter, r *http.Request) error {
+		return errors.New("unknown")
+	}, l)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	h(httptest.NewRecorder(), req.WithContext(context.WithValue(req.Context(), RequestIDKey("request_id"), "123")))
+
+	assert.Equal(t, []logger.Entry{{
+		Level:   zapcore.ErrorLevel,
+		Message: "unknown",
+		Fields:  map[string]any{"request_id": "123"},
+	}}, l.Entries())
+}
@@ -4,9 +4,8 @@ import (
 	"fmt"
 	"go-clean-api/pkg/infrastructure/chi_router/httputil"
 	"html/template"
+	"iter"
 	"net/http"
-
-	"github.com/go-chi/render"
 )
 
 // HealthCheck returns status code 200
@@ -30,7 +29,7 @@ func GetAPIv1Doc(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
-// BigTasks returns a big JSON
+// BigTasks returns a big list of tasks, streamed element by element
 // TODO: Remove?
 func BigTasks(w http.ResponseWriter, r *http.Request) error {
 	type Task struct {
@@ -38,15 +37,22 @@ func BigTasks(w http.ResponseWriter, r *http.Request) error {
 		Name string
 	}
 
-	var tasks []Task
-	for i := range 10_000 {
-		tasks = append(tasks, Task{
-			ID:   i*100_000 + 1,
-			Name: fmt.Sprintf("My task with ID: %d", i),
-		})
+	var tasks iter.Seq[Task] = func(yield func(Task) bool) {
+		for i := range 10_000 {
+			if !yield(Task{ID: i*100_000 + 1, Name: fmt.Sprintf("My task with ID: %d", i)}) {
+				return
+			}
+		}
 	}
 
-	render.JSON(w, r, tasks)
-
-	return nil
+	return httputil.Stream(w, http.StatusOK, httputil.StreamedList[Task]{
+		Items: tasks,
+		Whole: func() any {
+			var all []Task
+			for task := range tasks {
+				all = append(all, task)
+			}
+			return all
+		},
+	})
 }
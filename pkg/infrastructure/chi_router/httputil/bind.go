@@ -0,0 +1,216 @@
+package httputil
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	domainerr "go-clean-api/pkg/domain/errors"
+	"go-clean-api/pkg/domain/validation"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// bindMultipartMemory is the maximum size of a multipart form kept in memory
+const bindMultipartMemory = 32 << 20
+
+// ErrUnknownField is returned when a body contains a field which does not exist in the bound struct
+var ErrUnknownField = errors.New("unknown field")
+
+// Bind decodes the body of a request in a T and validates it with the validate tags of T.
+//
+// JSON, XML, MessagePack and form bodies are supported: XML bodies are decoded with the xml tags of T, the other ones
+// with its json tags (or the form tags for forms). Unknown fields (except in XML) and trailing JSON data are rejected
+// with a 400 and a body larger than the request size limit returns 413.
+// All the invalid fields are returned at once in a 422 response, with messages in the language of the Accept-Language
+// header (English by default). The response is sent when an error is returned.
+func Bind[T any](w http.ResponseWriter, r *http.Request) (T, error) {
+	var v T
+	if err := decodeBody(r, &v); err != nil {
+		if maxBytesErr := (*http.MaxBytesError)(nil); errors.As(err, &maxBytesErr) {
+			return v, Err(w, StatusRequestEntityTooLarge, err, "Request body too large", fmt.Sprintf("request body must not exceed %d bytes", maxBytesErr.Limit))
+		}
+		if errors.Is(err, ErrUnsupportedMediaType) {
+			return v, Err(w, StatusUnsupportedMediaType, err, "Unsupported media type", fmt.Sprintf("supported media types: %s", strings.Join(append(supportedMediaTypes(false), MIMEApplicationForm, MIMEMultipartForm), ", ")))
+		}
+		return v, Err400(w, err, "Error when decoding the body", err.Error())
+	}
+
+	locale := Locale(r)
+	if errs := validation.ValidateStructLocale(v, locale); len(errs) > 0 {
+		SetContentLanguage(w, locale)
+		return v, Problem(w, StatusUnprocessableEntity, domainerr.CodeValidation, &errs, "Invalid parameters", errs)
+	}
+
+	return v, nil
+}
+
+// decodeBody decodes the body of a request according to its Content-Type (JSON by default).
+// An empty body leaves v unchanged.
+func decodeBody(r *http.Request, v any) error {
+	if !hasBody(r) {
+		return nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case MIMEApplicationForm:
+		if err := r.ParseForm(); err != nil {
+			return err
+		}
+		return decodeForm(r.PostForm, v)
+	case MIMEMultipartForm:
+		if err := r.ParseMultipartForm(bindMultipartMemory); err != nil {
+			return err
+		}
+		return decodeForm(r.PostForm, v)
+	}
+
+	format, err := ContentFormat(r.Header.Get("Content-Type"))
+	if err != nil {
+		return err
+	}
+	switch format.ContentType {
+	case MIMEApplicationXML:
+		if err := xml.NewDecoder(r.Body).Decode(v); err != nil && err != io.EOF {
+			return err
+		}
+		return nil
+	case MIMEApplicationMsgPack:
+		dec := newMsgPackDecoder(r.Body)
+		dec.DisallowUnknownFields(true)
+		if err := dec.Decode(v); err != nil && err != io.EOF {
+			return err
+		}
+		return nil
+	}
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		if err == io.EOF {
+			return nil
+		}
+		if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+			return fmt.Errorf("%w: %s", ErrUnknownField, field)
+		}
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		if maxBytesErr := (*http.MaxBytesError)(nil); errors.As(err, &maxBytesErr) {
+			return err
+		}
+		return errors.New("body must contain a single JSON value")
+	}
+
+	return nil
+}
+
+// decodeForm sets the fields of the struct pointed by v from the values of a form.
+// Strings, booleans, numbers and slices of them are supported.
+func decodeForm(values url.Values, v any) error {
+	rv := reflect.ValueOf(v).Elem()
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("form cannot be decoded in %s", rv.Type())
+	}
+
+	fields := make(map[string]reflect.Value, rv.NumField())
+	for i := range rv.NumField() {
+		f := rv.Type().Field(i)
+		name := formFieldName(f)
+		if !f.IsExported() || name == "" {
+			continue
+		}
+		fields[name] = rv.Field(i)
+	}
+
+	for key, vals := range values {
+		field, ok := fields[strings.TrimSuffix(key, "[]")]
+		if !ok {
+			return fmt.Errorf("%w: %q", ErrUnknownField, key)
+		}
+		if err := setFormField(field, vals); err != nil {
+			return fmt.Errorf("invalid value of field %q: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// formFieldName returns the name of a struct field in a form: its form tag, its JSON name or its name
+func formFieldName(f reflect.StructField) string {
+	for _, tag := range []string{"form", "json"} {
+		name, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return f.Name
+}
+
+// setFormField sets the values of a form field
+func setFormField(field reflect.Value, values []string) error {
+	if field.Kind() == reflect.Slice {
+		s := reflect.MakeSlice(field.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := setFormValue(s.Index(i), value); err != nil {
+				return err
+			}
+		}
+		field.Set(s)
+		return nil
+	}
+
+	if len(values) != 1 {
+		return errors.New("single value expected")
+	}
+	return setFormValue(field, values[0])
+}
+
+// setFormValue sets a scalar value from its string representation
+func setFormValue(v reflect.Value, value string) error {
+	if v.Kind() == reflect.Pointer {
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
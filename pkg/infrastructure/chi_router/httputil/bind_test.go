@@ -0,0 +1,197 @@
+package httputil
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testBindRequest struct {
+	Email  string   `json:"email" xml:"email" form:"email" validate:"required,email"`
+	Age    int      `json:"age,omitempty" xml:"age,omitempty" form:"age" validate:"omitempty,min=18"`
+	Scopes []string `json:"scopes" xml:"scopes" form:"scopes" validate:"required,min=1,dive,required"`
+}
+
+func TestBind(t *testing.T) {
+	tests := []struct {
+		name           string
+		contentType    string
+		acceptLanguage string
+		body           string
+		limit          int64
+		wantedStatus   int
+		wantedCode     string
+		wantedRequest  testBindRequest
+		wantedDetails  []map[string]string
+		wantedLanguage string
+	}{
+		{
+			name:          "JSON",
+			contentType:   MIMEApplicationJSON,
+			body:          `{"email":"john@test.com","age":20,"scopes":["users"]}`,
+			wantedStatus:  http.StatusOK,
+			wantedRequest: testBindRequest{Email: "john@test.com", Age: 20, Scopes: []string{"users"}},
+		},
+		{
+			name:          "XML",
+			contentType:   MIMEApplicationXML,
+			body:          `<request><email>john@test.com</email><age>20</age><scopes>users</scopes></request>`,
+			wantedStatus:  http.StatusOK,
+			wantedRequest: testBindRequest{Email: "john@test.com", Age: 20, Scopes: []string{"users"}},
+		},
+		{
+			name:          "MessagePack",
+			contentType:   MIMEApplicationMsgPack,
+			body:          string(mustMarshal(t, FormatMsgPack, testBindRequest{Email: "john@test.com", Age: 20, Scopes: []string{"users"}})),
+			wantedStatus:  http.StatusOK,
+			wantedRequest: testBindRequest{Email: "john@test.com", Age: 20, Scopes: []string{"users"}},
+		},
+		{
+			name:         "Invalid XML value",
+			contentType:  MIMEApplicationXML,
+			body:         `<request><email>john@test.com</email><age>old</age><scopes>users</scopes></request>`,
+			wantedStatus: http.StatusBadRequest,
+			wantedCode:   "bad_request",
+		},
+		{
+			name:          "Form",
+			contentType:   MIMEApplicationForm,
+			body:          `email=john%40test.com&age=20&scopes=users&scopes=webhooks`,
+			wantedStatus:  http.StatusOK,
+			wantedRequest: testBindRequest{Email: "john@test.com", Age: 20, Scopes: []string{"users", "webhooks"}},
+		},
+		{
+			name:         "Unknown JSON field",
+			contentType:  MIMEApplicationJSON,
+			body:         `{"email":"john@test.com","scopes":["users"],"admin":true}`,
+			wantedStatus: http.StatusBadRequest,
+			wantedCode:   "bad_request",
+		},
+		{
+			name:         "Unknown form field",
+			contentType:  MIMEApplicationForm,
+			body:         `email=john%40test.com&scopes=users&admin=1`,
+			wantedStatus: http.StatusBadRequest,
+			wantedCode:   "bad_request",
+		},
+		{
+			name:         "Invalid form value",
+			contentType:  MIMEApplicationForm,
+			body:         `email=john%40test.com&scopes=users&age=old`,
+			wantedStatus: http.StatusBadRequest,
+			wantedCode:   "bad_request",
+		},
+		{
+			name:         "Trailing data",
+			contentType:  MIMEApplicationJSON,
+			body:         `{"email":"john@test.com","scopes":["users"]} {}`,
+			wantedStatus: http.StatusBadRequest,
+			wantedCode:   "bad_request",
+		},
+		{
+			name:         "Too large",
+			contentType:  MIMEApplicationJSON,
+			body:         `{"email":"john@test.com","scopes":["users"]}`,
+			limit:        16,
+			wantedStatus: http.StatusRequestEntityTooLarge,
+			wantedCode:   "request_entity_too_large",
+		},
+		{
+			name:         "Unsupported media type",
+			contentType:  "text/plain",
+			body:         `john@test.com`,
+			wantedStatus: http.StatusUnsupportedMediaType,
+			wantedCode:   "unsupported_media_type",
+		},
+		{
+			name:         "All invalid fields",
+			contentType:  MIMEApplicationJSON,
+			body:         `{"email":"john","age":12,"scopes":["users",""]}`,
+			wantedStatus: http.StatusUnprocessableEntity,
+			wantedCode:   "validation_error",
+			wantedDetails: []map[string]string{
+				{"field": "email", "tag": "email", "value": "", "message": "email must be a valid email address"},
+				{"field": "age", "tag": "min", "value": "18", "message": "age must be 18 or greater"},
+				{"field": "scopes[1]", "tag": "required", "value": "", "message": "scopes[1] is a required field"},
+			},
+		},
+		{
+			name:           "Messages in French",
+			contentType:    MIMEApplicationJSON,
+			acceptLanguage: "fr-FR,fr;q=0.9,en;q=0.8",
+			body:           `{"email":"john","scopes":["users"]}`,
+			wantedStatus:   http.StatusUnprocessableEntity,
+			wantedCode:     "validation_error",
+			wantedLanguage: "fr",
+			wantedDetails: []map[string]string{
+				{"field": "email", "tag": "email", "value": "", "message": "email doit être une adresse email valide"},
+			},
+		},
+		{
+			name:         "Empty body",
+			contentType:  MIMEApplicationJSON,
+			wantedStatus: http.StatusUnprocessableEntity,
+			wantedCode:   "validation_error",
+			wantedDetails: []map[string]string{
+				{"field": "email", "tag": "required", "value": "", "message": "email is a required field"},
+				{"field": "scopes", "tag": "required", "value": "", "message": "scopes is a required field"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			req.Header.Set("Accept-Language", tt.acceptLanguage)
+			rec := httptest.NewRecorder()
+			if tt.limit > 0 {
+				req.Body = http.MaxBytesReader(rec, req.Body, tt.limit)
+			}
+
+			body, err := Bind[testBindRequest](rec, req)
+
+			if tt.wantedStatus == http.StatusOK {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.wantedRequest, body)
+				return
+			}
+			assert.NotNil(t, err)
+			assert.Equal(t, tt.wantedStatus, rec.Code)
+
+			var problem struct {
+				Code    string              `json:"code"`
+				Details []map[string]string `json:"details"`
+			}
+			assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			assert.Equal(t, tt.wantedCode, problem.Code)
+			assert.Equal(t, tt.wantedDetails, problem.Details)
+			if tt.wantedLanguage != "" {
+				assert.Equal(t, tt.wantedLanguage, rec.Header().Get("Content-Language"))
+			}
+		})
+	}
+}
+
+func TestBindMultipartForm(t *testing.T) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	mw.WriteField("email", "john@test.com")
+	mw.WriteField("scopes[]", "users")
+	mw.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/", &buf)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	rec := httptest.NewRecorder()
+
+	body, err := Bind[testBindRequest](rec, req)
+
+	assert.Nil(t, err)
+	assert.Equal(t, testBindRequest{Email: "john@test.com", Scopes: []string{"users"}}, body)
+}
@@ -0,0 +1,313 @@
+package httputil
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Content encodings
+const (
+	EncodingZstd   = "zstd"
+	EncodingBrotli = "br"
+	EncodingGzip   = "gzip"
+)
+
+// DefaultCompressMinSize is the default minimum size of a response to compress it (in bytes)
+const DefaultCompressMinSize = 1024
+
+// DefaultCompressEncodings are the default supported encodings by order of preference
+var DefaultCompressEncodings = []string{EncodingZstd, EncodingBrotli, EncodingGzip}
+
+// DefaultCompressContentTypes are the default media types of the compressed responses
+var DefaultCompressContentTypes = []string{
+	MIMEApplicationJSON,
+	MIMEApplicationProblemJSON,
+	MIMEApplicationXML,
+	MIMEApplicationProblemXML,
+	MIMEApplicationNDJSON,
+	MIMETextXML,
+	"text/html",
+	"text/css",
+	"text/plain",
+	"application/javascript",
+	"image/svg+xml",
+}
+
+// CompressOptions is the configuration of the Compress middleware
+type CompressOptions struct {
+	// Encodings are the supported encodings by order of preference (zstd, br, gzip)
+	Encodings []string
+
+	// MinSize is the minimum size of a response to compress it (in bytes)
+	MinSize int
+
+	// ContentTypes are the media types of the compressed responses ("text/*" matches all text types)
+	ContentTypes []string
+}
+
+// compressEncoder is a compression writer which can be reused
+type compressEncoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// compressEncoders are the pools of encoders of each encoding
+var compressEncoders = map[string]*sync.Pool{
+	EncodingZstd: {New: func() any {
+		enc, _ := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault), zstd.WithEncoderConcurrency(1))
+		return enc
+	}},
+	EncodingBrotli: {New: func() any {
+		return brotli.NewWriterLevel(nil, brotli.DefaultCompression)
+	}},
+	EncodingGzip: {New: func() any {
+		return gzip.NewWriter(nil)
+	}},
+}
+
+// Compress is a middleware which compresses the responses with the preferred encoding of the Accept-Encoding header.
+//
+// Only the responses with an allowed content type and a body of at least MinSize bytes are compressed. A response
+// flushed before reaching MinSize (a stream) is compressed as soon as it is flushed.
+// The content encoding is added to the strong entity tag of a compressed response (see ETagWithEncoding).
+func Compress(opts CompressOptions) func(http.Handler) http.Handler {
+	if len(opts.Encodings) == 0 {
+		opts.Encodings = DefaultCompressEncodings
+	}
+	if opts.MinSize <= 0 {
+		opts.MinSize = DefaultCompressMinSize
+	}
+	if len(opts.ContentTypes) == 0 {
+		opts.ContentTypes = DefaultCompressContentTypes
+	}
+	opts.Encodings = slices.DeleteFunc(slices.Clone(opts.Encodings), func(e string) bool {
+		_, ok := compressEncoders[e]
+		return !ok
+	})
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+
+			encoding := AcceptedEncoding(r.Header.Get("Accept-Encoding"), opts.Encodings)
+			if encoding == "" || r.Method == http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &compressWriter{ResponseWriter: w, encoding: encoding, opts: &opts}
+			defer cw.Close()
+
+			next.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// AcceptedEncoding returns the first supported encoding accepted by an Accept-Encoding header,
+// an empty string if none is accepted.
+func AcceptedEncoding(acceptEncoding string, supported []string) string {
+	accepted := make(map[string]float64)
+	for part := range strings.SplitSeq(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			var err error
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		accepted[name] = q
+	}
+
+	for _, encoding := range supported {
+		q, ok := accepted[encoding]
+		if !ok {
+			q, ok = accepted["*"]
+		}
+		if ok && q > 0 {
+			return encoding
+		}
+	}
+	return ""
+}
+
+// compressWriter is a http.ResponseWriter which compresses the response body.
+// The beginning of the body is buffered until MinSize bytes are written to decide if it is compressed.
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	opts     *CompressOptions
+
+	status  int
+	buf     bytes.Buffer
+	decided bool
+	encoder compressEncoder
+}
+
+// WriteHeader implements http.ResponseWriter.
+// The header is sent when the compression is decided.
+func (cw *compressWriter) WriteHeader(status int) {
+	if cw.status != 0 {
+		return
+	}
+	if status < http.StatusOK {
+		// Informational responses are sent as is
+		cw.ResponseWriter.WriteHeader(status)
+		return
+	}
+
+	cw.status = status
+	if !bodyAllowedForStatus(status) {
+		cw.decide(false)
+	}
+}
+
+// Write implements http.ResponseWriter
+func (cw *compressWriter) Write(b []byte) (int, error) {
+	if cw.status == 0 {
+		cw.WriteHeader(http.StatusOK)
+	}
+
+	if !cw.decided {
+		cw.buf.Write(b)
+		if cw.buf.Len() < cw.opts.MinSize {
+			return len(b), nil
+		}
+		if err := cw.decide(cw.compressible()); err != nil {
+			return 0, err
+		}
+		return len(b), nil
+	}
+
+	if cw.encoder != nil {
+		return cw.encoder.Write(b)
+	}
+	return cw.ResponseWriter.Write(b)
+}
+
+// Flush implements http.Flusher.
+// A response flushed before the end is compressed if its content type is allowed.
+func (cw *compressWriter) Flush() {
+	if cw.status == 0 {
+		cw.WriteHeader(http.StatusOK)
+	}
+	if !cw.decided {
+		cw.decide(cw.compressible())
+	}
+	if cw.encoder != nil {
+		cw.encoder.Flush()
+	}
+	if f, ok := cw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap returns the original http.ResponseWriter
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// Close sends the buffered body and closes the encoder
+func (cw *compressWriter) Close() error {
+	if cw.status == 0 {
+		// Nothing has been written by the handler
+		return nil
+	}
+	if !cw.decided {
+		if err := cw.decide(cw.buf.Len() >= cw.opts.MinSize && cw.compressible()); err != nil {
+			return err
+		}
+	}
+	if cw.encoder == nil {
+		return nil
+	}
+
+	err := cw.encoder.Close()
+	cw.encoder.Reset(nil)
+	compressEncoders[cw.encoding].Put(cw.encoder)
+	cw.encoder = nil
+
+	return err
+}
+
+// compressible returns true if the response can be compressed
+func (cw *compressWriter) compressible() bool {
+	h := cw.Header()
+	if h.Get("Content-Encoding") != "" || !bodyAllowedForStatus(cw.status) {
+		return false
+	}
+	if cl, err := strconv.Atoi(h.Get("Content-Length")); err == nil && cl < cw.opts.MinSize {
+		return false
+	}
+
+	contentType := h.Get("Content-Type")
+	if contentType == "" {
+		// The content type cannot be sniffed by net/http once the body is compressed
+		contentType = http.DetectContentType(cw.buf.Bytes())
+		h.Set("Content-Type", contentType)
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, t := range cw.opts.ContentTypes {
+		if t == mediaType || (strings.HasSuffix(t, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(t, "*"))) {
+			return true
+		}
+	}
+	return false
+}
+
+// decide sends the header and the buffered body, compressed or not
+func (cw *compressWriter) decide(compress bool) error {
+	cw.decided = true
+
+	if compress {
+		h := cw.Header()
+		h.Del("Content-Length")
+		h.Set("Content-Encoding", cw.encoding)
+		if etag := h.Get(HeaderETag); etag != "" {
+			h.Set(HeaderETag, ETagWithEncoding(etag, cw.encoding))
+		}
+
+		cw.encoder = compressEncoders[cw.encoding].Get().(compressEncoder)
+		cw.encoder.Reset(cw.ResponseWriter)
+	}
+
+	cw.ResponseWriter.WriteHeader(cw.status)
+
+	if cw.buf.Len() == 0 {
+		return nil
+	}
+	var err error
+	if cw.encoder != nil {
+		_, err = cw.encoder.Write(cw.buf.Bytes())
+	} else {
+		_, err = cw.ResponseWriter.Write(cw.buf.Bytes())
+	}
+	cw.buf.Reset()
+
+	return err
+}
+
+// bodyAllowedForStatus returns true if a response with this status can have a body
+func bodyAllowedForStatus(status int) bool {
+	return status != http.StatusNoContent && status != http.StatusNotModified
+}
@@ -0,0 +1,184 @@
+package httputil
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAcceptedEncoding(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		wanted         string
+	}{
+		{name: "Empty header", acceptEncoding: "", wanted: ""},
+		{name: "Preferred encoding", acceptEncoding: "gzip, deflate, br, zstd", wanted: EncodingZstd},
+		{name: "Only gzip", acceptEncoding: "gzip", wanted: EncodingGzip},
+		{name: "Refused encoding", acceptEncoding: "zstd;q=0, br;q=0.5", wanted: EncodingBrotli},
+		{name: "Wildcard", acceptEncoding: "*", wanted: EncodingZstd},
+		{name: "Refused wildcard", acceptEncoding: "*;q=0", wanted: ""},
+		{name: "Unsupported encoding", acceptEncoding: "deflate", wanted: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wanted, AcceptedEncoding(tt.acceptEncoding, DefaultCompressEncodings))
+		})
+	}
+}
+
+func TestCompress(t *testing.T) {
+	large := strings.Repeat(`{"name":"task"}`, 200)
+
+	tests := []struct {
+		name           string
+		method         string
+		acceptEncoding string
+		contentType    string
+		status         int
+		body           string
+		flush          bool
+		wantedEncoding string
+	}{
+		{name: "zstd", acceptEncoding: "zstd", contentType: MIMEApplicationJSON, body: large, wantedEncoding: EncodingZstd},
+		{name: "Brotli", acceptEncoding: "br", contentType: MIMEApplicationJSON, body: large, wantedEncoding: EncodingBrotli},
+		{name: "gzip", acceptEncoding: "gzip", contentType: "application/json; charset=utf-8", body: large, wantedEncoding: EncodingGzip},
+		{name: "Text wildcard", acceptEncoding: "gzip", contentType: "text/plain", body: large, wantedEncoding: EncodingGzip},
+		{name: "Flushed stream", acceptEncoding: "gzip", contentType: MIMEApplicationNDJSON, body: `{"id":1}`, flush: true, wantedEncoding: EncodingGzip},
+		{name: "Below the minimum size", acceptEncoding: "gzip", contentType: MIMEApplicationJSON, body: `{"id":1}`},
+		{name: "Not allowed content type", acceptEncoding: "gzip", contentType: "image/png", body: large},
+		{name: "Not accepted", acceptEncoding: "deflate", contentType: MIMEApplicationJSON, body: large},
+		{name: "No content", acceptEncoding: "gzip", status: http.StatusNoContent},
+		{name: "HEAD", method: http.MethodHead, acceptEncoding: "gzip", contentType: MIMEApplicationJSON},
+	}
+
+	h := Compress(CompressOptions{ContentTypes: []string{MIMEApplicationJSON, MIMEApplicationNDJSON, "text/*"}})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := h(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.contentType != "" {
+					w.Header().Set("Content-Type", tt.contentType)
+				}
+				if tt.status != 0 {
+					w.WriteHeader(tt.status)
+				}
+				io.WriteString(w, tt.body)
+				if tt.flush {
+					w.(http.Flusher).Flush()
+				}
+			}))
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, "/", nil)
+			req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+			assert.Equal(t, tt.wantedEncoding, rec.Header().Get("Content-Encoding"))
+			if tt.status != 0 {
+				assert.Equal(t, tt.status, rec.Code)
+			}
+			assert.Equal(t, tt.body, decompress(t, tt.wantedEncoding, rec.Body.Bytes()))
+		})
+	}
+}
+
+func TestCompressETag(t *testing.T) {
+	large := strings.Repeat(`{"name":"task"}`, 200)
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		body           string
+		wantedETag     string
+	}{
+		{name: "Compressed", acceptEncoding: "br", body: large, wantedETag: `"2-br"`},
+		{name: "Not compressed", acceptEncoding: "br", body: `{"id":1}`, wantedETag: `"2"`},
+		{name: "Not accepted", acceptEncoding: "deflate", body: large, wantedETag: `"2"`},
+	}
+
+	h := Compress(CompressOptions{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := h(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", MIMEApplicationJSON)
+				w.Header().Set(HeaderETag, ETag("2"))
+				io.WriteString(w, tt.body)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantedETag, rec.Header().Get(HeaderETag))
+		})
+	}
+}
+
+func TestCompressReusesEncoders(t *testing.T) {
+	body := strings.Repeat("a", 2*DefaultCompressMinSize)
+	handler := Compress(CompressOptions{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		io.WriteString(w, body)
+	}))
+
+	for range 3 {
+		for _, encoding := range DefaultCompressEncodings {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept-Encoding", encoding)
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, encoding, rec.Header().Get("Content-Encoding"))
+			assert.Equal(t, body, decompress(t, encoding, rec.Body.Bytes()))
+		}
+	}
+}
+
+func decompress(t *testing.T, encoding string, data []byte) string {
+	var r io.Reader
+	switch encoding {
+	case "":
+		return string(data)
+	case EncodingGzip:
+		gr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		r = gr
+	case EncodingBrotli:
+		r = brotli.NewReader(bytes.NewReader(data))
+	case EncodingZstd:
+		zr, err := zstd.NewReader(bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer zr.Close()
+		r = zr
+	}
+
+	body, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body)
+}
@@ -0,0 +1,110 @@
+package httputil
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Conditional request headers
+const (
+	HeaderETag        = "ETag"
+	HeaderIfMatch     = "If-Match"
+	HeaderIfNoneMatch = "If-None-Match"
+)
+
+// ETag returns a strong entity tag from an opaque value (the version of a resource for example).
+func ETag(value string) string {
+	return `"` + value + `"`
+}
+
+// ParseETags returns the entity tags of an If-Match or If-None-Match header.
+// The "*" wildcard is returned as is.
+func ParseETags(header string) []string {
+	var etags []string
+	for part := range strings.SplitSeq(header, ",") {
+		if etag := strings.TrimSpace(part); etag != "" {
+			etags = append(etags, etag)
+		}
+	}
+	return etags
+}
+
+// StrongETagValue returns the opaque value of a strong entity tag.
+// Weak entity tags (W/"...") are never equal with a strong comparison, so they are rejected.
+// The content encoding added by Compress and the format are removed (see ETagWithEncoding and ETagWithFormat).
+func StrongETagValue(etag string) (string, bool) {
+	if len(etag) < 2 || etag[0] != '"' || etag[len(etag)-1] != '"' {
+		return "", false
+	}
+	value := etag[1 : len(etag)-1-len(etagEncodingSuffix(etag))]
+	return value[:len(value)-len(etagFormatSuffix(value))], true
+}
+
+// ETagWithFormat returns the strong entity tag of a representation in a format ("12" becomes "12-xml"),
+// so that the representations of a resource in each negotiated format have distinct entity tags.
+// Weak entity tags are returned as is.
+func ETagWithFormat(etag string, format Format) string {
+	if len(etag) < 2 || etag[0] != '"' || etag[len(etag)-1] != '"' || format.Name == "" {
+		return etag
+	}
+	return etag[:len(etag)-1] + "-" + format.Name + `"`
+}
+
+// ETagWithEncoding returns the strong entity tag of a representation compressed with the content encoding
+// ("12" becomes "12-gzip"), so that its compressed and uncompressed representations have distinct entity tags.
+// Weak entity tags are returned as is.
+func ETagWithEncoding(etag, encoding string) string {
+	if len(etag) < 2 || etag[0] != '"' || etag[len(etag)-1] != '"' || etagEncodingSuffix(etag) != "" {
+		return etag
+	}
+	return etag[:len(etag)-1] + "-" + encoding + `"`
+}
+
+// etagEncodingSuffix returns the content encoding suffix of an entity tag ("-gzip" for example)
+func etagEncodingSuffix(etag string) string {
+	value := strings.TrimSuffix(etag, `"`)
+	for encoding := range compressEncoders {
+		if strings.HasSuffix(value, "-"+encoding) {
+			return "-" + encoding
+		}
+	}
+	return ""
+}
+
+// etagFormatSuffix returns the format suffix of an entity tag value ("-xml" for example)
+func etagFormatSuffix(value string) string {
+	for _, f := range formats {
+		if strings.HasSuffix(value, "-"+f.Name) {
+			return "-" + f.Name
+		}
+	}
+	return ""
+}
+
+// trimETag returns the entity tag without the weak indicator and the content encoding
+func trimETag(etag string) string {
+	etag = strings.TrimPrefix(etag, "W/")
+	if suffix := etagEncodingSuffix(etag); suffix != "" {
+		etag = etag[:len(etag)-1-len(suffix)] + `"`
+	}
+	return etag
+}
+
+// IsNotModified returns true if the If-None-Match header of the request matches the entity tag
+// of the resource, using a weak comparison which ignores the content encoding.
+func IsNotModified(r *http.Request, etag string) bool {
+	for _, e := range ParseETags(r.Header.Get(HeaderIfNoneMatch)) {
+		if e == "*" || trimETag(e) == trimETag(etag) {
+			return true
+		}
+	}
+	return false
+}
+
+// NotModified sends a 304 response with the entity tag of the resource.
+func NotModified(w http.ResponseWriter, etag string) error {
+	w.Header().Set(HeaderETag, etag)
+	w.WriteHeader(http.StatusNotModified)
+
+	return nil
+}
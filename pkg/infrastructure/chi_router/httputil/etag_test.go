@@ -0,0 +1,90 @@
+package httputil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseETags(t *testing.T) {
+	assert.Nil(t, ParseETags(""))
+	assert.Equal(t, []string{"*"}, ParseETags("*"))
+	assert.Equal(t, []string{`"1"`, `W/"2"`}, ParseETags(` "1" , W/"2",`))
+}
+
+func TestStrongETagValue(t *testing.T) {
+	tests := []struct {
+		etag        string
+		wantedValue string
+		wantedOk    bool
+	}{
+		{etag: `"12"`, wantedValue: "12", wantedOk: true},
+		{etag: `"12-gzip"`, wantedValue: "12", wantedOk: true},
+		{etag: `"12-xml"`, wantedValue: "12", wantedOk: true},
+		{etag: `"12-msgpack-gzip"`, wantedValue: "12", wantedOk: true},
+		{etag: `"12-deflate"`, wantedValue: "12-deflate", wantedOk: true},
+		{etag: `""`, wantedValue: "", wantedOk: true},
+		{etag: `W/"12"`},
+		{etag: `12`},
+		{etag: `"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.etag, func(t *testing.T) {
+			value, ok := StrongETagValue(tt.etag)
+			assert.Equal(t, tt.wantedOk, ok)
+			assert.Equal(t, tt.wantedValue, value)
+		})
+	}
+}
+
+func TestIsNotModified(t *testing.T) {
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		wanted      bool
+	}{
+		{name: "No header", ifNoneMatch: "", wanted: false},
+		{name: "Same ETag", ifNoneMatch: `"2"`, wanted: true},
+		{name: "Weak comparison", ifNoneMatch: `W/"2"`, wanted: true},
+		{name: "Compressed", ifNoneMatch: `"2-zstd"`, wanted: true},
+		{name: "In list", ifNoneMatch: `"1", "2"`, wanted: true},
+		{name: "Wildcard", ifNoneMatch: "*", wanted: true},
+		{name: "Other ETag", ifNoneMatch: `"1"`, wanted: false},
+		{name: "Other format", ifNoneMatch: `"2-xml"`, wanted: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.ifNoneMatch != "" {
+				r.Header.Set(HeaderIfNoneMatch, tt.ifNoneMatch)
+			}
+			assert.Equal(t, tt.wanted, IsNotModified(r, ETag("2")))
+		})
+	}
+}
+
+func TestETagWithEncoding(t *testing.T) {
+	assert.Equal(t, `"2-gzip"`, ETagWithEncoding(ETag("2"), EncodingGzip))
+	assert.Equal(t, `"2-gzip"`, ETagWithEncoding(`"2-gzip"`, EncodingGzip))
+	assert.Equal(t, `W/"2"`, ETagWithEncoding(`W/"2"`, EncodingGzip))
+}
+
+func TestETagWithFormat(t *testing.T) {
+	assert.Equal(t, `"2-json"`, ETagWithFormat(ETag("2"), FormatJSON))
+	assert.Equal(t, `"2-xml"`, ETagWithFormat(ETag("2"), FormatXML))
+	assert.Equal(t, `"2-msgpack-gzip"`, ETagWithEncoding(ETagWithFormat(ETag("2"), FormatMsgPack), EncodingGzip))
+	assert.Equal(t, `W/"2"`, ETagWithFormat(`W/"2"`, FormatJSON))
+}
+
+func TestNotModified(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	assert.Nil(t, NotModified(rec, ETag("2")))
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Equal(t, `"2"`, rec.Header().Get(HeaderETag))
+	assert.Empty(t, rec.Body.String())
+}
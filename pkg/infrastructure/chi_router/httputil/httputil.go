@@ -1,10 +1,17 @@
 package httputil
 
 import (
-	"encoding/json"
+	"encoding/xml"
 	"net/http"
+	"strings"
 )
 
+// DefaultRequestIDHeader is the default header containing the request ID (see REQUEST_ID_HEADER)
+const DefaultRequestIDHeader = "X-Request-Id"
+
+// TraceParentHeader is the W3C Trace Context header containing the trace ID
+const TraceParentHeader = "traceparent"
+
 // Error status codes
 const (
 	StatusBadRequest                   = 400
@@ -50,88 +57,149 @@ const (
 	StatusNetworkAuthenticationRequired = 511
 )
 
-// HTTPError represents an HTTP error.
+// ProblemTypeBaseURI is the prefix of the problem types, followed by the error code.
+var ProblemTypeBaseURI = "urn:problem-type:"
+
+// HTTPError represents an HTTP error as an RFC 9457 problem details object.
 type HTTPError struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
-	Details any    `json:"details,omitempty"`
-	Err     error  `json:"-"`
+	XMLName xml.Name `json:"-" xml:"urn:ietf:rfc:7807 problem"`
+
+	// Type is a URI identifying the problem type
+	Type string `json:"type" xml:"type"`
+
+	// Title is a short summary of the problem type
+	Title string `json:"title" xml:"title"`
+
+	// Status is the HTTP status code
+	Status int `json:"status" xml:"status"`
+
+	// Detail is an explanation specific to this occurrence of the problem
+	Detail string `json:"detail,omitempty" xml:"detail,omitempty"`
+
+	// Instance identifies this occurrence of the problem, it is the request ID
+	Instance string `json:"instance,omitempty" xml:"instance,omitempty"`
+
+	// Code is a stable machine-readable error code
+	Code string `json:"code" xml:"code"`
+
+	// Details contains structured details of the problem (validation errors for example)
+	Details any `json:"details,omitempty" xml:"details,omitempty"`
+
+	Err error `json:"-" xml:"-"`
 }
 
 // NewHTTPError returns a new HTTPError.
-func NewHTTPError(code int, message string, details any, err error) *HTTPError {
-	return &HTTPError{
-		Code:    code,
-		Message: message,
-		Details: details,
-		Err:     err,
+// A string details is used as the problem detail, other details are sent in the details member.
+func NewHTTPError(status int, code, title string, details any, err error) *HTTPError {
+	e := &HTTPError{
+		Type:   ProblemTypeBaseURI + code,
+		Title:  title,
+		Status: status,
+		Code:   code,
+		Err:    err,
+	}
+
+	if detail, ok := details.(string); ok {
+		e.Detail = detail
+	} else {
+		e.Details = details
 	}
+
+	return e
 }
 
 // Error returns the error message.
 func (e HTTPError) Error() string {
+	if e.Err == nil {
+		return e.Title
+	}
 	return e.Err.Error()
 }
 
-// SendError sends the error to the client.
+// SendError sends the error to the client in the negotiated format.
+// The error is sent in JSON if it cannot be encoded in the negotiated format.
 func (e *HTTPError) SendError(w http.ResponseWriter) error {
-	res, err := json.Marshal(e)
+	if e.Instance == "" {
+		e.Instance = RequestID(w)
+	}
+
+	format := ResponseFormat(w)
+	res, err := format.Marshal(e)
+	if err != nil && format.ContentType != MIMEApplicationJSON {
+		format = FormatJSON
+		res, err = format.Marshal(e)
+	}
 	if err != nil {
-		return Err500(w, err, "error when JSON encoding the response", nil)
+		return Err500(w, err, "error when encoding the response", nil)
 	}
 
-	w.Header().Add("Content-Type", "application/json")
-	w.WriteHeader(e.Code)
+	w.Header().Set("Content-Type", format.ProblemContentType)
+	w.WriteHeader(e.Status)
 	w.Write(res)
 
 	return e.Err
 }
 
-func Err(w http.ResponseWriter, status int, err error, msg string, details any) error {
-	e := NewHTTPError(status, msg, details, err)
+// DefaultErrorCode returns the error code of an HTTP status (e.g. "not_found" for 404).
+func DefaultErrorCode(status int) string {
+	return strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+}
+
+// Problem sends an error with a specific error code.
+func Problem(w http.ResponseWriter, status int, code string, err error, title string, details any) error {
+	e := NewHTTPError(status, code, title, details, err)
 	return e.SendError(w)
 }
 
+// Err sends an error with the default error code of the status.
+func Err(w http.ResponseWriter, status int, err error, msg string, details any) error {
+	return Problem(w, status, DefaultErrorCode(status), err, msg, details)
+}
+
 func Err400(w http.ResponseWriter, err error, msg string, details any) error {
 	return Err(w, StatusBadRequest, err, msg, details)
 }
 
 func Err401(w http.ResponseWriter, err error, msg string, details any) error {
-	return Err(w, StatusUnauthorized, err, msg, nil)
+	return Err(w, StatusUnauthorized, err, msg, details)
+}
+
+func Err403(w http.ResponseWriter, err error, msg string, details any) error {
+	return Err(w, StatusForbidden, err, msg, details)
 }
 
 func Err404(w http.ResponseWriter, err error, msg string, details any) error {
-	return Err(w, StatusNotFound, err, msg, nil)
+	return Err(w, StatusNotFound, err, msg, details)
 }
 
 func Err405(w http.ResponseWriter, err error, msg string, details any) error {
-	return Err(w, StatusMethodNotAllowed, err, msg, nil)
+	return Err(w, StatusMethodNotAllowed, err, msg, details)
 }
 
 func Err500(w http.ResponseWriter, err error, msg string, details any) error {
 	return Err(w, StatusInternalServerError, err, msg, details)
 }
 
+// JSON sends the data with status 200 in the negotiated format (JSON by default).
 func JSON(w http.ResponseWriter, data any) error {
-	res, err := json.Marshal(data)
-	if err != nil {
-		return Err500(w, err, "error when JSON encoding the response", nil)
-	}
-
-	w.Header().Add("Content-Type", "application/json")
-	w.Write(res)
-
-	return nil
+	return Send(w, http.StatusOK, data)
 }
 
+// Created sends the data with status 201 in the negotiated format (JSON by default).
 func Created(w http.ResponseWriter, data any) error {
-	res, err := json.Marshal(data)
+	return Send(w, http.StatusCreated, data)
+}
+
+// Send sends the data with the status in the negotiated format (JSON by default).
+func Send(w http.ResponseWriter, status int, data any) error {
+	format := ResponseFormat(w)
+	res, err := format.Marshal(data)
 	if err != nil {
-		return Err500(w, err, "error when JSON encoding the response", nil)
+		return Err500(w, err, "error when encoding the response", nil)
 	}
 
-	w.Header().Add("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
+	w.Header().Set("Content-Type", format.ContentType)
+	w.WriteHeader(status)
 	w.Write(res)
 
 	return nil
@@ -0,0 +1,46 @@
+package httputil
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProblem(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	err := Problem(WithRequestID(rec, "request-id"), http.StatusNotFound, "user_not_found", errors.New("[user_uc:GetByID user not found]"), "Not Found", "user not found")
+
+	assert.EqualError(t, err, "[user_uc:GetByID user not found]")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"type": "urn:problem-type:user_not_found",
+		"title": "Not Found",
+		"status": 404,
+		"detail": "user not found",
+		"instance": "request-id",
+		"code": "user_not_found"
+	}`, rec.Body.String())
+}
+
+func TestProblemXML(t *testing.T) {
+	rec := httptest.NewRecorder()
+	w := &negotiatedWriter{ResponseWriter: rec, format: FormatXML}
+
+	Err401(w, nil, "Unauthorized", "invalid token")
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, MIMEApplicationProblemXML, rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), `<problem xmlns="urn:ietf:rfc:7807"><type>urn:problem-type:unauthorized</type><title>Unauthorized</title><status>401</status><detail>invalid token</detail><code>unauthorized</code></problem>`)
+}
+
+func TestDefaultErrorCode(t *testing.T) {
+	assert.Equal(t, "bad_request", DefaultErrorCode(http.StatusBadRequest))
+	assert.Equal(t, "not_found", DefaultErrorCode(http.StatusNotFound))
+	assert.Equal(t, "unsupported_media_type", DefaultErrorCode(http.StatusUnsupportedMediaType))
+	assert.Equal(t, "internal_server_error", DefaultErrorCode(http.StatusInternalServerError))
+}
@@ -0,0 +1,386 @@
+package httputil
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"go-clean-api/pkg/domain/validation"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Media types
+const (
+	MIMEApplicationJSON        = "application/json"
+	MIMEApplicationProblemJSON = "application/problem+json"
+	MIMEApplicationXML         = "application/xml"
+	MIMEApplicationProblemXML  = "application/problem+xml"
+	MIMETextXML                = "text/xml"
+	MIMEApplicationMsgPack     = "application/msgpack"
+	MIMEApplicationNDJSON      = "application/x-ndjson"
+	MIMEApplicationForm        = "application/x-www-form-urlencoded"
+	MIMEMultipartForm          = "multipart/form-data"
+)
+
+var (
+	// ErrNotAcceptable is returned when no media type of the Accept header is supported
+	ErrNotAcceptable = errors.New("not acceptable media type")
+
+	// ErrUnsupportedMediaType is returned when the media type of the request body is not supported
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+)
+
+// Format is a media type supported for requests and responses
+type Format struct {
+	// Name identifies the format in the entity tags (see ETagWithFormat)
+	Name string
+
+	// ContentType is the media type sent in the Content-Type header of the responses
+	ContentType string
+
+	// ProblemContentType is the media type sent in the Content-Type header of the error responses
+	ProblemContentType string
+
+	// Aliases are the media types accepted for this format
+	Aliases []string
+
+	// ResponseOnly is true if the format is not accepted for the request bodies
+	ResponseOnly bool
+
+	Marshal   func(v any) ([]byte, error)
+	Unmarshal func(data []byte, v any) error
+}
+
+// Supported formats
+var (
+	FormatJSON = Format{
+		Name:               "json",
+		ContentType:        MIMEApplicationJSON,
+		ProblemContentType: MIMEApplicationProblemJSON,
+		Aliases:            []string{MIMEApplicationJSON, MIMEApplicationProblemJSON},
+		Marshal:            json.Marshal,
+		Unmarshal:          json.Unmarshal,
+	}
+
+	FormatXML = Format{
+		Name:               "xml",
+		ContentType:        MIMEApplicationXML,
+		ProblemContentType: MIMEApplicationProblemXML,
+		Aliases:            []string{MIMEApplicationXML, MIMETextXML, MIMEApplicationProblemXML},
+		Marshal:            marshalXML,
+		Unmarshal:          xml.Unmarshal,
+	}
+
+	FormatMsgPack = Format{
+		Name:               "msgpack",
+		ContentType:        MIMEApplicationMsgPack,
+		ProblemContentType: MIMEApplicationMsgPack,
+		Aliases:            []string{MIMEApplicationMsgPack, "application/x-msgpack", "application/vnd.msgpack"},
+		Marshal:            marshalMsgPack,
+		Unmarshal:          unmarshalMsgPack,
+	}
+
+	FormatNDJSON = Format{
+		Name:               "ndjson",
+		ContentType:        MIMEApplicationNDJSON,
+		ProblemContentType: MIMEApplicationProblemJSON,
+		Aliases:            []string{MIMEApplicationNDJSON, "application/ndjson", "application/jsonl"},
+		ResponseOnly:       true,
+		Marshal:            marshalNDJSON,
+		Unmarshal:          json.Unmarshal,
+	}
+)
+
+// formats lists the supported formats by order of preference
+var formats = []Format{FormatJSON, FormatXML, FormatMsgPack, FormatNDJSON}
+
+// Negotiate is a middleware which negotiates the format of the requests and the responses.
+//
+// The response format is chosen from the Accept header (JSON by default) and used by JSON, Created and
+// HTTPError.SendError. Request bodies are decoded by Bind according to their Content-Type (see decodeBody).
+// An unsupported Accept header returns 406 and an unsupported Content-Type returns 415.
+func Negotiate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept")
+
+		format, err := AcceptedFormat(r.Header.Get("Accept"))
+		if err != nil {
+			Err(w, StatusNotAcceptable, err, "Not acceptable", fmt.Sprintf("supported media types: %s", strings.Join(supportedMediaTypes(true), ", ")))
+			return
+		}
+		nw := &negotiatedWriter{ResponseWriter: w, format: format}
+
+		if hasBody(r) {
+			if err := checkContentType(r); err != nil {
+				Err(nw, StatusUnsupportedMediaType, err, "Unsupported media type", fmt.Sprintf("supported media types: %s", strings.Join(supportedMediaTypes(false), ", ")))
+				return
+			}
+		}
+
+		next.ServeHTTP(nw, r)
+	})
+}
+
+// AcceptedFormat returns the preferred supported format of an Accept header.
+// An empty header or a wildcard accepts JSON.
+func AcceptedFormat(accept string) (Format, error) {
+	if strings.TrimSpace(accept) == "" {
+		return FormatJSON, nil
+	}
+
+	var best *Format
+	bestQ := 0.0
+	for part := range strings.SplitSeq(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		if q <= bestQ {
+			continue
+		}
+
+		if f, ok := formatOfMediaType(mediaType, true); ok {
+			best, bestQ = &f, q
+		}
+	}
+
+	if best == nil {
+		return Format{}, fmt.Errorf("%w: %s", ErrNotAcceptable, accept)
+	}
+	return *best, nil
+}
+
+// AcceptedLanguage returns the supported language preferred by an Accept-Language header,
+// an empty string if none is accepted. Languages are matched on their primary subtag ("fr-FR" matches "fr").
+func AcceptedLanguage(acceptLanguage string, supported []string) string {
+	best := ""
+	bestQ := 0.0
+	for part := range strings.SplitSeq(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if primary == "" {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			var err error
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		if q <= bestQ {
+			continue
+		}
+
+		if primary == "*" && len(supported) > 0 {
+			best, bestQ = supported[0], q
+		} else if slices.Contains(supported, primary) {
+			best, bestQ = primary, q
+		}
+	}
+
+	return best
+}
+
+// Locale returns the supported locale of the messages preferred by the Accept-Language header of a request,
+// the default locale if none is accepted.
+func Locale(r *http.Request) string {
+	if locale := AcceptedLanguage(r.Header.Get("Accept-Language"), validation.Locales); locale != "" {
+		return locale
+	}
+	return validation.DefaultLocale
+}
+
+// SetContentLanguage sets the language of a response whose messages depend on the Accept-Language header
+func SetContentLanguage(w http.ResponseWriter, locale string) {
+	w.Header().Add("Vary", "Accept-Language")
+	w.Header().Set("Content-Language", locale)
+}
+
+// ContentFormat returns the format of a Content-Type header.
+// An empty header is considered as JSON.
+func ContentFormat(contentType string) (Format, error) {
+	if strings.TrimSpace(contentType) == "" {
+		return FormatJSON, nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return Format{}, fmt.Errorf("%w: %s", ErrUnsupportedMediaType, contentType)
+	}
+
+	f, ok := formatOfMediaType(mediaType, false)
+	if !ok {
+		return Format{}, fmt.Errorf("%w: %s", ErrUnsupportedMediaType, contentType)
+	}
+	return f, nil
+}
+
+// ResponseFormat returns the format negotiated by the Negotiate middleware, JSON otherwise.
+func ResponseFormat(w http.ResponseWriter) Format {
+	for w != nil {
+		if nw, ok := w.(*negotiatedWriter); ok {
+			return nw.format
+		}
+
+		u, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			break
+		}
+		w = u.Unwrap()
+	}
+
+	return FormatJSON
+}
+
+// negotiatedWriter is a http.ResponseWriter carrying the negotiated response format
+type negotiatedWriter struct {
+	http.ResponseWriter
+	format Format
+}
+
+// Unwrap returns the original http.ResponseWriter
+func (w *negotiatedWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Flush implements http.Flusher
+func (w *negotiatedWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// formatOfMediaType returns the format of a media type.
+// Wildcards and response only formats are only allowed for the Accept header.
+func formatOfMediaType(mediaType string, accept bool) (Format, bool) {
+	if accept && (mediaType == "*/*" || mediaType == "application/*") {
+		return FormatJSON, true
+	}
+	if accept && mediaType == "text/*" {
+		return FormatXML, true
+	}
+
+	for _, f := range formats {
+		if f.ResponseOnly && !accept {
+			continue
+		}
+		if slices.Contains(f.Aliases, mediaType) {
+			return f, true
+		}
+	}
+	return Format{}, false
+}
+
+// supportedMediaTypes returns the media types of the formats supported for the responses (accept)
+// or for the request bodies
+func supportedMediaTypes(accept bool) []string {
+	var types []string
+	for _, f := range formats {
+		if f.ResponseOnly && !accept {
+			continue
+		}
+		types = append(types, f.Aliases...)
+	}
+	return types
+}
+
+// hasBody returns true if the request has a body
+func hasBody(r *http.Request) bool {
+	return r.Body != nil && r.Body != http.NoBody && r.ContentLength != 0
+}
+
+// checkContentType returns an error if the media type of the request body is not supported.
+// Form bodies are accepted.
+func checkContentType(r *http.Request) error {
+	if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err == nil && (mediaType == MIMEApplicationForm || mediaType == MIMEMultipartForm) {
+		return nil
+	}
+
+	_, err := ContentFormat(r.Header.Get("Content-Type"))
+	return err
+}
+
+// marshalXML encodes a value in XML.
+// Slices have no root element in XML, so they are wrapped in a <data> element.
+func marshalXML(v any) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		v = struct {
+			XMLName xml.Name `xml:"data"`
+			Items   any      `xml:"item"`
+		}{Items: v}
+	}
+
+	data, err := xml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// marshalMsgPack encodes a value in MessagePack using the json struct tags
+func marshalMsgPack(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+	enc.UseCompactInts(true)
+
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// marshalNDJSON encodes a value in newline delimited JSON: one line by element for a slice
+func marshalNDJSON(v any) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for i := range rv.Len() {
+		if err := enc.Encode(rv.Index(i).Interface()); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// unmarshalMsgPack decodes a MessagePack value using the json struct tags
+func unmarshalMsgPack(data []byte, v any) error {
+	return newMsgPackDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// newMsgPackDecoder returns a MessagePack decoder using the json struct tags
+func newMsgPackDecoder(r io.Reader) *msgpack.Decoder {
+	dec := msgpack.NewDecoder(r)
+	dec.SetCustomStructTag("json")
+	dec.SetMapDecoder(func(d *msgpack.Decoder) (any, error) {
+		return d.DecodeUntypedMap()
+	})
+	return dec
+}
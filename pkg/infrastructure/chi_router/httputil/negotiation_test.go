@@ -0,0 +1,210 @@
+package httputil
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+type testUser struct {
+	Email  string   `json:"email" xml:"email"`
+	Scopes []string `json:"scopes" xml:"scopes"`
+}
+
+// echoHandler decodes a JSON body and sends it back like the API handlers
+func echoHandler(w http.ResponseWriter, r *http.Request) {
+	ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+	body, err := Bind[testUser](ww, r)
+	if err != nil {
+		return
+	}
+	Created(ww, body)
+}
+
+func TestAcceptedFormat(t *testing.T) {
+	tests := []struct {
+		name       string
+		accept     string
+		wantedType string
+		wantedErr  error
+	}{
+		{name: "Empty header", accept: "", wantedType: MIMEApplicationJSON},
+		{name: "Wildcard", accept: "*/*", wantedType: MIMEApplicationJSON},
+		{name: "XML", accept: "text/xml", wantedType: MIMEApplicationXML},
+		{name: "MessagePack alias", accept: "application/x-msgpack", wantedType: MIMEApplicationMsgPack},
+		{name: "NDJSON alias", accept: "application/jsonl", wantedType: MIMEApplicationNDJSON},
+		{name: "Quality", accept: "application/json;q=0.5, application/xml;q=0.9", wantedType: MIMEApplicationXML},
+		{name: "Browser", accept: "text/html,application/xhtml+xml,*/*;q=0.8", wantedType: MIMEApplicationJSON},
+		{name: "Not acceptable", accept: "text/html", wantedErr: ErrNotAcceptable},
+		{name: "Refused", accept: "application/json;q=0", wantedErr: ErrNotAcceptable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := AcceptedFormat(tt.accept)
+			if tt.wantedErr != nil {
+				assert.ErrorIs(t, err, tt.wantedErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantedType, format.ContentType)
+		})
+	}
+}
+
+func TestAcceptedLanguage(t *testing.T) {
+	supported := []string{"en", "fr"}
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		wanted         string
+	}{
+		{name: "Empty header", acceptLanguage: "", wanted: ""},
+		{name: "Region", acceptLanguage: "fr-FR", wanted: "fr"},
+		{name: "Quality", acceptLanguage: "en;q=0.8, fr-CA;q=0.9, de", wanted: "fr"},
+		{name: "Wildcard", acceptLanguage: "de, *;q=0.5", wanted: "en"},
+		{name: "Not supported", acceptLanguage: "de-DE, es", wanted: ""},
+		{name: "Refused", acceptLanguage: "fr;q=0", wanted: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wanted, AcceptedLanguage(tt.acceptLanguage, supported))
+		})
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	h := Negotiate(http.HandlerFunc(echoHandler))
+
+	tests := []struct {
+		name              string
+		contentType       string
+		accept            string
+		body              []byte
+		wantedStatus      int
+		wantedContentType string
+		wantedUser        testUser
+	}{
+		{
+			name:              "JSON",
+			contentType:       MIMEApplicationJSON,
+			body:              []byte(`{"email":"john@test.com","scopes":["users:read"]}`),
+			wantedStatus:      http.StatusCreated,
+			wantedContentType: MIMEApplicationJSON,
+			wantedUser:        testUser{Email: "john@test.com", Scopes: []string{"users:read"}},
+		},
+		{
+			name:              "XML request and response",
+			contentType:       "application/xml; charset=utf-8",
+			accept:            MIMEApplicationXML,
+			body:              []byte(`<user><email>john@test.com</email><scopes>users:read</scopes><scopes>users:write</scopes></user>`),
+			wantedStatus:      http.StatusCreated,
+			wantedContentType: MIMEApplicationXML,
+			wantedUser:        testUser{Email: "john@test.com", Scopes: []string{"users:read", "users:write"}},
+		},
+		{
+			name:              "XML array with one element",
+			contentType:       MIMETextXML,
+			body:              []byte(`<user><email>john@test.com</email><scopes>users:read</scopes></user>`),
+			wantedStatus:      http.StatusCreated,
+			wantedContentType: MIMEApplicationJSON,
+			wantedUser:        testUser{Email: "john@test.com", Scopes: []string{"users:read"}},
+		},
+		{
+			name:              "MessagePack request and response",
+			contentType:       MIMEApplicationMsgPack,
+			accept:            MIMEApplicationMsgPack,
+			body:              mustMarshal(t, FormatMsgPack, testUser{Email: "john@test.com", Scopes: []string{"users:read"}}),
+			wantedStatus:      http.StatusCreated,
+			wantedContentType: MIMEApplicationMsgPack,
+			wantedUser:        testUser{Email: "john@test.com", Scopes: []string{"users:read"}},
+		},
+		{
+			name:              "Not acceptable",
+			contentType:       MIMEApplicationJSON,
+			accept:            "text/html",
+			body:              []byte(`{}`),
+			wantedStatus:      http.StatusNotAcceptable,
+			wantedContentType: MIMEApplicationProblemJSON,
+		},
+		{
+			name:              "Unsupported media type",
+			contentType:       "text/plain",
+			accept:            MIMEApplicationXML,
+			body:              []byte(`email`),
+			wantedStatus:      http.StatusUnsupportedMediaType,
+			wantedContentType: MIMEApplicationProblemXML,
+		},
+		{
+			name:              "NDJSON request",
+			contentType:       MIMEApplicationNDJSON,
+			body:              []byte(`{"email":"john@test.com"}`),
+			wantedStatus:      http.StatusUnsupportedMediaType,
+			wantedContentType: MIMEApplicationProblemJSON,
+		},
+		{
+			name:              "Invalid XML",
+			contentType:       MIMEApplicationXML,
+			body:              []byte(`<user>`),
+			wantedStatus:      http.StatusBadRequest,
+			wantedContentType: MIMEApplicationProblemJSON,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(string(tt.body)))
+			req.Header.Set("Content-Type", tt.contentType)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantedStatus, rec.Code)
+			assert.Equal(t, tt.wantedContentType, rec.Header().Get("Content-Type"))
+			if tt.wantedStatus != http.StatusCreated {
+				return
+			}
+
+			format, err := ContentFormat(rec.Header().Get("Content-Type"))
+			assert.Nil(t, err)
+			body, _ := io.ReadAll(rec.Body)
+
+			var user testUser
+			assert.Nil(t, format.Unmarshal(body, &user))
+			assert.Equal(t, tt.wantedUser, user)
+		})
+	}
+}
+
+func TestSendErrorFallsBackToJSON(t *testing.T) {
+	rec := httptest.NewRecorder()
+	w := &negotiatedWriter{ResponseWriter: rec, format: FormatXML}
+
+	// A map cannot be encoded in XML
+	err := Err400(w, errors.New("invalid"), "Invalid parameters", map[string]string{"email": "required"})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"type":"urn:problem-type:bad_request","title":"Invalid parameters","status":400,"code":"bad_request","details":{"email":"required"}}`, rec.Body.String())
+}
+
+func mustMarshal(t *testing.T, format Format, v any) []byte {
+	data, err := format.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
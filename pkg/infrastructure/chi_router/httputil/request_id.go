@@ -0,0 +1,43 @@
+package httputil
+
+import "net/http"
+
+// WithRequestID returns a http.ResponseWriter carrying the ID of the request, sent in the errors (see RequestID).
+func WithRequestID(w http.ResponseWriter, id string) http.ResponseWriter {
+	return &requestIDWriter{ResponseWriter: w, id: id}
+}
+
+// RequestID returns the ID of the request carried by the writer (see WithRequestID), an empty string otherwise.
+func RequestID(w http.ResponseWriter) string {
+	for w != nil {
+		if rw, ok := w.(*requestIDWriter); ok {
+			return rw.id
+		}
+
+		u, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			break
+		}
+		w = u.Unwrap()
+	}
+
+	return ""
+}
+
+// requestIDWriter is a http.ResponseWriter carrying the ID of the request
+type requestIDWriter struct {
+	http.ResponseWriter
+	id string
+}
+
+// Unwrap returns the original http.ResponseWriter
+func (w *requestIDWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Flush implements http.Flusher
+func (w *requestIDWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,126 @@
+package httputil
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+)
+
+// streamBufferSize is the size of the buffer of the streamed responses
+const streamBufferSize = 32 << 10
+
+var (
+	// ErrInvalidStreamMeta is returned when the meta of a streamed list is not encoded as a JSON object
+	ErrInvalidStreamMeta = errors.New("stream meta must be encoded as a JSON object")
+
+	// ErrResponseTruncated is returned when a streamed response ends early after its status is sent
+	ErrResponseTruncated = errors.New("response truncated")
+)
+
+// StreamedList is a list response sent element by element
+type StreamedList[T any] struct {
+	// Meta is sent as a JSON object with the items in its "data" member, a bare array is sent if it is nil
+	Meta any
+
+	// Items are the elements of the list
+	Items iter.Seq[T]
+
+	// Whole returns the response sent in the formats which cannot be streamed (XML and MessagePack)
+	Whole func() any
+
+	// Err returns the error which has ended Items early (optional)
+	Err func() error
+}
+
+// Stream sends a list in the negotiated format without building the whole response in memory.
+//
+// In JSON, the elements are encoded one by one in the "data" member of the meta object. In NDJSON, each element
+// is sent on its own line without the meta. Other formats send the response built by Whole.
+//
+// The first element is read before sending the status, so that an error of Items at this point is returned
+// without sending a response. An error of Items (or of the encoding) after the status is sent is wrapped in
+// ErrResponseTruncated: the caller must abort the response (see handlers.WrapError), so that a client cannot take
+// it for a complete one.
+func Stream[T any](w http.ResponseWriter, status int, list StreamedList[T]) error {
+	format := ResponseFormat(w)
+	if format.ContentType != MIMEApplicationJSON && format.ContentType != MIMEApplicationNDJSON {
+		whole := list.Whole()
+		if err := list.err(); err != nil {
+			return err
+		}
+		return Send(w, status, whole)
+	}
+	ndjson := format.ContentType == MIMEApplicationNDJSON
+
+	// The meta is encoded before sending the status to be able to send an error
+	var prefix, suffix []byte
+	if !ndjson {
+		prefix, suffix = []byte("["), []byte("]")
+		if list.Meta != nil {
+			meta, err := json.Marshal(list.Meta)
+			if err != nil {
+				return Err500(w, err, "error when encoding the response", nil)
+			}
+			meta = bytes.TrimSpace(meta)
+			if len(meta) < 2 || meta[0] != '{' || meta[len(meta)-1] != '}' {
+				return Err500(w, ErrInvalidStreamMeta, "error when encoding the response", nil)
+			}
+
+			prefix = meta[:len(meta)-1]
+			if len(prefix) > 1 {
+				prefix = append(prefix, ',')
+			}
+			prefix = append(prefix, `"data":[`...)
+			suffix = []byte("]}")
+		}
+	}
+
+	next, stop := iter.Pull(list.Items)
+	defer stop()
+
+	item, ok := next()
+	if !ok {
+		if err := list.err(); err != nil {
+			return err
+		}
+	}
+
+	w.Header().Set("Content-Type", format.ContentType)
+	w.WriteHeader(status)
+
+	bw := bufio.NewWriterSize(w, streamBufferSize)
+	bw.Write(prefix)
+
+	enc := json.NewEncoder(bw)
+	for first := true; ok; item, ok = next() {
+		if !ndjson && !first {
+			bw.WriteByte(',')
+		}
+		first = false
+
+		// The status is already sent: an element which cannot be encoded ends the response
+		if err := enc.Encode(item); err != nil {
+			bw.Flush()
+			return fmt.Errorf("%w: %w", ErrResponseTruncated, err)
+		}
+	}
+	if err := list.err(); err != nil {
+		bw.Flush()
+		return fmt.Errorf("%w: %w", ErrResponseTruncated, err)
+	}
+
+	bw.Write(suffix)
+	return bw.Flush()
+}
+
+// err returns the error of the items
+func (l StreamedList[T]) err() error {
+	if l.Err == nil {
+		return nil
+	}
+	return l.Err()
+}
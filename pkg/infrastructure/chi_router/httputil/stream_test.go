@@ -0,0 +1,168 @@
+package httputil
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testPage struct {
+	Page  int `json:"page" xml:"page"`
+	Total int `json:"total" xml:"total"`
+}
+
+type testUsersPage struct {
+	XMLName struct{}   `xml:"users"`
+	Data    []testUser `xml:"data"`
+	Page    int        `xml:"page"`
+}
+
+func TestStream(t *testing.T) {
+	users := []testUser{
+		{Email: "john@test.com", Scopes: []string{"users:read"}},
+		{Email: "jane@test.com", Scopes: []string{}},
+	}
+
+	tests := []struct {
+		name              string
+		format            Format
+		meta              any
+		items             []testUser
+		wantedStatus      int
+		wantedContentType string
+		wantedBody        string
+	}{
+		{
+			name:              "JSON with meta",
+			format:            FormatJSON,
+			meta:              testPage{Page: 1, Total: 2},
+			items:             users,
+			wantedStatus:      http.StatusOK,
+			wantedContentType: MIMEApplicationJSON,
+			wantedBody:        `{"page":1,"total":2,"data":[{"email":"john@test.com","scopes":["users:read"]},{"email":"jane@test.com","scopes":[]}]}`,
+		},
+		{
+			name:              "JSON without meta",
+			format:            FormatJSON,
+			items:             users,
+			wantedStatus:      http.StatusOK,
+			wantedContentType: MIMEApplicationJSON,
+			wantedBody:        `[{"email":"john@test.com","scopes":["users:read"]},{"email":"jane@test.com","scopes":[]}]`,
+		},
+		{
+			name:              "JSON empty list",
+			format:            FormatJSON,
+			meta:              struct{}{},
+			wantedStatus:      http.StatusOK,
+			wantedContentType: MIMEApplicationJSON,
+			wantedBody:        `{"data":[]}`,
+		},
+		{
+			name:              "NDJSON",
+			format:            FormatNDJSON,
+			meta:              testPage{Page: 1, Total: 2},
+			items:             users,
+			wantedStatus:      http.StatusOK,
+			wantedContentType: MIMEApplicationNDJSON,
+			wantedBody:        "{\"email\":\"john@test.com\",\"scopes\":[\"users:read\"]}\n{\"email\":\"jane@test.com\",\"scopes\":[]}\n",
+		},
+		{
+			name:              "XML",
+			format:            FormatXML,
+			items:             users[:1],
+			wantedStatus:      http.StatusOK,
+			wantedContentType: MIMEApplicationXML,
+			wantedBody:        "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n" + `<users><data><email>john@test.com</email><scopes>users:read</scopes></data><page>1</page></users>`,
+		},
+		{
+			name:              "Invalid meta",
+			format:            FormatJSON,
+			meta:              []int{1},
+			wantedStatus:      http.StatusInternalServerError,
+			wantedContentType: MIMEApplicationProblemJSON,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			w := &negotiatedWriter{ResponseWriter: rec, format: tt.format}
+
+			Stream(w, http.StatusOK, StreamedList[testUser]{
+				Meta:  tt.meta,
+				Items: slices.Values(tt.items),
+				Whole: func() any {
+					return testUsersPage{Data: tt.items, Page: 1}
+				},
+			})
+
+			assert.Equal(t, tt.wantedStatus, rec.Code)
+			assert.Equal(t, tt.wantedContentType, rec.Header().Get("Content-Type"))
+			if tt.wantedStatus != http.StatusOK {
+				return
+			}
+			if tt.format.ContentType == MIMEApplicationJSON {
+				assert.JSONEq(t, tt.wantedBody, rec.Body.String())
+			} else {
+				assert.Equal(t, tt.wantedBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestStreamError(t *testing.T) {
+	errItems := errors.New("items error")
+	users := []testUser{{Email: "john@test.com", Scopes: []string{}}}
+
+	tests := []struct {
+		name         string
+		format       Format
+		items        []testUser
+		wantedStatus int
+		wantedBody   string
+	}{
+		{name: "Before the first element", format: FormatJSON, wantedBody: ""},
+		{name: "After the first element", format: FormatJSON, items: users, wantedStatus: http.StatusOK, wantedBody: `{"page":1,"total":2,"data":[{"email":"john@test.com","scopes":[]}` + "\n"},
+		{name: "Whole response", format: FormatXML, items: users, wantedBody: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			w := &negotiatedWriter{ResponseWriter: rec, format: tt.format}
+
+			var err error
+			items := func(yield func(testUser) bool) {
+				for _, user := range tt.items {
+					if !yield(user) {
+						return
+					}
+				}
+				err = errItems
+			}
+
+			errStream := Stream(w, http.StatusOK, StreamedList[testUser]{
+				Meta:  testPage{Page: 1, Total: 2},
+				Items: items,
+				Whole: func() any {
+					return testUsersPage{Data: slices.Collect(items), Page: 1}
+				},
+				Err: func() error {
+					return err
+				},
+			})
+
+			assert.ErrorIs(t, errStream, errItems)
+			assert.Equal(t, tt.wantedStatus != 0, errors.Is(errStream, ErrResponseTruncated))
+			if tt.wantedStatus == 0 {
+				assert.False(t, rec.Flushed)
+				assert.Empty(t, rec.Header().Get("Content-Type"))
+			}
+			assert.Equal(t, tt.wantedBody, rec.Body.String())
+		})
+	}
+}
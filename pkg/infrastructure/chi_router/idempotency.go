@@ -0,0 +1,179 @@
+package chi_router
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"go-clean-api/pkg/domain/usecases"
+	"go-clean-api/pkg/infrastructure/chi_router/handlers"
+	"go-clean-api/pkg/infrastructure/chi_router/httputil"
+	"go-clean-api/pkg/infrastructure/logger"
+	"io"
+	"maps"
+	"net/http"
+	"strconv"
+)
+
+const (
+	// IdempotencyKeyHeader is the header containing the idempotency key of a request
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// IdempotentReplayedHeader is set on the responses replayed from a previous request
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	// idempotencyKeyMaxLength is the maximum length of an idempotency key
+	idempotencyKeyMaxLength = 255
+)
+
+// idempotencyIgnoredHeaders are the response headers which are not replayed (with the request ID header).
+// The transport headers are set again by the middlewares (the stored body is not compressed).
+var idempotencyIgnoredHeaders = []string{
+	"Content-Encoding",
+	"Content-Length",
+	"Vary",
+}
+
+// idempotency makes the unsafe requests with an Idempotency-Key header idempotent.
+//
+// The response of the first request is stored and replayed for the duplicate requests with the same key.
+// A duplicate sent while the first request is in progress returns 409 and a key reused with another
+// request (method, path or body) returns 422. Server errors are not stored, so the request can be retried.
+func (s *ChiServer) idempotency(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" || isSafeMethod(r.Method) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(key) > idempotencyKeyMaxLength {
+			httputil.Err400(w, nil, "Invalid idempotency key", "idempotency key must not exceed "+strconv.Itoa(idempotencyKeyMaxLength)+" characters")
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			httputil.Err400(w, err, "Error when reading the body", nil)
+			return
+		}
+		r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		auth, _ := handlers.AuthFromContext(r.Context())
+		res, err := s.IdempotencyUseCase.Begin(usecases.BeginIdempotencyRequest{
+			Key:         key,
+			UserID:      auth.UserID,
+			Fingerprint: requestFingerprint(r, body),
+		})
+		if err != nil {
+			if !errors.Is(err, usecases.ErrIdempotencyKeyReused) && !errors.Is(err, usecases.ErrIdempotencyKeyInUse) {
+				logger.FromContext(r.Context(), s.Logger).Error(err.Error(), logger.Fields{})
+			}
+			handlers.SendError(w, err)
+			return
+		}
+
+		if res.Replay != nil {
+			h := w.Header()
+			for name, values := range res.Replay.ResponseHeaders {
+				h[name] = values
+			}
+			h.Set(IdempotentReplayedHeader, "true")
+			w.WriteHeader(res.Replay.ResponseStatus)
+			w.Write(res.Replay.ResponseBody)
+			return
+		}
+
+		rec := &idempotencyRecorder{ResponseWriter: w}
+		completed := false
+		defer func() {
+			// The key is released if the request has panicked or failed
+			if !completed {
+				s.releaseIdempotencyKey(res.Key)
+			}
+		}()
+
+		next.ServeHTTP(rec, r)
+
+		status := rec.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		if status >= http.StatusInternalServerError {
+			return
+		}
+
+		headers := maps.Clone(map[string][]string(w.Header()))
+		delete(headers, s.requestIDHeader())
+		for _, h := range idempotencyIgnoredHeaders {
+			delete(headers, h)
+		}
+
+		if _, err := s.IdempotencyUseCase.Complete(usecases.CompleteIdempotencyRequest{
+			Key:             res.Key,
+			ResponseStatus:  status,
+			ResponseHeaders: headers,
+			ResponseBody:    rec.body.Bytes(),
+		}); err != nil {
+			logger.FromContext(r.Context(), s.Logger).Error(err.Error(), logger.Fields{})
+			return
+		}
+		completed = true
+	})
+}
+
+// releaseIdempotencyKey deletes a key so that the request can be retried
+func (s *ChiServer) releaseIdempotencyKey(key string) {
+	if _, err := s.IdempotencyUseCase.Release(usecases.ReleaseIdempotencyRequest{Key: key}); err != nil {
+		s.Logger.Error(err.Error(), logger.Fields{})
+	}
+}
+
+// requestFingerprint returns the SHA-256 hash of the method, the path and the body of a request
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// isSafeMethod returns true for the HTTP methods which do not modify resources
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions || method == http.MethodTrace
+}
+
+// idempotencyRecorder is a http.ResponseWriter which keeps a copy of the response status and body
+type idempotencyRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+// WriteHeader implements http.ResponseWriter
+func (r *idempotencyRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write implements http.ResponseWriter
+func (r *idempotencyRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the original http.ResponseWriter
+func (r *idempotencyRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// Flush implements http.Flusher
+func (r *idempotencyRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,135 @@
+package chi_router
+
+import (
+	"encoding/json"
+	"go-clean-api/pkg/adapters/repositories/memory"
+	"go-clean-api/pkg/domain/usecases"
+	"go-clean-api/pkg/infrastructure/chi_router/httputil"
+	"go-clean-api/pkg/infrastructure/logger"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newIdempotencyTestServer() *ChiServer {
+	return &ChiServer{
+		Logger:             logger.NewNopLogger(),
+		IdempotencyUseCase: usecases.NewIdempotency(memory.NewIdempotencyKey(), usecases.IdempotencyConfig{}),
+	}
+}
+
+func newIdempotencyTestRequest(key, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+	req.Header.Set(IdempotencyKeyHeader, key)
+	return req
+}
+
+func TestIdempotencyReplaysResponse(t *testing.T) {
+	s := newIdempotencyTestServer()
+	calls := 0
+	h := s.idempotency(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Location", "/users/1")
+		httputil.Created(w, map[string]string{"body": string(body)})
+	}))
+
+	first := httptest.NewRecorder()
+	h.ServeHTTP(first, newIdempotencyTestRequest("key", `{"email":"john@test.com"}`))
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Empty(t, first.Header().Get(IdempotentReplayedHeader))
+
+	second := httptest.NewRecorder()
+	h.ServeHTTP(second, newIdempotencyTestRequest("key", `{"email":"john@test.com"}`))
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Equal(t, "true", second.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, "/users/1", second.Header().Get("Location"))
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, 1, calls)
+
+	// Key reused with another body
+	reused := httptest.NewRecorder()
+	h.ServeHTTP(reused, newIdempotencyTestRequest("key", `{"email":"jane@test.com"}`))
+	assert.Equal(t, http.StatusUnprocessableEntity, reused.Code)
+	assert.Equal(t, usecases.CodeIdempotencyKeyReused, problemCode(t, reused))
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotencyConcurrentRequest(t *testing.T) {
+	s := newIdempotencyTestServer()
+	var duplicate *httptest.ResponseRecorder
+	var h http.Handler
+	h = s.idempotency(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A duplicate is received while the first request is in progress
+		duplicate = httptest.NewRecorder()
+		h.ServeHTTP(duplicate, newIdempotencyTestRequest("key", `{}`))
+		httputil.Created(w, nil)
+	}))
+
+	h.ServeHTTP(httptest.NewRecorder(), newIdempotencyTestRequest("key", `{}`))
+
+	assert.Equal(t, http.StatusConflict, duplicate.Code)
+	assert.Equal(t, usecases.CodeIdempotencyKeyInUse, problemCode(t, duplicate))
+}
+
+func TestIdempotencyServerErrorIsNotStored(t *testing.T) {
+	s := newIdempotencyTestServer()
+	calls := 0
+	h := s.idempotency(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			httputil.Err500(w, nil, "Internal Server Error", nil)
+			return
+		}
+		httputil.Created(w, nil)
+	}))
+
+	first := httptest.NewRecorder()
+	h.ServeHTTP(first, newIdempotencyTestRequest("key", `{}`))
+	assert.Equal(t, http.StatusInternalServerError, first.Code)
+
+	retry := httptest.NewRecorder()
+	h.ServeHTTP(retry, newIdempotencyTestRequest("key", `{}`))
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotencyIgnoredRequests(t *testing.T) {
+	s := newIdempotencyTestServer()
+	calls := 0
+	h := s.idempotency(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// Without key
+	h.ServeHTTP(httptest.NewRecorder(), newIdempotencyTestRequest("", `{}`))
+	h.ServeHTTP(httptest.NewRecorder(), newIdempotencyTestRequest("", `{}`))
+
+	// Safe method
+	for range 2 {
+		req := httptest.NewRequest(http.MethodGet, "/users", nil)
+		req.Header.Set(IdempotencyKeyHeader, "key")
+		h.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	assert.Equal(t, 4, calls)
+
+	// Too long key
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, newIdempotencyTestRequest(strings.Repeat("k", idempotencyKeyMaxLength+1), `{}`))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, 4, calls)
+}
+
+// problemCode returns the code of a problem details response
+func problemCode(t *testing.T, rec *httptest.ResponseRecorder) string {
+	var problem httputil.HTTPError
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatal(err)
+	}
+	return problem.Code
+}
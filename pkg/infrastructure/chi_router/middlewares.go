@@ -1,16 +1,17 @@
 package chi_router
 
 import (
-	"context"
+	"errors"
 	"fmt"
+	"go-clean-api/pkg/domain/usecases"
 	"go-clean-api/pkg/infrastructure/chi_router/handlers"
 	"go-clean-api/pkg/infrastructure/logger"
 	"go-clean-api/pkg/infrastructure/auth"
 	"go-clean-api/pkg/infrastructure/chi_router/httputil"
 	"net/http"
+	"strings"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/lestrrat-go/jwx/v2/jwt"
 
 	"github.com/go-chi/chi/v5"
@@ -34,7 +35,7 @@ func (s *ChiServer) initJWTToken() error {
 }
 
 func (s *ChiServer) initMiddlewares(r *chi.Mux) {
-	r.Use(s.requestID) // Must be before the access logger
+	r.Use(s.initRequestID()) // Must be before the access logger and RealIP
 	if s.Config.Log.EnableAccessLog {
 		r.Use(s.initAccessLogger())
 	}
@@ -44,7 +45,16 @@ func (s *ChiServer) initMiddlewares(r *chi.Mux) {
 		r.Use(middleware.RequestSize(s.Config.Server.MaxRequestSize << 10))
 	}
 
-	r.Use(middleware.Recoverer)
+	// Response compression
+	if s.Config.Compression.Enable {
+		r.Use(httputil.Compress(httputil.CompressOptions{
+			Encodings:    s.Config.Compression.Encodings,
+			MinSize:      s.Config.Compression.MinSize,
+			ContentTypes: s.Config.Compression.ContentTypes,
+		}))
+	}
+
+	r.Use(s.initRecoverer())
 	r.Use(middleware.Timeout(time.Duration(s.Config.Server.Timeout) * time.Second))
 	r.Use(middleware.RealIP)
 
@@ -75,14 +85,14 @@ func (s *ChiServer) initAccessLogger() func(next http.Handler) http.Handler {
 			stop := time.Since(start)
 			url := r.Host + r.RequestURI
 			fields := logger.Fields{
-				logger.NewField("code", "int", ww.Status()),
-				logger.NewField("method", "string", r.Method),
-				logger.NewField("path", "string", r.URL.Path),
-				logger.NewField("url", "string", url),
-				logger.NewField("ip", "string", r.RemoteAddr),
-				logger.NewField("userAgent", "string", r.UserAgent()),
-				logger.NewField("latency", "string", stop.String()),
-				logger.NewField("request_id", "string", requestId),
+				logger.Int("code", ww.Status()),
+				logger.String("method", r.Method),
+				logger.String("path", r.URL.Path),
+				logger.String("url", url),
+				logger.String("ip", r.RemoteAddr),
+				logger.String("userAgent", r.UserAgent()),
+				logger.String("latency", stop.String()),
+				logger.String("request_id", requestId),
 			}
 
 			s.Logger.Info("", fields)
@@ -116,14 +126,37 @@ func (s *ChiServer) initBasicAuth() func(next http.Handler) http.Handler {
 // 	return middleware.BasicAuth("Restricted", creds)
 // }
 
-func (s *ChiServer) initJWT(r chi.Router) {
+// APIKeyHeader is the header containing the API key
+const APIKeyHeader = "X-API-Key"
+
+// initAuth authenticates the requests with an API key (X-API-Key header) or a JWT (Authorization: Bearer header)
+func (s *ChiServer) initAuth(r chi.Router) {
 	r.Use(jwtauth.Verifier(tokenAuth))
-	r.Use(s.jwtAuthenticator(tokenAuth))
+	r.Use(s.authenticator(tokenAuth))
 }
 
-func (s *ChiServer) jwtAuthenticator(ja *jwtauth.JWTAuth) func(http.Handler) http.Handler {
+func (s *ChiServer) authenticator(ja *jwtauth.JWTAuth) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		hfn := func(w http.ResponseWriter, r *http.Request) {
+			if key := r.Header.Get(APIKeyHeader); key != "" {
+				res, err := s.APIKeyUseCase.Authenticate(usecases.AuthenticateAPIKeyRequest{Key: key})
+				if err != nil {
+					if !errors.Is(err, usecases.ErrInvalidAPIKey) {
+						logger.FromContext(r.Context(), s.Logger).Error(err.Error(), logger.Fields{})
+					}
+					handlers.SendError(w, err)
+					return
+				}
+
+				ctx := handlers.WithAuth(r.Context(), handlers.Auth{
+					UserID:   res.UserID.String(),
+					APIKeyID: res.ID.String(),
+					Scopes:   res.Scopes,
+				})
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
 			token, _, err := jwtauth.FromContext(r.Context())
 
 			if err != nil {
@@ -137,19 +170,44 @@ func (s *ChiServer) jwtAuthenticator(ja *jwtauth.JWTAuth) func(http.Handler) htt
 			}
 
 			// Token is authenticated, pass it through
-			next.ServeHTTP(w, r)
+			ctx := handlers.WithAuth(r.Context(), handlers.Auth{UserID: token.Subject()})
+			next.ServeHTTP(w, r.WithContext(ctx))
 		}
 		return http.HandlerFunc(hfn)
 	}
 }
 
-func (s *ChiServer) requestID(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		id := uuid.New().String()
-		ctx := context.WithValue(r.Context(), handlers.RequestIDKey("request_id"), id)
+// requireScope restricts the access of the API keys with scopes to a resource.
+// The scope is "<resource>:read" for safe methods and "<resource>:write" otherwise.
+func (s *ChiServer) requireScope(resource string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scope := resource + ":write"
+			if r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions {
+				scope = resource + ":read"
+			}
+
+			if auth, _ := handlers.AuthFromContext(r.Context()); !auth.HasScope(scope) {
+				httputil.Err403(w, nil, "Forbidden", fmt.Sprintf("API key scope %s is required", scope))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
 
-		w.Header().Add("X-Request-Id", id)
+// traceID returns the trace ID of a W3C traceparent header (version-traceid-parentid-flags),
+// or an empty string if the header is invalid.
+func traceID(traceParent string) string {
+	parts := strings.Split(traceParent, "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || len(parts[1]) != 32 {
+		return ""
+	}
 
-		next.ServeHTTP(w, r.WithContext(ctx))
-	})
+	id := parts[1]
+	if strings.Trim(id, "0") == "" || strings.Trim(id, "0123456789abcdef") != "" {
+		return ""
+	}
+	return id
 }
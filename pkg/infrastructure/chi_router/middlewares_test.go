@@ -0,0 +1,28 @@
+package chi_router
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTraceID(t *testing.T) {
+	tests := []struct {
+		name        string
+		traceParent string
+		wanted      string
+	}{
+		{name: "Valid", traceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", wanted: "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{name: "Empty", traceParent: "", wanted: ""},
+		{name: "Invalid version", traceParent: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", wanted: ""},
+		{name: "Zero trace ID", traceParent: "00-00000000000000000000000000000000-00f067aa0ba902b7-01", wanted: ""},
+		{name: "Uppercase trace ID", traceParent: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", wanted: ""},
+		{name: "Short trace ID", traceParent: "00-4bf92f35-00f067aa0ba902b7-01", wanted: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wanted, traceID(tt.traceParent))
+		})
+	}
+}
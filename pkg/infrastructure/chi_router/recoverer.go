@@ -0,0 +1,117 @@
+package chi_router
+
+import (
+	"errors"
+	"expvar"
+	"fmt"
+	"go-clean-api/pkg/infrastructure/chi_router/handlers"
+	"go-clean-api/pkg/infrastructure/logger"
+	"net/http"
+	"os"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// panicsTotal counts the recovered panics, it is exposed by the profiler at /debug/vars
+var panicsTotal = expvar.NewInt("http_panics_total")
+
+// initRecoverer recovers from the panics of the handlers.
+// The panic value, the stack trace and the request context are logged with the request-scoped logger,
+// the client receives the standard 500 error and the http_panics_total metric is incremented.
+// If the handler has already written the response (a streamed list for example), the connection is aborted
+// instead, so that the client cannot take the response for a complete one.
+// If a crash dump directory is configured, a panic report is also written in it.
+func (s *ChiServer) initRecoverer() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			defer func() {
+				rvr := recover()
+				if rvr == nil {
+					return
+				}
+				if err, ok := rvr.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+					// The response is aborted on purpose, the HTTP server handles it
+					panic(rvr)
+				}
+
+				s.reportPanic(r, rvr, debug.Stack())
+
+				// The connection of an upgraded request (websocket) is hijacked, no response can be sent
+				if r.Header.Get("Connection") == "Upgrade" {
+					return
+				}
+				if ww.Status() != 0 || ww.BytesWritten() > 0 {
+					panic(http.ErrAbortHandler)
+				}
+				handlers.SendError(ww, fmt.Errorf("panic: %v", rvr))
+			}()
+
+			next.ServeHTTP(ww, r)
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+// reportPanic logs a recovered panic, increments the metric and writes the crash dump
+func (s *ChiServer) reportPanic(r *http.Request, rvr any, stack []byte) {
+	panicsTotal.Add(1)
+
+	l := logger.FromContext(r.Context(), s.Logger)
+	fields := logger.Fields{
+		logger.String("panic", fmt.Sprint(rvr)),
+		logger.String("stack", string(stack)),
+		logger.String("method", r.Method),
+		logger.String("path", r.URL.Path),
+	}
+	route := ""
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		route = rctx.RoutePattern()
+		fields = append(fields, logger.String("route", route))
+	}
+
+	if s.Config.Server.CrashDumpPath != "" {
+		path, err := writeCrashDump(s.Config.Server.CrashDumpPath, r, route, rvr, stack)
+		if err != nil {
+			l.Error("error when writing the crash dump", logger.Fields{logger.Err(err)})
+		} else {
+			fields = append(fields, logger.String("crash_dump", path))
+		}
+	}
+
+	l.Error("panic recovered", fields)
+}
+
+// writeCrashDump writes a panic report in the directory and returns its path.
+// The query string, the headers and the body are not written as they can contain sensitive data.
+func writeCrashDump(dir string, r *http.Request, route string, rvr any, stack []byte) (string, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+
+	now := time.Now().UTC()
+	file, err := os.CreateTemp(dir, "panic-"+now.Format("20060102T150405")+"-*.log")
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	var report strings.Builder
+	fmt.Fprintf(&report, "time: %s\n", now.Format(time.RFC3339Nano))
+	fmt.Fprintf(&report, "request_id: %s\n", handlers.Actor(r).RequestID)
+	fmt.Fprintf(&report, "method: %s\n", r.Method)
+	fmt.Fprintf(&report, "path: %s\n", r.URL.Path)
+	fmt.Fprintf(&report, "route: %s\n", route)
+	fmt.Fprintf(&report, "panic: %v\n\n", rvr)
+	report.Write(stack)
+
+	if _, err := file.WriteString(report.String()); err != nil {
+		return "", err
+	}
+	return file.Name(), nil
+}
@@ -0,0 +1,128 @@
+package chi_router
+
+import (
+	"context"
+	"go-clean-api/pkg"
+	"go-clean-api/pkg/infrastructure/chi_router/handlers"
+	"go-clean-api/pkg/infrastructure/chi_router/httputil"
+	"go-clean-api/pkg/infrastructure/logger"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+)
+
+func newRecovererTestRouter(s *ChiServer) *chi.Mux {
+	r := chi.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), handlers.RequestIDKey("request_id"), "123")
+			ctx = logger.NewContext(ctx, s.Logger.With(logger.Fields{logger.String("request_id", "123")}))
+			next.ServeHTTP(httputil.WithRequestID(w, "123"), r.WithContext(ctx))
+		})
+	})
+	r.Use(s.initRecoverer())
+	r.Get("/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		panic("nil map")
+	})
+	r.Get("/abort", func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	})
+	r.Get("/stream", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", httputil.MIMEApplicationJSON)
+		w.Write([]byte(`{"data":[`))
+		panic("nil map")
+	})
+	return r
+}
+
+func TestRecoverer(t *testing.T) {
+	l := logger.NewMemoryLogger()
+	dir := filepath.Join(t.TempDir(), "crashes")
+	s := &ChiServer{
+		Logger: l,
+		Config: pkg.Config{Server: pkg.ConfigServer{CrashDumpPath: dir}},
+	}
+	panics := panicsTotal.Value()
+
+	res := httptest.NewRecorder()
+	newRecovererTestRouter(s).ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/users/1?token=secret", nil))
+
+	// Standard error
+	assert.Equal(t, http.StatusInternalServerError, res.Code)
+	assert.Equal(t, httputil.MIMEApplicationProblemJSON, res.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"type":"urn:problem-type:internal_error","title":"Internal Server Error","status":500,"code":"internal_error","instance":"123"}`, res.Body.String())
+
+	// Metric
+	assert.Equal(t, panics+1, panicsTotal.Value())
+
+	// Log
+	entries := l.Entries()
+	assert.Equal(t, 1, len(entries))
+	assert.Equal(t, zapcore.ErrorLevel, entries[0].Level)
+	assert.Equal(t, "panic recovered", entries[0].Message)
+	assert.Equal(t, "nil map", entries[0].Fields["panic"])
+	assert.Equal(t, "123", entries[0].Fields["request_id"])
+	assert.Equal(t, "/users/{id}", entries[0].Fields["route"])
+	assert.Equal(t, "/users/1", entries[0].Fields["path"])
+	assert.Contains(t, entries[0].Fields["stack"], "recoverer_test.go")
+
+	// Crash dump
+	path, ok := entries[0].Fields["crash_dump"].(string)
+	assert.True(t, ok)
+	assert.Equal(t, dir, filepath.Dir(path))
+
+	dump, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Contains(t, string(dump), "request_id: 123\n")
+	assert.Contains(t, string(dump), "route: /users/{id}\n")
+	assert.Contains(t, string(dump), "panic: nil map\n")
+	assert.Contains(t, string(dump), "recoverer_test.go")
+	assert.NotContains(t, string(dump), "secret")
+}
+
+func TestRecovererWithoutCrashDump(t *testing.T) {
+	l := logger.NewMemoryLogger()
+	s := &ChiServer{Logger: l}
+
+	res := httptest.NewRecorder()
+	newRecovererTestRouter(s).ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/users/1", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, res.Code)
+	assert.Equal(t, []string{"panic recovered"}, l.Messages(zapcore.ErrorLevel))
+	assert.NotContains(t, l.Entries()[0].Fields, "crash_dump")
+}
+
+func TestRecovererAbortHandler(t *testing.T) {
+	l := logger.NewMemoryLogger()
+	s := &ChiServer{Logger: l}
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		newRecovererTestRouter(s).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/abort", nil))
+	})
+	assert.Empty(t, l.Entries())
+}
+
+func TestRecovererWrittenResponse(t *testing.T) {
+	l := logger.NewMemoryLogger()
+	s := &ChiServer{Logger: l}
+	panics := panicsTotal.Value()
+
+	res := httptest.NewRecorder()
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		newRecovererTestRouter(s).ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/stream", nil))
+	})
+
+	// The written response is not followed by an error
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, `{"data":[`, res.Body.String())
+
+	// The panic is logged and counted
+	assert.Equal(t, panics+1, panicsTotal.Value())
+	assert.Equal(t, []string{"panic recovered"}, l.Messages(zapcore.ErrorLevel))
+}
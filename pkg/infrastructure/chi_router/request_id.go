@@ -0,0 +1,123 @@
+package chi_router
+
+import (
+	"context"
+	"go-clean-api/pkg/infrastructure/chi_router/handlers"
+	"go-clean-api/pkg/infrastructure/chi_router/httputil"
+	"go-clean-api/pkg/infrastructure/logger"
+	"net"
+	"net/http"
+	"net/netip"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+)
+
+// initRequestID returns the middleware setting the ID of the requests.
+//
+// The ID sent in the request ID header by a trusted proxy is kept if it is valid,
+// otherwise a new sortable ID (UUIDv7 or ULID) is generated.
+// The ID is stored in the context with the request-scoped logger (see logger.FromContext),
+// sent in the response header and carried by the response writer for the errors (see httputil.RequestID).
+// The middleware must be before RealIP to check the proxy address.
+func (s *ChiServer) initRequestID() func(next http.Handler) http.Handler {
+	config := s.Config.RequestID
+	header := s.requestIDHeader()
+	proxies := parseTrustedProxies(config.TrustedProxies)
+	generate := requestIDGenerator(config.Generator)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(header)
+			if id == "" || !isTrustedProxy(proxies, r.RemoteAddr) || !isValidRequestID(id, config.MaxLength) {
+				id = generate()
+			}
+			ctx := context.WithValue(r.Context(), handlers.RequestIDKey("request_id"), id)
+
+			fields := logger.Fields{logger.String("request_id", id)}
+			if traceID := traceID(r.Header.Get(httputil.TraceParentHeader)); traceID != "" {
+				fields = append(fields, logger.String("trace_id", traceID))
+			}
+			ctx = logger.NewContext(ctx, s.Logger.With(fields))
+
+			w.Header().Set(header, id)
+
+			next.ServeHTTP(httputil.WithRequestID(w, id), r.WithContext(ctx))
+		})
+	}
+}
+
+// requestIDHeader returns the header containing the request ID
+func (s *ChiServer) requestIDHeader() string {
+	if s.Config.RequestID.Header != "" {
+		return s.Config.RequestID.Header
+	}
+	return httputil.DefaultRequestIDHeader
+}
+
+// requestIDGenerator returns the function generating the request IDs (UUIDv7 by default)
+func requestIDGenerator(name string) func() string {
+	if name == "ulid" {
+		return func() string {
+			return ulid.Make().String()
+		}
+	}
+
+	return func() string {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return uuid.New().String()
+		}
+		return id.String()
+	}
+}
+
+// isValidRequestID returns true if the ID is not too long and contains only
+// letters, digits and the characters - _ . :
+func isValidRequestID(id string, maxLength int) bool {
+	if id == "" || (maxLength > 0 && len(id) > maxLength) {
+		return false
+	}
+
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// parseTrustedProxies parses IPs and CIDRs (validated by the configuration)
+func parseTrustedProxies(values []string) []netip.Prefix {
+	proxies := make([]netip.Prefix, 0, len(values))
+	for _, v := range values {
+		if prefix, err := netip.ParsePrefix(v); err == nil {
+			proxies = append(proxies, prefix.Masked())
+		} else if addr, err := netip.ParseAddr(v); err == nil {
+			proxies = append(proxies, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+		}
+	}
+	return proxies
+}
+
+// isTrustedProxy returns true if the remote address (ip:port) is a trusted proxy
+func isTrustedProxy(proxies []netip.Prefix, remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+
+	for _, p := range proxies {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package chi_router
+
+import (
+	"go-clean-api/pkg"
+	"go-clean-api/pkg/infrastructure/chi_router/handlers"
+	"go-clean-api/pkg/infrastructure/logger"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveRequestID(s *ChiServer, remoteAddr string, header http.Header) (requestID string, res *httptest.ResponseRecorder) {
+	h := s.initRequestID()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID = handlers.Actor(r).RequestID
+		logger.FromContext(r.Context(), nil).Info("request")
+		handlers.SendError(w, http.ErrAbortHandler)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = remoteAddr
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	res = httptest.NewRecorder()
+	h.ServeHTTP(res, req)
+	return
+}
+
+func TestRequestIDFromTrustedProxy(t *testing.T) {
+	l := logger.NewMemoryLogger()
+	s := &ChiServer{
+		Logger: l,
+		Config: pkg.Config{RequestID: pkg.ConfigRequestID{
+			Header:         "X-Correlation-Id",
+			TrustedProxies: []string{"10.0.0.0/8", "192.168.1.1"},
+			Generator:      "uuidv7",
+			MaxLength:      64,
+		}},
+	}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		inbound    string
+		wantedKept bool
+	}{
+		{name: "Trusted proxy", remoteAddr: "10.1.2.3:1234", inbound: "gateway-123:abc", wantedKept: true},
+		{name: "Trusted proxy IP", remoteAddr: "192.168.1.1:1234", inbound: "gateway-123", wantedKept: true},
+		{name: "Untrusted client", remoteAddr: "203.0.113.1:1234", inbound: "gateway-123"},
+		{name: "Invalid characters", remoteAddr: "10.1.2.3:1234", inbound: "gateway 123\n"},
+		{name: "Too long", remoteAddr: "10.1.2.3:1234", inbound: strings.Repeat("a", 65)},
+		{name: "No inbound ID", remoteAddr: "10.1.2.3:1234"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.inbound != "" {
+				header.Set("X-Correlation-Id", tt.inbound)
+			}
+
+			requestID, res := serveRequestID(s, tt.remoteAddr, header)
+
+			if tt.wantedKept {
+				assert.Equal(t, tt.inbound, requestID)
+			} else {
+				id, err := uuid.Parse(requestID)
+				assert.Nil(t, err)
+				assert.Equal(t, uuid.Version(7), id.Version())
+			}
+			assert.Equal(t, requestID, res.Header().Get("X-Correlation-Id"))
+			assert.Contains(t, res.Body.String(), `"instance":"`+requestID+`"`)
+
+			// The request-scoped logger is tagged with the request ID
+			entries := l.Entries()
+			assert.Equal(t, requestID, entries[len(entries)-1].Fields["request_id"])
+		})
+	}
+}
+
+func TestRequestIDHeaderPerServer(t *testing.T) {
+	correlation := &ChiServer{Logger: logger.NewNopLogger(), Config: pkg.Config{RequestID: pkg.ConfigRequestID{Header: "X-Correlation-Id"}}}
+	standard := &ChiServer{Logger: logger.NewNopLogger()}
+
+	// The header of a server does not change the header of the others
+	correlationID, res := serveRequestID(correlation, "203.0.113.1:1234", nil)
+	assert.Equal(t, correlationID, res.Header().Get("X-Correlation-Id"))
+	assert.Empty(t, res.Header().Get("X-Request-Id"))
+
+	requestID, res := serveRequestID(standard, "203.0.113.1:1234", nil)
+	assert.Equal(t, requestID, res.Header().Get("X-Request-Id"))
+	assert.Empty(t, res.Header().Get("X-Correlation-Id"))
+	assert.Contains(t, res.Body.String(), `"instance":"`+requestID+`"`)
+}
+
+func TestRequestIDGenerator(t *testing.T) {
+	_, err := ulid.ParseStrict(requestIDGenerator("ulid")())
+	assert.Nil(t, err)
+
+	id, err := uuid.Parse(requestIDGenerator("uuidv7")())
+	assert.Nil(t, err)
+	assert.Equal(t, uuid.Version(7), id.Version())
+
+	// The IDs are sortable
+	first, second := requestIDGenerator("ulid")(), requestIDGenerator("ulid")()
+	assert.True(t, first < second)
+}
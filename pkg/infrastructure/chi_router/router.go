@@ -1,44 +1,88 @@
 package chi_router
 
 import (
+	"context"
 	"fmt"
 	"go-clean-api/pkg"
 	"go-clean-api/pkg/domain/usecases"
 	"go-clean-api/pkg/infrastructure/chi_router/handlers"
+	"go-clean-api/pkg/infrastructure/chi_router/handlers/admin"
+	"go-clean-api/pkg/infrastructure/chi_router/handlers/api/api_key"
+	"go-clean-api/pkg/infrastructure/chi_router/handlers/api/audit_log"
 	"go-clean-api/pkg/infrastructure/chi_router/handlers/api/user"
+	"go-clean-api/pkg/infrastructure/chi_router/handlers/api/webhook"
 	"go-clean-api/pkg/infrastructure/chi_router/handlers/web"
 	"go-clean-api/pkg/infrastructure/chi_router/httputil"
 	"go-clean-api/pkg/infrastructure/logger"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 )
 
 // ChiServer is a struct that represents a Chi server
 type ChiServer struct {
-	Logger      logger.CustomLogger
-	Config      pkg.Config
-	UserUseCase usecases.User
+	Logger             logger.CustomLogger
+	Config             pkg.Config
+	UserUseCase        usecases.User
+	AuditLogUseCase    usecases.AuditLog
+	WebhookUseCase     usecases.Webhook
+	APIKeyUseCase      usecases.APIKey
+	IdempotencyUseCase usecases.Idempotency
+	LogLevel           *logger.Level
 }
 
 // NewChiServer creates a new ChiServer
-func NewChiServer(config pkg.Config, l logger.CustomLogger, userUseCase usecases.User) ChiServer {
+func NewChiServer(config pkg.Config, l logger.CustomLogger, userUseCase usecases.User, auditLogUseCase usecases.AuditLog, webhookUseCase usecases.Webhook, apiKeyUseCase usecases.APIKey, idempotencyUseCase usecases.Idempotency, logLevel *logger.Level) ChiServer {
 	return ChiServer{
-		Logger:      l,
-		Config:      config,
-		UserUseCase: userUseCase,
+		Logger:             l,
+		Config:             config,
+		UserUseCase:        userUseCase,
+		AuditLogUseCase:    auditLogUseCase,
+		WebhookUseCase:     webhookUseCase,
+		APIKeyUseCase:      apiKeyUseCase,
+		IdempotencyUseCase: idempotencyUseCase,
+		LogLevel:           logLevel,
 	}
 }
 
-// Start the HTTP server
+// Start the HTTP server.
+// On SIGINT or SIGTERM, the server stops accepting connections and waits
+// for the requests in progress (at most the server timeout) before returning.
 func (s *ChiServer) Start() error {
 	r, err := s.Setup()
 	if err != nil {
 		return err
 	}
 
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server := &http.Server{
+		Addr:    fmt.Sprintf("%s:%d", s.Config.Server.Addr, s.Config.Server.Port),
+		Handler: r,
+	}
+	errServe := make(chan error, 1)
+	go func() {
+		errServe <- server.ListenAndServe()
+	}()
+
 	fmt.Printf("Server started on %s:%d...\n", s.Config.Server.Addr, s.Config.Server.Port)
-	return http.ListenAndServe(fmt.Sprintf("%s:%d", s.Config.Server.Addr, s.Config.Server.Port), r)
+
+	select {
+	case err := <-errServe:
+		return err
+	case <-ctx.Done():
+	}
+
+	fmt.Println("Server shutting down...")
+	ctxShutdown, cancel := context.WithTimeout(context.Background(), time.Duration(s.Config.Server.Timeout)*time.Second)
+	defer cancel()
+
+	return server.Shutdown(ctxShutdown)
 }
 
 // Setup the HTTP server
@@ -76,7 +120,7 @@ func (s *ChiServer) HandleError(f func(w http.ResponseWriter, r *http.Request) e
 func (s *ChiServer) routes(r *chi.Mux) {
 	// Web routes
 	r.Get("/health", s.HandleError(web.HealthCheck))
-	r.Get("/big-tasks", s.HandleError(web.BigTasks))
+	r.With(httputil.Negotiate).Get("/big-tasks", s.HandleError(web.BigTasks))
 
 	// API documentation
 	r.Route("/doc", func(d chi.Router) {
@@ -85,6 +129,16 @@ func (s *ChiServer) routes(r *chi.Mux) {
 		d.Get("/api-v1", s.HandleError(web.GetAPIv1Doc))
 	})
 
+	// Administration
+	if s.LogLevel != nil {
+		r.Route("/admin", func(a chi.Router) {
+			a.Use(s.initBasicAuth())
+
+			h := admin.NewHandler(a, s.Logger, s.LogLevel, s.Config.Log.LevelRevertAfter)
+			h.Routes()
+		})
+	}
+
 	// Static files
 	fs := http.FileServer(http.Dir("./assets"))
 	r.Handle("/assets/*", http.StripPrefix("/assets/", fs))
@@ -92,6 +146,7 @@ func (s *ChiServer) routes(r *chi.Mux) {
 	// API routes
 	r.Route("/api", func(a chi.Router) {
 		a.Use(s.initCORS())
+		a.Use(httputil.Negotiate)
 
 		// Version 1
 		a.Route("/v1", func(v1 chi.Router) {
@@ -106,13 +161,42 @@ func (s *ChiServer) routes(r *chi.Mux) {
 
 			// Private routes
 			v1.Group(func(v1 chi.Router) {
-				s.initJWT(v1)
+				s.initAuth(v1)
+				if s.Config.Idempotency.Enable {
+					v1.Use(s.idempotency) // Keys are scoped to the authenticated user
+				}
 
 				// User routes
 				v1.Route("/users", func(u chi.Router) {
+					u.Use(s.requireScope("users"))
+
 					h := user.NewHandler(u, s.Logger, s.UserUseCase)
 					h.PrivateRoutes()
 				})
+
+				// Audit log routes
+				v1.Route("/audit-logs", func(a chi.Router) {
+					a.Use(s.requireScope("audit-logs"))
+
+					h := audit_log.NewHandler(a, s.Logger, s.AuditLogUseCase)
+					h.PrivateRoutes()
+				})
+
+				// Webhook routes
+				v1.Route("/webhooks", func(wh chi.Router) {
+					wh.Use(s.requireScope("webhooks"))
+
+					h := webhook.NewHandler(wh, s.Logger, s.WebhookUseCase)
+					h.PrivateRoutes()
+				})
+
+				// API key routes
+				v1.Route("/api-keys", func(k chi.Router) {
+					k.Use(s.requireScope("api-keys"))
+
+					h := api_key.NewHandler(k, s.Logger, s.APIKeyUseCase)
+					h.PrivateRoutes()
+				})
 			})
 		})
 	})
@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"fmt"
+	"go-clean-api/pkg/adapters/db"
+	"go-clean-api/pkg/adapters/repositories/gorm_mysql"
+	"go-clean-api/pkg/domain/usecases"
+	vo "go-clean-api/pkg/domain/value_objects"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	apiKeyUserID    string
+	apiKeyID        string
+	apiKeyName      string
+	apiKeyScopes    []string
+	apiKeyExpiresIn time.Duration
+)
+
+func init() {
+	apiKeysCmd.PersistentFlags().StringVarP(&apiKeyUserID, "user-id", "u", "", "user ID")
+	apiKeysCmd.MarkPersistentFlagRequired("user-id")
+
+	apiKeysCreateCmd.Flags().StringVarP(&apiKeyName, "name", "n", "", "API key name")
+	apiKeysCreateCmd.Flags().StringSliceVarP(&apiKeyScopes, "scopes", "s", nil, "API key scopes (Ex.: users:read,users:write), all if empty")
+	apiKeysCreateCmd.Flags().DurationVarP(&apiKeyExpiresIn, "expires-in", "e", 0, "API key lifetime (Ex.: 720h), no expiration if empty")
+	apiKeysCreateCmd.MarkFlagRequired("name")
+
+	apiKeysRevokeCmd.Flags().StringVarP(&apiKeyID, "id", "i", "", "API key ID")
+	apiKeysRevokeCmd.MarkFlagRequired("id")
+
+	apiKeysCmd.AddCommand(apiKeysCreateCmd, apiKeysListCmd, apiKeysRevokeCmd)
+	rootCmd.AddCommand(apiKeysCmd)
+}
+
+var apiKeysCmd = &cobra.Command{
+	Use:   "api-keys",
+	Short: "API keys management",
+	Long:  `API keys management`,
+}
+
+var apiKeysCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "API key creation",
+	Long:  `API key creation`,
+	Run: func(cmd *cobra.Command, args []string) {
+		apiKeyUseCase, userID := initAPIKeyUseCase()
+
+		req := usecases.CreateAPIKeyRequest{
+			UserID: userID,
+			Name:   strings.TrimSpace(apiKeyName),
+			Scopes: apiKeyScopes,
+		}
+		if apiKeyExpiresIn > 0 {
+			expiresAt := vo.NewTime(time.Now().Add(apiKeyExpiresIn), nil)
+			req.ExpiresAt = &expiresAt
+		}
+
+		res, errRes := apiKeyUseCase.Create(req)
+		if errRes != nil {
+			fmt.Printf("\nError: %s\n", errRes)
+			return
+		}
+
+		expiresAt := "never"
+		if res.ExpiresAt != nil {
+			expiresAt = res.ExpiresAt.RFC3339()
+		}
+
+		// Display result
+		fmt.Printf(`
+API key successfully created:
+    - ID:         %s
+    - Name:       %s
+    - Scopes:     %s
+    - Expires at: %s
+    - Key:        %s
+
+The key is displayed only once, store it securely.
+`,
+			res.ID.Value(),
+			res.Name,
+			displayAPIKeyScopes(res.Scopes),
+			expiresAt,
+			res.Key,
+		)
+	},
+}
+
+var apiKeysListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "API keys list",
+	Long:  `API keys list`,
+	Run: func(cmd *cobra.Command, args []string) {
+		apiKeyUseCase, userID := initAPIKeyUseCase()
+
+		res, errRes := apiKeyUseCase.GetAll(usecases.GetAllAPIKeysRequest{
+			UserID:     userID,
+			Pagination: vo.NewPagination(1, vo.PaginationMaxSize, 0),
+		})
+		if errRes != nil {
+			fmt.Printf("\nError: %s\n", errRes)
+			return
+		}
+
+		fmt.Printf("\n%d API key(s):\n", res.Total)
+		for _, apiKey := range res.Data {
+			status := "active"
+			if apiKey.RevokedAt != nil {
+				status = "revoked"
+			} else if !apiKey.IsActive(time.Now()) {
+				status = "expired"
+			}
+
+			lastUsedAt := "never"
+			if apiKey.LastUsedAt != nil {
+				lastUsedAt = apiKey.LastUsedAt.RFC3339()
+			}
+
+			fmt.Printf("    - %s  %-8s  %-20s  prefix=%s  scopes=%s  last_used_at=%s\n",
+				apiKey.ID.Value(),
+				status,
+				apiKey.Name,
+				apiKey.Prefix,
+				displayAPIKeyScopes(apiKey.Scopes),
+				lastUsedAt,
+			)
+		}
+	},
+}
+
+var apiKeysRevokeCmd = &cobra.Command{
+	Use:   "revoke",
+	Short: "API key revocation",
+	Long:  `API key revocation`,
+	Run: func(cmd *cobra.Command, args []string) {
+		apiKeyUseCase, userID := initAPIKeyUseCase()
+
+		id, err := vo.NewIDFrom(strings.TrimSpace(apiKeyID))
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		if _, errRes := apiKeyUseCase.Revoke(usecases.RevokeAPIKeyRequest{ID: id, UserID: userID}); errRes != nil {
+			fmt.Printf("\nError: %s\n", errRes)
+			return
+		}
+
+		fmt.Printf("\nAPI key %s successfully revoked\n", id.Value())
+	},
+}
+
+// initAPIKeyUseCase initializes the API key use case and parses the user ID flag.
+func initAPIKeyUseCase() (usecases.APIKey, vo.ID) {
+	config, err := initConfig()
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	database, err := initDatabase(config)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	userID, err := vo.NewIDFrom(strings.TrimSpace(apiKeyUserID))
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	gormDB, ok := database.(*db.GormMySQL)
+	if !ok {
+		log.Fatalln("db is not of type *db.GormMySQL")
+	}
+
+	return usecases.NewAPIKey(gorm_mysql.NewAPIKey(gormDB), gorm_mysql.NewUser(gormDB)), userID
+}
+
+func displayAPIKeyScopes(scopes []string) string {
+	if len(scopes) == 0 {
+		return "all"
+	}
+	return strings.Join(scopes, ",")
+}
@@ -0,0 +1,218 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// logLevels ranks the log levels by severity
+var logLevels = map[string]int{
+	"DEBUG":  0,
+	"INFO":   1,
+	"WARN":   2,
+	"ERROR":  3,
+	"DPANIC": 4,
+	"PANIC":  5,
+	"FATAL":  6,
+}
+
+// logFilter selects the log entries to display.
+// The zero value matches all the entries.
+type logFilter struct {
+	// Minimum level (-1 for all the levels)
+	minLevel int
+
+	// Status code range (0 for no bound)
+	minStatus uint
+	maxStatus uint
+
+	// Methods in upper case
+	methods []string
+
+	// Glob of the request path (see path.Match)
+	pathGlob string
+
+	requestID string
+
+	// Time window (zero for no bound)
+	since time.Time
+	until time.Time
+
+	// Only the SQL queries
+	sqlOnly bool
+
+	// Minimum duration of the SQL queries (0 for all the queries)
+	slowThreshold time.Duration
+}
+
+// logFilterOptions are the criteria of a log filter as given in the command flags
+type logFilterOptions struct {
+	Level     string
+	Status    string
+	Methods   []string
+	PathGlob  string
+	RequestID string
+
+	// RFC 3339 times, dates (YYYY-MM-DD) or durations before now (15m, 2h...)
+	Since string
+	Until string
+
+	SQL  bool
+	Slow time.Duration
+}
+
+// newLogFilter creates a log filter from the command flags
+func newLogFilter(opts logFilterOptions, now time.Time) (f logFilter, err error) {
+	f.minLevel = -1
+	if opts.Level != "" {
+		rank, ok := logLevels[strings.ToUpper(opts.Level)]
+		if !ok {
+			return f, fmt.Errorf("invalid level %q", opts.Level)
+		}
+		f.minLevel = rank
+	}
+
+	if opts.Status != "" {
+		f.minStatus, f.maxStatus, err = parseStatusRange(opts.Status)
+		if err != nil {
+			return f, err
+		}
+	}
+
+	for _, m := range opts.Methods {
+		f.methods = append(f.methods, strings.ToUpper(strings.TrimSpace(m)))
+	}
+
+	if opts.PathGlob != "" {
+		if _, err := path.Match(opts.PathGlob, "/"); err != nil {
+			return f, fmt.Errorf("invalid path glob %q: %w", opts.PathGlob, err)
+		}
+		f.pathGlob = opts.PathGlob
+	}
+
+	f.requestID = opts.RequestID
+
+	if opts.Since != "" {
+		if f.since, err = parseLogTime(opts.Since, now); err != nil {
+			return f, err
+		}
+	}
+	if opts.Until != "" {
+		if f.until, err = parseLogTime(opts.Until, now); err != nil {
+			return f, err
+		}
+	}
+
+	if opts.Slow < 0 {
+		return f, errors.New("invalid slow query threshold")
+	}
+	f.sqlOnly = opts.SQL || opts.Slow > 0
+	f.slowThreshold = opts.Slow
+
+	return f, nil
+}
+
+// active returns true if the filter rejects some entries
+func (f logFilter) active() bool {
+	return f.minLevel >= 0 || f.minStatus > 0 || f.maxStatus > 0 || len(f.methods) > 0 ||
+		f.pathGlob != "" || f.requestID != "" || !f.since.IsZero() || !f.until.IsZero() || f.sqlOnly
+}
+
+// match returns true if the entry satisfies all the criteria of the filter
+func (f logFilter) match(e logEntry) bool {
+	if f.minLevel >= 0 {
+		rank, ok := logLevels[e.Level]
+		if !ok || rank < f.minLevel {
+			return false
+		}
+	}
+
+	if (f.minStatus > 0 || f.maxStatus > 0) && (e.Code == 0 || e.Code < f.minStatus || (f.maxStatus > 0 && e.Code > f.maxStatus)) {
+		return false
+	}
+
+	if len(f.methods) > 0 && !slices.Contains(f.methods, e.Method) {
+		return false
+	}
+
+	if f.pathGlob != "" {
+		if ok, _ := path.Match(f.pathGlob, e.Path); !ok {
+			return false
+		}
+	}
+
+	if f.requestID != "" && e.RequestID != f.requestID {
+		return false
+	}
+
+	if !f.since.IsZero() && e.Time.Before(f.since) {
+		return false
+	}
+	if !f.until.IsZero() && e.Time.After(f.until) {
+		return false
+	}
+
+	if f.sqlOnly {
+		if e.SQL == "" {
+			return false
+		}
+		if f.slowThreshold > 0 {
+			d, err := time.ParseDuration(e.Duration)
+			if err != nil || d < f.slowThreshold {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// parseStatusRange parses a status code (404), a class (4xx) or a range (400-499)
+func parseStatusRange(s string) (min, max uint, err error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	if from, to, found := strings.Cut(s, "-"); found {
+		min, _, err = parseStatusRange(from)
+		if err != nil {
+			return 0, 0, err
+		}
+		_, max, err = parseStatusRange(to)
+		if err != nil {
+			return 0, 0, err
+		}
+		if min > max {
+			return 0, 0, fmt.Errorf("invalid status range %q", s)
+		}
+		return min, max, nil
+	}
+
+	if len(s) == 3 && strings.HasSuffix(s, "xx") && s[0] >= '1' && s[0] <= '5' {
+		class := uint(s[0]-'0') * 100
+		return class, class + 99, nil
+	}
+
+	code, err := strconv.ParseUint(s, 10, 16)
+	if err != nil || code < 100 || code > 599 {
+		return 0, 0, fmt.Errorf("invalid status %q", s)
+	}
+	return uint(code), uint(code), nil
+}
+
+// parseLogTime parses an RFC 3339 time, a date (YYYY-MM-DD) or a duration before now
+func parseLogTime(s string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, s, time.Local); err == nil {
+		return t, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, errors.New("invalid time " + strconv.Quote(s) + " (RFC 3339 time, YYYY-MM-DD date or duration)")
+}
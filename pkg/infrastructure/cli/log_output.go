@@ -0,0 +1,231 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Output modes of the logs command
+const (
+	logOutputPretty = "pretty"
+	logOutputJSON   = "json"
+	logOutputCSV    = "csv"
+)
+
+// logEntry is a log line of the server or of GORM written by zap
+type logEntry struct {
+	Level       string    `json:"level"`
+	Time        time.Time `json:"time"`
+	Caller      string    `json:"caller"`
+	Message     string    `json:"message"`
+	Description string    `json:"description"`
+	Error       string    `json:"error"`
+	Code        uint      `json:"code"`
+	Method      string    `json:"method"`
+	Path        string    `json:"path"`
+	Body        string    `json:"body"`
+	URL         string    `json:"url"`
+	Host        string    `json:"host"`
+	IP          string    `json:"ip"`
+	RequestID   string    `json:"request_id"`
+	Latency     string    `json:"latency"`
+	UserAgent   string    `json:"userAgent"`
+
+	// SQL queries
+	SQL      string `json:"sql"`
+	Rows     *int64 `json:"rows"`
+	Duration string `json:"duration"`
+	Slow     bool   `json:"slow"`
+}
+
+// parseLine parses a JSON log line
+func parseLine(line []byte) (e logEntry, err error) {
+	err = json.Unmarshal(line, &e)
+	return
+}
+
+// logWriter writes the log entries in an output mode
+type logWriter interface {
+	// Write writes an entry, e is nil if the line is not a JSON log
+	Write(e *logEntry, line []byte) error
+
+	// Flush writes the buffered entries
+	Flush() error
+}
+
+// newLogWriter creates the log writer of an output mode
+func newLogWriter(mode string, w io.Writer, verbose bool) (logWriter, error) {
+	switch mode {
+	case logOutputPretty, "":
+		return &prettyLogWriter{w: w, verbose: verbose}, nil
+	case logOutputJSON:
+		return &jsonLogWriter{w: w}, nil
+	case logOutputCSV:
+		return newCSVLogWriter(w)
+	default:
+		return nil, fmt.Errorf("invalid output %q (pretty | json | csv)", mode)
+	}
+}
+
+// prettyLogWriter writes colored human readable lines
+type prettyLogWriter struct {
+	w       io.Writer
+	verbose bool
+}
+
+func (p *prettyLogWriter) Write(e *logEntry, line []byte) (err error) {
+	if e == nil {
+		_, err = fmt.Fprintln(p.w, string(line))
+	} else if e.SQL != "" {
+		_, err = fmt.Fprintln(p.w, prettySQLEntry(*e))
+	} else {
+		_, err = fmt.Fprintln(p.w, prettyLogEntry(*e, p.verbose))
+	}
+	return
+}
+
+func (p *prettyLogWriter) Flush() error {
+	return nil
+}
+
+// jsonLogWriter writes the raw JSON lines
+type jsonLogWriter struct {
+	w io.Writer
+}
+
+func (j *jsonLogWriter) Write(e *logEntry, line []byte) error {
+	if e == nil {
+		return nil
+	}
+	_, err := fmt.Fprintln(j.w, string(line))
+	return err
+}
+
+func (j *jsonLogWriter) Flush() error {
+	return nil
+}
+
+// csvLogWriter writes the main fields of the entries in CSV
+type csvLogWriter struct {
+	w *csv.Writer
+}
+
+// csvLogHeader is the header of the CSV output
+var csvLogHeader = []string{"time", "level", "code", "method", "path", "latency", "request_id", "message", "error", "caller", "sql", "duration"}
+
+func newCSVLogWriter(w io.Writer) (*csvLogWriter, error) {
+	c := &csvLogWriter{w: csv.NewWriter(w)}
+	return c, c.w.Write(csvLogHeader)
+}
+
+func (c *csvLogWriter) Write(e *logEntry, line []byte) error {
+	if e == nil {
+		return nil
+	}
+
+	code := ""
+	if e.Code != 0 {
+		code = strconv.FormatUint(uint64(e.Code), 10)
+	}
+
+	err := c.w.Write([]string{
+		e.Time.Format(time.RFC3339),
+		e.Level,
+		code,
+		e.Method,
+		e.Path,
+		e.Latency,
+		e.RequestID,
+		e.Message,
+		e.Error,
+		e.Caller,
+		e.SQL,
+		e.Duration,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Lines are flushed for the follow mode
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvLogWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// prettyLogEntry formats an entry in a colored human readable line
+func prettyLogEntry(errLog logEntry, verboseFlag bool) string {
+	code := ""
+	if errLog.Code != 0 {
+		code = fmt.Sprintf(" | %d", displayLogStatusCode(errLog.Code))
+	}
+	message := ""
+	if errLog.Message != "" {
+		message = fmt.Sprintf(" | Message: %s", errLog.Message)
+	}
+	description := ""
+	if errLog.Description != "" {
+		description = fmt.Sprintf(" | Description: %s", errLog.Description)
+	}
+	errorLog := ""
+	if errLog.Error != "" && errLog.Error != "<nil>" {
+		errorLog = fmt.Sprintf(" | Error: %s", errLog.Error)
+	}
+	method := ""
+	if errLog.Method != "" {
+		method = fmt.Sprintf(" | %6s", displayLogMethod(errLog.Method))
+	}
+	url := ""
+	if errLog.URL != "" && verboseFlag {
+		url = fmt.Sprintf(" | %s", errLog.URL)
+	}
+	path := ""
+	if errLog.Path != "" {
+		path = fmt.Sprintf(" | %s", errLog.Path)
+	}
+	host := ""
+	if errLog.Host != "" && verboseFlag {
+		host = fmt.Sprintf(" | %s", errLog.Host)
+	}
+	ip := ""
+	if errLog.IP != "" && verboseFlag {
+		ip = fmt.Sprintf(" | IP: %s", errLog.IP)
+	}
+	requestID := ""
+	if errLog.RequestID != "" {
+		requestID = fmt.Sprintf(" | RequestID: %s", errLog.RequestID)
+	}
+	userAgent := ""
+	if errLog.UserAgent != "" && verboseFlag {
+		userAgent = fmt.Sprintf(" | UserAgent: %s", errLog.UserAgent)
+	}
+	latency := ""
+	if errLog.Latency != "" {
+		latency = fmt.Sprintf(" | %s", errLog.Latency)
+	}
+
+	return fmt.Sprintf("%s | %7s %s%s%s%s%s%s%s%s%s%s%s%s%s",
+		errLog.Time.Format(time.RFC3339),
+		displayLogLevel(errLog.Level),
+		code,
+		method,
+		message,
+		description,
+		errorLog,
+		path,
+		url,
+		host,
+		ip,
+		requestID,
+		userAgent,
+		latency,
+		" | "+errLog.Caller,
+	)
+}
@@ -0,0 +1,177 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// logFollowInterval is the interval between two checks of a followed file
+const logFollowInterval = 250 * time.Millisecond
+
+// readLogs sends the lines of the files (of stdin if there is no file) to out and closes it.
+// In follow mode, the files are read until ctx is done, like tail -F: the new lines are sent
+// and the files are reopened after a rotation or a truncation.
+func readLogs(ctx context.Context, files []string, follow bool, out chan<- []byte) error {
+	defer close(out)
+
+	if len(files) == 0 {
+		return readLogLines(ctx, os.Stdin, out)
+	}
+
+	if !follow {
+		for _, name := range files {
+			if err := readLogFile(ctx, name, out); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(files))
+	for i, name := range files {
+		wg.Go(func() {
+			errs[i] = followLogFile(ctx, name, out)
+		})
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// readLogFile sends all the lines of a file, the rotated files compressed with gzip (.gz) are uncompressed
+func readLogFile(ctx context.Context, name string, out chan<- []byte) error {
+	file, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if strings.HasSuffix(name, ".gz") {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+
+		return readLogLines(ctx, gz, out)
+	}
+
+	return readLogLines(ctx, file, out)
+}
+
+// readLogLines sends the lines of a reader until its end
+func readLogLines(ctx context.Context, r io.Reader, out chan<- []byte) error {
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 && !sendLogLine(ctx, line, out) {
+			return nil
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// followLogFile sends the lines of a file and then its new lines until ctx is done
+func followLogFile(ctx context.Context, name string, out chan<- []byte) error {
+	file, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer func() { file.Close() }()
+
+	reader := bufio.NewReader(file)
+	var partial []byte
+	var offset int64
+
+	for {
+		chunk, err := reader.ReadBytes('\n')
+		offset += int64(len(chunk))
+		if err == nil {
+			if !sendLogLine(ctx, append(partial, chunk...), out) {
+				return nil
+			}
+			partial = nil
+			continue
+		}
+		if err != io.EOF {
+			return err
+		}
+
+		// Incomplete line, the end is waited
+		partial = append(partial, chunk...)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(logFollowInterval):
+		}
+
+		info, err := os.Stat(name)
+		if err != nil {
+			// The file is being rotated
+			continue
+		}
+		current, err := file.Stat()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case !os.SameFile(info, current):
+			// Rotation: the end of the old file is sent before reading the new one
+			rest, _ := io.ReadAll(reader)
+			rest = append(partial, rest...)
+			partial = nil
+			for line := range bytes.Lines(rest) {
+				if !sendLogLine(ctx, line, out) {
+					return nil
+				}
+			}
+
+			newFile, err := os.Open(name)
+			if err != nil {
+				continue
+			}
+			file.Close()
+			file = newFile
+			reader.Reset(file)
+			offset = 0
+		case info.Size() < offset:
+			// Truncation
+			if _, err := file.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+			reader.Reset(file)
+			partial, offset = nil, 0
+		}
+	}
+}
+
+// sendLogLine sends a line without its line break, it returns false if ctx is done
+func sendLogLine(ctx context.Context, line []byte, out chan<- []byte) bool {
+	line = bytes.TrimRight(line, "\r\n")
+	if len(line) == 0 {
+		return true
+	}
+
+	select {
+	case out <- line:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,280 @@
+package cli
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/logrusorgru/aurora"
+)
+
+// Kinds of the SQL tokens
+const (
+	sqlTokenOther = iota
+	sqlTokenKeyword
+	sqlTokenString
+	sqlTokenNumber
+	sqlTokenIdentifier
+)
+
+// sqlKeywords are the highlighted SQL keywords
+var sqlKeywords = map[string]struct{}{}
+
+func init() {
+	for _, k := range strings.Fields(`SELECT FROM WHERE AND OR NOT IN IS NULL INSERT INTO VALUES UPDATE SET DELETE
+		JOIN LEFT RIGHT INNER OUTER CROSS ON AS ORDER BY GROUP HAVING LIMIT OFFSET ASC DESC DISTINCT LIKE BETWEEN
+		CASE WHEN THEN ELSE END EXISTS UNION ALL BEGIN COMMIT ROLLBACK SAVEPOINT RELEASE FOR SHARE DUPLICATE KEY
+		COUNT SUM MIN MAX AVG TRUE FALSE DEFAULT RETURNING`) {
+		sqlKeywords[k] = struct{}{}
+	}
+}
+
+// sqlInList matches the lists of placeholders of the normalized statements
+var sqlInList = regexp.MustCompile(`\(\?(, \?)+\)`)
+
+type sqlToken struct {
+	kind int
+	text string
+}
+
+// sqlTokens splits a statement in tokens
+func sqlTokens(sql string) (tokens []sqlToken) {
+	for i := 0; i < len(sql); {
+		c := sql[i]
+		j := i + 1
+		kind := sqlTokenOther
+
+		switch {
+		case c == '\'' || c == '"':
+			kind = sqlTokenString
+			for j < len(sql) {
+				if sql[j] == '\\' {
+					j += 2
+					continue
+				}
+				if sql[j] == c {
+					// Doubled quote
+					if j+1 < len(sql) && sql[j+1] == c {
+						j += 2
+						continue
+					}
+					j++
+					break
+				}
+				j++
+			}
+		case c == '`':
+			kind = sqlTokenIdentifier
+			for j < len(sql) && sql[j] != '`' {
+				j++
+			}
+			j++
+		case c >= '0' && c <= '9':
+			kind = sqlTokenNumber
+			for j < len(sql) && (sql[j] >= '0' && sql[j] <= '9' || sql[j] == '.') {
+				j++
+			}
+		case isSQLWordByte(c):
+			for j < len(sql) && (isSQLWordByte(sql[j]) || sql[j] >= '0' && sql[j] <= '9') {
+				j++
+			}
+			if _, ok := sqlKeywords[strings.ToUpper(sql[i:j])]; ok {
+				kind = sqlTokenKeyword
+			} else {
+				kind = sqlTokenIdentifier
+			}
+		}
+
+		j = min(j, len(sql))
+		tokens = append(tokens, sqlToken{kind: kind, text: sql[i:j]})
+		i = j
+	}
+	return
+}
+
+func isSQLWordByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+// highlightSQL colors the keywords, the strings and the numbers of a statement
+func highlightSQL(sql string) string {
+	var b strings.Builder
+	for _, t := range sqlTokens(sql) {
+		switch t.kind {
+		case sqlTokenKeyword:
+			b.WriteString(aurora.Bold(aurora.Blue(t.text)).String())
+		case sqlTokenString:
+			b.WriteString(aurora.Green(t.text).String())
+		case sqlTokenNumber:
+			b.WriteString(aurora.Magenta(t.text).String())
+		default:
+			b.WriteString(t.text)
+		}
+	}
+	return b.String()
+}
+
+// normalizeSQL returns the shape of a statement to group the identical ones:
+// the values are replaced by ?, the lists of values by (?) and the spaces are collapsed.
+func normalizeSQL(sql string) string {
+	var b strings.Builder
+	for _, t := range sqlTokens(sql) {
+		switch t.kind {
+		case sqlTokenString, sqlTokenNumber:
+			b.WriteByte('?')
+		case sqlTokenKeyword:
+			b.WriteString(strings.ToUpper(t.text))
+		default:
+			b.WriteString(t.text)
+		}
+	}
+
+	normalized := strings.Join(strings.Fields(b.String()), " ")
+	normalized = strings.ReplaceAll(normalized, "( ", "(")
+	normalized = strings.ReplaceAll(normalized, " )", ")")
+	normalized = strings.ReplaceAll(normalized, " ,", ",")
+	normalized = strings.ReplaceAll(normalized, ",?", ", ?")
+	return sqlInList.ReplaceAllString(normalized, "(?)")
+}
+
+// prettySQLEntry formats a SQL entry in a colored human readable line
+func prettySQLEntry(e logEntry) string {
+	rows := ""
+	if e.Rows != nil && *e.Rows >= 0 {
+		rows = fmt.Sprintf(" | %d rows", *e.Rows)
+	}
+	slow := ""
+	if e.Slow {
+		slow = fmt.Sprintf(" | %s", aurora.Yellow("SLOW"))
+	}
+	errorLog := ""
+	if e.Error != "" {
+		errorLog = fmt.Sprintf(" | Error: %s", e.Error)
+	}
+
+	return fmt.Sprintf("%s | %7s | %s%s%s%s | %s | %s",
+		e.Time.Format(time.RFC3339),
+		displayLogLevel(e.Level),
+		e.Duration,
+		rows,
+		slow,
+		errorLog,
+		highlightSQL(e.SQL),
+		e.Caller,
+	)
+}
+
+// sqlGroup are the statistics of the identical statements
+type sqlGroup struct {
+	SQL   string  `json:"sql"`
+	Count int     `json:"count"`
+	Total float64 `json:"total_ms"`
+	Avg   float64 `json:"avg_ms"`
+	Max   float64 `json:"max_ms"`
+	Slow  int     `json:"slow"`
+
+	total time.Duration
+	max   time.Duration
+}
+
+// sqlGroupWriter groups the identical statements and writes them on flush,
+// sorted by total duration
+type sqlGroupWriter struct {
+	w      io.Writer
+	mode   string
+	groups map[string]*sqlGroup
+}
+
+func newSQLGroupWriter(mode string, w io.Writer) (*sqlGroupWriter, error) {
+	switch mode {
+	case logOutputPretty, "", logOutputJSON, logOutputCSV:
+	default:
+		return nil, fmt.Errorf("invalid output %q (pretty | json | csv)", mode)
+	}
+	return &sqlGroupWriter{w: w, mode: mode, groups: make(map[string]*sqlGroup)}, nil
+}
+
+func (g *sqlGroupWriter) Write(e *logEntry, _ []byte) error {
+	if e == nil || e.SQL == "" {
+		return nil
+	}
+
+	sql := normalizeSQL(e.SQL)
+	group, ok := g.groups[sql]
+	if !ok {
+		group = &sqlGroup{SQL: sql}
+		g.groups[sql] = group
+	}
+
+	d, _ := time.ParseDuration(e.Duration)
+	group.Count++
+	group.total += d
+	group.max = max(group.max, d)
+	if e.Slow {
+		group.Slow++
+	}
+
+	return nil
+}
+
+// Groups returns the groups sorted by total duration
+func (g *sqlGroupWriter) Groups() []sqlGroup {
+	groups := make([]sqlGroup, 0, len(g.groups))
+	for _, group := range g.groups {
+		group.Total = milliseconds(group.total)
+		group.Avg = milliseconds(group.total / time.Duration(group.Count))
+		group.Max = milliseconds(group.max)
+		groups = append(groups, *group)
+	}
+	slices.SortFunc(groups, func(a, b sqlGroup) int {
+		return cmp.Or(cmp.Compare(b.total, a.total), cmp.Compare(b.Count, a.Count), strings.Compare(a.SQL, b.SQL))
+	})
+	return groups
+}
+
+func (g *sqlGroupWriter) Flush() error {
+	groups := g.Groups()
+
+	switch g.mode {
+	case logOutputJSON:
+		encoder := json.NewEncoder(g.w)
+		for _, group := range groups {
+			if err := encoder.Encode(group); err != nil {
+				return err
+			}
+		}
+		return nil
+	case logOutputCSV:
+		w := csv.NewWriter(g.w)
+		w.Write([]string{"count", "total_ms", "avg_ms", "max_ms", "slow", "sql"})
+		for _, group := range groups {
+			w.Write([]string{
+				strconv.Itoa(group.Count),
+				strconv.FormatFloat(group.Total, 'f', 3, 64),
+				strconv.FormatFloat(group.Avg, 'f', 3, 64),
+				strconv.FormatFloat(group.Max, 'f', 3, 64),
+				strconv.Itoa(group.Slow),
+				group.SQL,
+			})
+		}
+		w.Flush()
+		return w.Error()
+	default:
+		tw := tabwriter.NewWriter(g.w, 0, 0, 2, ' ', 0)
+		fmt.Fprint(tw, "COUNT\tTOTAL\tAVG\tMAX\tSLOW\tSQL\n")
+		for _, group := range groups {
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%d\t%s\n",
+				group.Count, formatMilliseconds(group.Total), formatMilliseconds(group.Avg),
+				formatMilliseconds(group.Max), group.Slow, highlightSQL(group.SQL))
+		}
+		return tw.Flush()
+	}
+}
@@ -0,0 +1,312 @@
+package cli
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"regexp"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Sort orders of the endpoints in the access logs report
+const (
+	logStatsSortRequests = "requests"
+	logStatsSortErrors   = "errors"
+	logStatsSortP50      = "p50"
+	logStatsSortP95      = "p95"
+	logStatsSortP99      = "p99"
+)
+
+// logStatsIDSegment matches the path segments which are IDs (UUIDs, ULIDs or numbers)
+var logStatsIDSegment = regexp.MustCompile(`^([0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|[0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{26}|[0-9]+)$`)
+
+// statusClasses are the keys of the status histograms
+var statusClasses = []string{"1xx", "2xx", "3xx", "4xx", "5xx"}
+
+// logStats is the report of the access logs
+type logStats struct {
+	Requests        int                `json:"requests"`
+	From            time.Time          `json:"from"`
+	To              time.Time          `json:"to"`
+	Endpoints       []logEndpointStats `json:"endpoints"`
+	TopIPs          []logCount         `json:"top_ips"`
+	TopUserAgents   []logCount         `json:"top_user_agents"`
+	StatusHistogram []logStatusBucket  `json:"status_histogram"`
+}
+
+// logEndpointStats are the statistics of a method and a path
+type logEndpointStats struct {
+	Method          string  `json:"method"`
+	Path            string  `json:"path"`
+	Requests        int     `json:"requests"`
+	ClientErrors    int     `json:"client_errors"`
+	ServerErrors    int     `json:"server_errors"`
+	ClientErrorRate float64 `json:"client_error_rate"`
+	ServerErrorRate float64 `json:"server_error_rate"`
+	P50             float64 `json:"p50_ms"`
+	P95             float64 `json:"p95_ms"`
+	P99             float64 `json:"p99_ms"`
+}
+
+// logCount is the number of requests of a value (IP, user agent...)
+type logCount struct {
+	Value    string `json:"value"`
+	Requests int    `json:"requests"`
+}
+
+// logStatusBucket is the number of requests by status class in a time bucket
+type logStatusBucket struct {
+	Start  time.Time      `json:"start"`
+	Counts map[string]int `json:"counts"`
+}
+
+// logStatsAggregator aggregates the access log entries
+type logStatsAggregator struct {
+	// Duration of the time buckets of the status histogram
+	bucket time.Duration
+
+	// Replace the ID segments of the paths by {id}
+	groupIDs bool
+
+	requests   int
+	from, to   time.Time
+	endpoints  map[[2]string]*logEndpointAcc
+	ips        map[string]int
+	userAgents map[string]int
+	buckets    map[time.Time]map[string]int
+}
+
+// logEndpointAcc accumulates the entries of an endpoint
+type logEndpointAcc struct {
+	requests     int
+	clientErrors int
+	serverErrors int
+	latencies    []time.Duration
+}
+
+func newLogStatsAggregator(bucket time.Duration, groupIDs bool) *logStatsAggregator {
+	return &logStatsAggregator{
+		bucket:     bucket,
+		groupIDs:   groupIDs,
+		endpoints:  make(map[[2]string]*logEndpointAcc),
+		ips:        make(map[string]int),
+		userAgents: make(map[string]int),
+		buckets:    make(map[time.Time]map[string]int),
+	}
+}
+
+// Add aggregates an entry, the entries which are not access logs are ignored
+func (a *logStatsAggregator) Add(e logEntry) {
+	if e.Code == 0 || e.Method == "" {
+		return
+	}
+
+	a.requests++
+	if a.from.IsZero() || e.Time.Before(a.from) {
+		a.from = e.Time
+	}
+	if e.Time.After(a.to) {
+		a.to = e.Time
+	}
+
+	path := e.Path
+	if a.groupIDs {
+		path = groupPathIDs(path)
+	}
+	key := [2]string{e.Method, path}
+	acc, ok := a.endpoints[key]
+	if !ok {
+		acc = &logEndpointAcc{}
+		a.endpoints[key] = acc
+	}
+	acc.requests++
+	switch {
+	case e.Code >= 500:
+		acc.serverErrors++
+	case e.Code >= 400:
+		acc.clientErrors++
+	}
+	if latency, err := time.ParseDuration(e.Latency); err == nil {
+		acc.latencies = append(acc.latencies, latency)
+	}
+
+	if ip := remoteIP(e.IP); ip != "" {
+		a.ips[ip]++
+	}
+	if e.UserAgent != "" {
+		a.userAgents[e.UserAgent]++
+	}
+
+	start := e.Time.Truncate(a.bucket)
+	counts, ok := a.buckets[start]
+	if !ok {
+		counts = make(map[string]int, len(statusClasses))
+		a.buckets[start] = counts
+	}
+	counts[statusClass(e.Code)]++
+}
+
+// Report returns the statistics with the endpoints sorted by the sort order
+// and the top IPs and user agents (all of them if top is 0)
+func (a *logStatsAggregator) Report(sortBy string, top int) logStats {
+	stats := logStats{
+		Requests:        a.requests,
+		From:            a.from,
+		To:              a.to,
+		Endpoints:       make([]logEndpointStats, 0, len(a.endpoints)),
+		TopIPs:          topLogCounts(a.ips, top),
+		TopUserAgents:   topLogCounts(a.userAgents, top),
+		StatusHistogram: make([]logStatusBucket, 0, len(a.buckets)),
+	}
+
+	for key, acc := range a.endpoints {
+		slices.Sort(acc.latencies)
+		stats.Endpoints = append(stats.Endpoints, logEndpointStats{
+			Method:          key[0],
+			Path:            key[1],
+			Requests:        acc.requests,
+			ClientErrors:    acc.clientErrors,
+			ServerErrors:    acc.serverErrors,
+			ClientErrorRate: float64(acc.clientErrors) / float64(acc.requests),
+			ServerErrorRate: float64(acc.serverErrors) / float64(acc.requests),
+			P50:             milliseconds(percentile(acc.latencies, 50)),
+			P95:             milliseconds(percentile(acc.latencies, 95)),
+			P99:             milliseconds(percentile(acc.latencies, 99)),
+		})
+	}
+	slices.SortFunc(stats.Endpoints, func(a, b logEndpointStats) int {
+		var c int
+		switch sortBy {
+		case logStatsSortErrors:
+			c = cmp.Compare(b.ClientErrors+b.ServerErrors, a.ClientErrors+a.ServerErrors)
+		case logStatsSortP50:
+			c = cmp.Compare(b.P50, a.P50)
+		case logStatsSortP95:
+			c = cmp.Compare(b.P95, a.P95)
+		case logStatsSortP99:
+			c = cmp.Compare(b.P99, a.P99)
+		}
+		return cmp.Or(c, cmp.Compare(b.Requests, a.Requests), strings.Compare(a.Path, b.Path), strings.Compare(a.Method, b.Method))
+	})
+
+	for start, counts := range a.buckets {
+		stats.StatusHistogram = append(stats.StatusHistogram, logStatusBucket{Start: start, Counts: counts})
+	}
+	slices.SortFunc(stats.StatusHistogram, func(a, b logStatusBucket) int {
+		return a.Start.Compare(b.Start)
+	})
+
+	return stats
+}
+
+// writeLogStatsJSON writes the report in JSON
+func writeLogStatsJSON(w io.Writer, stats logStats) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(stats)
+}
+
+// writeLogStatsTable writes the report in aligned tables
+func writeLogStatsTable(w io.Writer, stats logStats) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Requests: %d", stats.Requests)
+	if stats.Requests > 0 {
+		fmt.Fprintf(tw, " (from %s to %s)", stats.From.Format(time.RFC3339), stats.To.Format(time.RFC3339))
+	}
+	fmt.Fprint(tw, "\n\nMETHOD\tPATH\tREQUESTS\t4XX\t5XX\tP50\tP95\tP99\n")
+	for _, e := range stats.Endpoints {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\t%s\t%s\t%s\n",
+			e.Method, e.Path, e.Requests, percent(e.ClientErrorRate), percent(e.ServerErrorRate),
+			formatMilliseconds(e.P50), formatMilliseconds(e.P95), formatMilliseconds(e.P99))
+	}
+
+	fmt.Fprint(tw, "\nIP\tREQUESTS\n")
+	for _, c := range stats.TopIPs {
+		fmt.Fprintf(tw, "%s\t%d\n", c.Value, c.Requests)
+	}
+
+	fmt.Fprint(tw, "\nUSER AGENT\tREQUESTS\n")
+	for _, c := range stats.TopUserAgents {
+		fmt.Fprintf(tw, "%s\t%d\n", c.Value, c.Requests)
+	}
+
+	fmt.Fprintf(tw, "\nBUCKET\t%s\tTOTAL\n", strings.ToUpper(strings.Join(statusClasses, "\t")))
+	for _, b := range stats.StatusHistogram {
+		fmt.Fprint(tw, b.Start.Format(time.RFC3339))
+		total := 0
+		for _, class := range statusClasses {
+			fmt.Fprintf(tw, "\t%d", b.Counts[class])
+			total += b.Counts[class]
+		}
+		fmt.Fprintf(tw, "\t%d\n", total)
+	}
+
+	return tw.Flush()
+}
+
+// groupPathIDs replaces the ID segments of a path by {id}
+func groupPathIDs(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if logStatsIDSegment.MatchString(s) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// remoteIP returns the IP of a remote address with or without port
+func remoteIP(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+// statusClass returns the class of a status code (2xx, 4xx...)
+func statusClass(code uint) string {
+	return fmt.Sprintf("%dxx", code/100)
+}
+
+// percentile returns the nearest-rank percentile of sorted values
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
+
+// topLogCounts returns the values with the most requests
+func topLogCounts(counts map[string]int, top int) []logCount {
+	list := make([]logCount, 0, len(counts))
+	for value, requests := range counts {
+		list = append(list, logCount{Value: value, Requests: requests})
+	}
+	slices.SortFunc(list, func(a, b logCount) int {
+		return cmp.Or(cmp.Compare(b.Requests, a.Requests), strings.Compare(a.Value, b.Value))
+	})
+	if top > 0 && len(list) > top {
+		list = list[:top]
+	}
+	return list
+}
+
+func milliseconds(d time.Duration) float64 {
+	return math.Round(float64(d)/float64(time.Millisecond)*1000) / 1000
+}
+
+func formatMilliseconds(ms float64) string {
+	return fmt.Sprintf("%.3fms", ms)
+}
+
+func percent(rate float64) string {
+	return fmt.Sprintf("%.1f%%", rate*100)
+}
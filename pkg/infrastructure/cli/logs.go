@@ -1,135 +1,173 @@
 package cli
 
 import (
-	"bufio"
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"path"
+	"slices"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	verboseFlag bool
+	verboseFlag   bool
+	logsServer    bool
+	logsDatabase  bool
+	logsFollow    bool
+	logsOutput    string
+	logsLevel     string
+	logsStatus    string
+	logsMethods   []string
+	logsPath      string
+	logsRequestID string
+	logsSince     string
+	logsUntil     string
+	logsSQL       bool
+	logsSlow      time.Duration
+	logsGroupSQL  bool
 )
 
 func init() {
 	logReaderCmd.Flags().BoolVarP(&verboseFlag, "verbose", "v", false, "verbose logs")
+	logReaderCmd.Flags().BoolVarP(&logsFollow, "follow", "f", false, "output appended lines as the files grow (rotations included)")
+	logReaderCmd.Flags().StringVarP(&logsOutput, "output", "o", logOutputPretty, "output mode (pretty | json | csv)")
+	logReaderCmd.Flags().BoolVar(&logsGroupSQL, "group-sql", false, "group the identical SQL queries with their count and total duration")
+
+	// Sources and filters are shared with the subcommands
+	flags := logReaderCmd.PersistentFlags()
+	flags.BoolVarP(&logsServer, "server", "s", false, "read the server log file of the configuration")
+	flags.BoolVarP(&logsDatabase, "database", "d", false, "read the database (GORM) log file of the configuration")
+	flags.StringVarP(&logsLevel, "level", "l", "", "minimum level (debug | info | warn | error | dpanic | panic | fatal)")
+	flags.StringVar(&logsStatus, "status", "", "status code, class or range (404, 5xx, 400-499)")
+	flags.StringSliceVarP(&logsMethods, "method", "m", nil, "HTTP methods (GET,POST...)")
+	flags.StringVarP(&logsPath, "path", "p", "", "glob of the request path (/api/v1/users/*)")
+	flags.StringVarP(&logsRequestID, "request-id", "r", "", "request ID")
+	flags.StringVar(&logsSince, "since", "", "start of the time window (RFC 3339 time, YYYY-MM-DD date or duration like 15m)")
+	flags.StringVar(&logsUntil, "until", "", "end of the time window (RFC 3339 time, YYYY-MM-DD date or duration like 15m)")
+	flags.BoolVar(&logsSQL, "sql", false, "only the SQL queries")
+	flags.DurationVar(&logsSlow, "slow", 0, "only the SQL queries lasting at least this duration (Ex.: 200ms)")
 
 	rootCmd.AddCommand(logReaderCmd)
 }
 
 var logReaderCmd = &cobra.Command{
-	Use:   "logs",
+	Use:   "logs [files...]",
 	Short: "Reader for server logs",
-	Long:  `Reader for server logs`,
+	Long: `Reader for server logs
+
+The logs are read from the files or from stdin if there is no file.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		scanner := bufio.NewScanner(os.Stdin)
-		for scanner.Scan() {
-			line, _ := parseLine(scanner.Bytes(), verboseFlag)
-			fmt.Println(line)
+		filter, err := logsFilterFromFlags(time.Now())
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		var writer logWriter
+		if logsGroupSQL {
+			if logsFollow {
+				log.Fatalln("SQL queries cannot be grouped in follow mode")
+			}
+			writer, err = newSQLGroupWriter(logsOutput, os.Stdout)
+		} else {
+			writer, err = newLogWriter(logsOutput, os.Stdout, verboseFlag)
+		}
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		files, err := logFiles(args, logsServer, logsDatabase)
+		if err != nil {
+			log.Fatalln(err)
 		}
 
-		if err := scanner.Err(); err != nil {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		lines := make(chan []byte)
+		errRead := make(chan error, 1)
+		go func() {
+			errRead <- readLogs(ctx, files, logsFollow, lines)
+		}()
+
+		for line := range lines {
+			if err := writeLogLine(writer, filter, line); err != nil {
+				log.Println(err)
+				stop()
+				break
+			}
+		}
+
+		if err := writer.Flush(); err != nil {
+			log.Println(err)
+		}
+		if err := <-errRead; err != nil {
 			log.Println(err)
 		}
 	},
 }
 
-type errorLog struct {
-	Level       string    `json:"level"`
-	Time        time.Time `json:"time"`
-	Caller      string    `json:"caller"`
-	Message     string    `json:"message"`
-	Description string    `json:"description"`
-	Error       string    `json:"error"`
-	Code        uint      `json:"code"`
-	Method      string    `json:"method"`
-	Path        string    `json:"path"`
-	Body        string    `json:"body"`
-	URL         string    `json:"url"`
-	Host        string    `json:"host"`
-	IP          string    `json:"ip"`
-	RequestID   string    `json:"request_id"`
-	Latency     string    `json:"latency"`
-	UserAgent   string    `json:"userAgent"`
+// logsFilterFromFlags creates the log filter of the command flags
+func logsFilterFromFlags(now time.Time) (logFilter, error) {
+	return newLogFilter(logFilterOptions{
+		Level:     logsLevel,
+		Status:    logsStatus,
+		Methods:   logsMethods,
+		PathGlob:  logsPath,
+		RequestID: logsRequestID,
+		Since:     logsSince,
+		Until:     logsUntil,
+		SQL:       logsSQL,
+		Slow:      logsSlow,
+	}, now)
 }
 
-func parseLine(line []byte, verboseFlag bool) (string, error) {
-	var errLog errorLog
-	err := json.Unmarshal(line, &errLog)
+// writeLogLine writes a line if it matches the filter.
+// The lines which are not JSON logs are only written if the filter is not active.
+func writeLogLine(writer logWriter, filter logFilter, line []byte) error {
+	entry, err := parseLine(line)
 	if err != nil {
-		return string(line), err
+		if filter.active() {
+			return nil
+		}
+		return writer.Write(nil, line)
 	}
 
-	code := ""
-	if errLog.Code != 0 {
-		code = fmt.Sprintf(" | %d", displayLogStatusCode(errLog.Code))
+	if !filter.match(entry) {
+		return nil
 	}
-	message := ""
-	if errLog.Message != "" {
-		message = fmt.Sprintf(" | Message: %s", errLog.Message)
-	}
-	description := ""
-	if errLog.Description != "" {
-		description = fmt.Sprintf(" | Description: %s", errLog.Description)
-	}
-	errorLog := ""
-	if errLog.Error != "" && errLog.Error != "<nil>" {
-		errorLog = fmt.Sprintf(" | Error: %s", errLog.Error)
-	}
-	method := ""
-	if errLog.Method != "" {
-		method = fmt.Sprintf(" | %6s", displayLogMethod(errLog.Method))
-	}
-	url := ""
-	if errLog.URL != "" && verboseFlag {
-		url = fmt.Sprintf(" | %s", errLog.URL)
-	}
-	path := ""
-	if errLog.Path != "" {
-		path = fmt.Sprintf(" | %s", errLog.Path)
-	}
-	host := ""
-	if errLog.Host != "" && verboseFlag {
-		host = fmt.Sprintf(" | %s", errLog.Host)
-	}
-	ip := ""
-	if errLog.IP != "" && verboseFlag {
-		ip = fmt.Sprintf(" | IP: %s", errLog.IP)
+	return writer.Write(&entry, line)
+}
+
+// logFiles returns the files to read: the files of the arguments and the log files of the configuration
+func logFiles(args []string, server, database bool) ([]string, error) {
+	files := slices.Clone(args)
+	if !server && !database {
+		return files, nil
 	}
-	requestID := ""
-	if errLog.RequestID != "" {
-		requestID = fmt.Sprintf(" | RequestID: %s", errLog.RequestID)
+
+	config, err := initConfig()
+	if err != nil {
+		return nil, err
 	}
-	userAgent := ""
-	if errLog.UserAgent != "" && verboseFlag {
-		userAgent = fmt.Sprintf(" | UserAgent: %s", errLog.UserAgent)
+
+	if server {
+		if !slices.Contains(config.Log.Outputs, "file") {
+			return nil, errors.New("server logs are not written in a file (LOG_OUTPUTS)")
+		}
+		files = append(files, fmt.Sprintf("%s/%s.log", path.Clean(config.Log.Path), config.AppName))
 	}
-	latency := ""
-	if errLog.Latency != "" {
-		latency = fmt.Sprintf(" | %s", errLog.Latency)
+	if database {
+		if config.Gorm.LogOutput != "file" || config.Gorm.LogFileName == "" {
+			return nil, errors.New("database logs are not written in a file (GORM_LOG_OUTPUT and GORM_LOG_FILE_NAME)")
+		}
+		files = append(files, path.Clean(config.Gorm.LogFileName))
 	}
 
-	result := fmt.Sprintf("%s | %7s %s%s%s%s%s%s%s%s%s%s%s%s%s",
-		errLog.Time.Format(time.RFC3339),
-		displayLogLevel(errLog.Level),
-		code,
-		method,
-		message,
-		description,
-		errorLog,
-		path,
-		url,
-		host,
-		ip,
-		requestID,
-		userAgent,
-		latency,
-		" | "+errLog.Caller,
-	)
-
-	return result, nil
+	return files, nil
 }
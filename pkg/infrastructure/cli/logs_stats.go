@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Output modes of the logs stats command
+const (
+	logStatsOutputTable = "table"
+	logStatsOutputJSON  = "json"
+)
+
+var (
+	logsStatsOutput   string
+	logsStatsSort     string
+	logsStatsTop      int
+	logsStatsBucket   time.Duration
+	logsStatsRawPaths bool
+)
+
+func init() {
+	logsStatsCmd.Flags().StringVarP(&logsStatsOutput, "output", "o", logStatsOutputTable, "output mode (table | json)")
+	logsStatsCmd.Flags().StringVar(&logsStatsSort, "sort", logStatsSortRequests, "endpoints order (requests | errors | p50 | p95 | p99)")
+	logsStatsCmd.Flags().IntVarP(&logsStatsTop, "top", "t", 10, "number of IPs and user agents, 0 for all")
+	logsStatsCmd.Flags().DurationVarP(&logsStatsBucket, "bucket", "b", time.Hour, "duration of the time buckets of the status histogram")
+	logsStatsCmd.Flags().BoolVar(&logsStatsRawPaths, "raw-paths", false, "do not group the paths with IDs (UUIDs, ULIDs, numbers) as {id}")
+
+	logReaderCmd.AddCommand(logsStatsCmd)
+}
+
+var logsStatsCmd = &cobra.Command{
+	Use:   "stats [files...]",
+	Short: "Access logs report",
+	Long: `Access logs report
+
+Request counts, error rates and latency percentiles by endpoint, top IPs and user agents
+and status histogram from the access logs of the files or of stdin if there is no file.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := checkLogStatsFlags(); err != nil {
+			log.Fatalln(err)
+		}
+
+		filter, err := logsFilterFromFlags(time.Now())
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		files, err := logFiles(args, logsServer, logsDatabase)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		lines := make(chan []byte)
+		errRead := make(chan error, 1)
+		go func() {
+			errRead <- readLogs(ctx, files, false, lines)
+		}()
+
+		aggregator := newLogStatsAggregator(logsStatsBucket, !logsStatsRawPaths)
+		for line := range lines {
+			entry, err := parseLine(line)
+			if err == nil && filter.match(entry) {
+				aggregator.Add(entry)
+			}
+		}
+		if err := <-errRead; err != nil {
+			log.Fatalln(err)
+		}
+
+		stats := aggregator.Report(logsStatsSort, logsStatsTop)
+		if logsStatsOutput == logStatsOutputJSON {
+			err = writeLogStatsJSON(os.Stdout, stats)
+		} else {
+			err = writeLogStatsTable(os.Stdout, stats)
+		}
+		if err != nil {
+			log.Fatalln(err)
+		}
+	},
+}
+
+// checkLogStatsFlags checks the flags of the logs stats command
+func checkLogStatsFlags() error {
+	if logsStatsOutput != logStatsOutputTable && logsStatsOutput != logStatsOutputJSON {
+		return errors.New("invalid output " + logsStatsOutput + " (table | json)")
+	}
+
+	switch logsStatsSort {
+	case logStatsSortRequests, logStatsSortErrors, logStatsSortP50, logStatsSortP95, logStatsSortP99:
+	default:
+		return errors.New("invalid sort " + logsStatsSort + " (requests | errors | p50 | p95 | p99)")
+	}
+
+	if logsStatsBucket <= 0 {
+		return errors.New("invalid bucket duration")
+	}
+	if logsStatsTop < 0 {
+		return errors.New("invalid top")
+	}
+
+	return nil
+}
@@ -0,0 +1,339 @@
+package cli
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStatusRange(t *testing.T) {
+	tests := []struct {
+		value     string
+		wantedMin uint
+		wantedMax uint
+		wantedErr bool
+	}{
+		{value: "404", wantedMin: 404, wantedMax: 404},
+		{value: "5xx", wantedMin: 500, wantedMax: 599},
+		{value: "400-499", wantedMin: 400, wantedMax: 499},
+		{value: "4xx-5xx", wantedMin: 400, wantedMax: 599},
+		{value: "600", wantedErr: true},
+		{value: "6xx", wantedErr: true},
+		{value: "499-400", wantedErr: true},
+		{value: "abc", wantedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			min, max, err := parseStatusRange(tt.value)
+
+			assert.Equal(t, tt.wantedErr, err != nil)
+			assert.Equal(t, tt.wantedMin, min)
+			assert.Equal(t, tt.wantedMax, max)
+		})
+	}
+}
+
+func TestLogFilterMatch(t *testing.T) {
+	now := time.Date(2025, 4, 20, 12, 0, 0, 0, time.UTC)
+	entry := logEntry{
+		Level:     "WARN",
+		Time:      now.Add(-10 * time.Minute),
+		Code:      404,
+		Method:    "GET",
+		Path:      "/api/v1/users/42",
+		RequestID: "abc",
+	}
+
+	sqlEntry := logEntry{Level: "INFO", Time: now, SQL: "SELECT 1", Duration: "250ms"}
+
+	tests := []struct {
+		name    string
+		options logFilterOptions
+		entry   logEntry
+		wanted  bool
+	}{
+		{name: "No filter", entry: entry, wanted: true},
+		{name: "Level", options: logFilterOptions{Level: "info"}, entry: entry, wanted: true},
+		{name: "Higher level", options: logFilterOptions{Level: "error"}, entry: entry, wanted: false},
+		{name: "Status class", options: logFilterOptions{Status: "4xx"}, entry: entry, wanted: true},
+		{name: "Other status", options: logFilterOptions{Status: "500-599"}, entry: entry, wanted: false},
+		{name: "Methods", options: logFilterOptions{Methods: []string{"post", "get"}}, entry: entry, wanted: true},
+		{name: "Other method", options: logFilterOptions{Methods: []string{"DELETE"}}, entry: entry, wanted: false},
+		{name: "Path glob", options: logFilterOptions{PathGlob: "/api/v1/users/*"}, entry: entry, wanted: true},
+		{name: "Other path", options: logFilterOptions{PathGlob: "/api/v1/webhooks/*"}, entry: entry, wanted: false},
+		{name: "Request ID", options: logFilterOptions{RequestID: "abc"}, entry: entry, wanted: true},
+		{name: "Other request ID", options: logFilterOptions{RequestID: "def"}, entry: entry, wanted: false},
+		{name: "Since duration", options: logFilterOptions{Since: "15m"}, entry: entry, wanted: true},
+		{name: "Since time", options: logFilterOptions{Since: "2025-04-20T11:55:00Z"}, entry: entry, wanted: false},
+		{name: "Until", options: logFilterOptions{Until: "5m"}, entry: entry, wanted: true},
+		{name: "Until time", options: logFilterOptions{Until: "2025-04-20T11:45:00Z"}, entry: entry, wanted: false},
+		{name: "SQL", options: logFilterOptions{SQL: true}, entry: sqlEntry, wanted: true},
+		{name: "Not SQL", options: logFilterOptions{SQL: true}, entry: entry, wanted: false},
+		{name: "Slow SQL", options: logFilterOptions{Slow: 200 * time.Millisecond}, entry: sqlEntry, wanted: true},
+		{name: "Fast SQL", options: logFilterOptions{Slow: time.Second}, entry: sqlEntry, wanted: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := newLogFilter(tt.options, now)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wanted, filter.match(tt.entry))
+		})
+	}
+}
+
+func TestNewLogFilterWithInvalidFlags(t *testing.T) {
+	_, err := newLogFilter(logFilterOptions{Level: "verbose"}, time.Now())
+	assert.NotNil(t, err)
+
+	_, err = newLogFilter(logFilterOptions{PathGlob: "["}, time.Now())
+	assert.NotNil(t, err)
+
+	_, err = newLogFilter(logFilterOptions{Since: "yesterday"}, time.Now())
+	assert.NotNil(t, err)
+
+	_, err = newLogFilter(logFilterOptions{Slow: -time.Second}, time.Now())
+	assert.NotNil(t, err)
+}
+
+func TestWriteLogLine(t *testing.T) {
+	lines := [][]byte{
+		[]byte(`{"level":"INFO","time":"2025-04-20T10:00:00Z","code":200,"method":"GET","path":"/","request_id":"a1","latency":"1ms","caller":"x.go:1"}`),
+		[]byte(`{"level":"ERROR","time":"2025-04-20T10:05:00Z","code":500,"method":"POST","path":"/users","message":"boom, again","caller":"x.go:2"}`),
+		[]byte(`not a JSON log`),
+	}
+
+	var buf bytes.Buffer
+	writer, err := newLogWriter(logOutputCSV, &buf, false)
+	assert.Nil(t, err)
+	for _, line := range lines {
+		assert.Nil(t, writeLogLine(writer, logFilter{minLevel: -1}, line))
+	}
+	assert.Nil(t, writer.Flush())
+	assert.Equal(t, `time,level,code,method,path,latency,request_id,message,error,caller,sql,duration
+2025-04-20T10:00:00Z,INFO,200,GET,/,1ms,a1,,,x.go:1,,
+2025-04-20T10:05:00Z,ERROR,500,POST,/users,,,"boom, again",,x.go:2,,
+`, buf.String())
+
+	// Only the matching JSON lines are written with an active filter
+	buf.Reset()
+	filter, _ := newLogFilter(logFilterOptions{Level: "error"}, time.Now())
+	writer, _ = newLogWriter(logOutputJSON, &buf, false)
+	for _, line := range lines {
+		assert.Nil(t, writeLogLine(writer, filter, line))
+	}
+	assert.Equal(t, string(lines[1])+"\n", buf.String())
+
+	_, err = newLogWriter("xml", &buf, false)
+	assert.NotNil(t, err)
+}
+
+func TestFollowLogFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "app.log")
+	assert.Nil(t, os.WriteFile(name, []byte("first\nsec"), 0o600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	lines := make(chan []byte)
+	go func() {
+		readLogs(ctx, []string{name}, true, lines)
+	}()
+
+	next := func() string {
+		select {
+		case line := <-lines:
+			return string(line)
+		case <-time.After(5 * time.Second):
+			return "timeout"
+		}
+	}
+
+	assert.Equal(t, "first", next())
+
+	// The incomplete line is waited
+	appendFile(t, name, "ond\n")
+	assert.Equal(t, "second", next())
+
+	// Rotation
+	assert.Nil(t, os.Rename(name, name+".1"))
+	appendFile(t, name+".1", "third\n")
+	assert.Nil(t, os.WriteFile(name, []byte("fourth\n"), 0o600))
+	assert.Equal(t, "third", next())
+	assert.Equal(t, "fourth", next())
+
+	// Truncation
+	assert.Nil(t, os.WriteFile(name, nil, 0o600))
+	time.Sleep(2 * logFollowInterval)
+	appendFile(t, name, "fifth\n")
+	assert.Equal(t, "fifth", next())
+
+	cancel()
+	_, ok := <-lines
+	assert.False(t, ok)
+}
+
+func TestReadCompressedLogFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "app.log.gz")
+	f, err := os.Create(name)
+	assert.Nil(t, err)
+	gz := gzip.NewWriter(f)
+	_, err = gz.Write([]byte("first\nsecond\n"))
+	assert.Nil(t, err)
+	assert.Nil(t, gz.Close())
+	assert.Nil(t, f.Close())
+
+	lines := make(chan []byte)
+	go func() {
+		readLogs(context.Background(), []string{name}, false, lines)
+	}()
+
+	var got []string
+	for line := range lines {
+		got = append(got, string(line))
+	}
+	assert.Equal(t, []string{"first", "second"}, got)
+}
+
+func appendFile(t *testing.T, name, data string) {
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_APPEND, 0o600)
+	assert.Nil(t, err)
+	_, err = f.WriteString(data)
+	assert.Nil(t, err)
+	assert.Nil(t, f.Close())
+}
+
+func TestLogStatsAggregator(t *testing.T) {
+	start := time.Date(2025, 4, 20, 10, 0, 0, 0, time.UTC)
+	aggregator := newLogStatsAggregator(time.Hour, true)
+	for i := range 100 {
+		code := uint(200)
+		if i%10 == 0 {
+			code = 404
+		}
+		if i == 99 {
+			code = 503
+		}
+		aggregator.Add(logEntry{
+			Time:      start.Add(time.Duration(i) * time.Minute),
+			Code:      code,
+			Method:    "GET",
+			Path:      "/api/v1/users/" + userIDSegment(i),
+			IP:        "10.0.0." + strconv.Itoa(i%3) + ":1234",
+			UserAgent: "curl",
+			Latency:   (time.Duration(i+1) * time.Millisecond).String(),
+		})
+	}
+	aggregator.Add(logEntry{Time: start, Code: 201, Method: "POST", Path: "/api/v1/users", IP: "[::1]:80", Latency: "250ms"})
+	aggregator.Add(logEntry{Time: start, Level: "ERROR", Message: "not an access log"})
+
+	stats := aggregator.Report(logStatsSortRequests, 2)
+
+	assert.Equal(t, 101, stats.Requests)
+	assert.Equal(t, start, stats.From)
+	assert.Equal(t, start.Add(99*time.Minute), stats.To)
+
+	assert.Equal(t, 2, len(stats.Endpoints))
+	assert.Equal(t, logEndpointStats{
+		Method:          "GET",
+		Path:            "/api/v1/users/{id}",
+		Requests:        100,
+		ClientErrors:    10,
+		ServerErrors:    1,
+		ClientErrorRate: 0.1,
+		ServerErrorRate: 0.01,
+		P50:             50,
+		P95:             95,
+		P99:             99,
+	}, stats.Endpoints[0])
+
+	// Sorted by latency
+	stats = aggregator.Report(logStatsSortP99, 0)
+	assert.Equal(t, "POST", stats.Endpoints[0].Method)
+	assert.Equal(t, 250.0, stats.Endpoints[0].P99)
+
+	assert.Equal(t, []logCount{{"10.0.0.0", 34}, {"10.0.0.1", 33}, {"10.0.0.2", 33}, {"::1", 1}}, stats.TopIPs)
+	assert.Equal(t, []logCount{{"curl", 100}}, stats.TopUserAgents)
+
+	assert.Equal(t, []logStatusBucket{
+		{Start: start, Counts: map[string]int{"2xx": 55, "4xx": 6}},
+		{Start: start.Add(time.Hour), Counts: map[string]int{"2xx": 35, "4xx": 4, "5xx": 1}},
+	}, stats.StatusHistogram)
+
+	var buf bytes.Buffer
+	assert.Nil(t, writeLogStatsTable(&buf, stats))
+	assert.Contains(t, buf.String(), "GET     /api/v1/users/{id}  100       10.0%  1.0%  50.000ms   95.000ms   99.000ms")
+}
+
+func TestGroupPathIDs(t *testing.T) {
+	assert.Equal(t, "/api/v1/users/{id}/api-keys/{id}", groupPathIDs("/api/v1/users/1b4e28ba-2fa1-11d2-883f-0016d3cca427/api-keys/01ARZ3NDEKTSV4RRFFQ69G5FAV"))
+	assert.Equal(t, "/api/v1/webhooks/{id}/deliveries", groupPathIDs("/api/v1/webhooks/42/deliveries"))
+	assert.Equal(t, "/api/v1/users", groupPathIDs("/api/v1/users"))
+}
+
+// userIDSegment returns a user ID path segment
+func userIDSegment(i int) string {
+	return fmt.Sprintf("00000000-0000-0000-0000-%012d", i)
+}
+
+func TestNormalizeSQL(t *testing.T) {
+	tests := []struct {
+		sql    string
+		wanted string
+	}{
+		{
+			sql:    "SELECT * FROM `users` WHERE email = 'john@test.com' AND deleted_at IS NULL LIMIT 1",
+			wanted: "SELECT * FROM `users` WHERE email = ? AND deleted_at IS NULL LIMIT ?",
+		},
+		{
+			sql:    "select id from users\n  where id in (1, 2,3) and name = 'O''Brien'",
+			wanted: "SELECT id FROM users WHERE id IN (?) AND name = ?",
+		},
+		{
+			sql:    "UPDATE users SET version = version + 1 WHERE id = \"42\" AND v2 = 3.5",
+			wanted: "UPDATE users SET version = version + ? WHERE id = ? AND v2 = ?",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sql, func(t *testing.T) {
+			assert.Equal(t, tt.wanted, normalizeSQL(tt.sql))
+		})
+	}
+}
+
+func TestSQLGroupWriter(t *testing.T) {
+	lines := [][]byte{
+		[]byte(`{"level":"INFO","time":"2025-04-20T10:00:00Z","message":"sql","sql":"SELECT * FROM users WHERE id = 1","rows":1,"duration":"2ms","slow":false}`),
+		[]byte(`{"level":"WARN","time":"2025-04-20T10:00:01Z","message":"slow sql","sql":"SELECT * FROM users WHERE id = 2","rows":1,"duration":"300ms","slow":true}`),
+		[]byte(`{"level":"INFO","time":"2025-04-20T10:00:02Z","message":"sql","sql":"DELETE FROM users WHERE id = 3","rows":0,"duration":"10ms","slow":false}`),
+		[]byte(`{"level":"INFO","time":"2025-04-20T10:00:03Z","code":200,"method":"GET","path":"/"}`),
+	}
+
+	var buf bytes.Buffer
+	writer, err := newSQLGroupWriter(logOutputJSON, &buf)
+	assert.Nil(t, err)
+	for _, line := range lines {
+		assert.Nil(t, writeLogLine(writer, logFilter{minLevel: -1}, line))
+	}
+
+	assert.Equal(t, []sqlGroup{
+		{SQL: "SELECT * FROM users WHERE id = ?", Count: 2, Total: 302, Avg: 151, Max: 300, Slow: 1, total: 302 * time.Millisecond, max: 300 * time.Millisecond},
+		{SQL: "DELETE FROM users WHERE id = ?", Count: 1, Total: 10, Avg: 10, Max: 10, total: 10 * time.Millisecond, max: 10 * time.Millisecond},
+	}, writer.Groups())
+
+	assert.Nil(t, writer.Flush())
+	assert.Equal(t, `{"sql":"SELECT * FROM users WHERE id = ?","count":2,"total_ms":302,"avg_ms":151,"max_ms":300,"slow":1}
+{"sql":"DELETE FROM users WHERE id = ?","count":1,"total_ms":10,"avg_ms":10,"max_ms":10,"slow":0}
+`, buf.String())
+}
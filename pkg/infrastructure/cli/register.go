@@ -49,7 +49,7 @@ var userCmd = &cobra.Command{
 			log.Fatalln(err)
 		}
 
-		email, err := vo.NewEmail(strings.TrimSpace(userEmail))
+		email, err := vo.NewEmail(userEmail)
 		if err != nil {
 			log.Fatalln(err)
 		}
@@ -64,8 +64,17 @@ var userCmd = &cobra.Command{
 			log.Fatalln("db is not of type *db.GormMySQL")
 		}
 		userRepo := gorm_mysql.NewUser(gormDB)
+		unitOfWork := gorm_mysql.NewUnitOfWork(gormDB)
 		tokenGen := auth.NewJWTTokenGenerator(config.JWT)
-		userUseCase := usecases.NewUser(userRepo, tokenGen)
+		passwordPolicy, err := auth.NewPasswordPolicy(config.Password)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		emailPolicy, err := auth.NewEmailPolicy(config.Email)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		userUseCase := usecases.NewUser(userRepo, unitOfWork, tokenGen, auth.NewPasswordHasher(config.Password), passwordPolicy, emailPolicy)
 		res, errRes := userUseCase.Create(usecases.CreateUserRequest{
 			Email:     email,
 			Password:  password,
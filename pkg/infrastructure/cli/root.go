@@ -3,6 +3,9 @@ package cli
 import (
 	"go-clean-api/pkg"
 	"go-clean-api/pkg/adapters/db"
+	"go-clean-api/pkg/infrastructure/logger"
+	"go-clean-api/pkg/logfile"
+	"log"
 
 	"github.com/logrusorgru/aurora"
 	"github.com/spf13/cobra"
@@ -15,6 +18,12 @@ var rootCmd = &cobra.Command{
 	Short:   "A Go Clean API",
 	Long:    "A Go Clean API",
 	Version: version,
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		// Flush the buffered logs
+		if err := logfile.CloseAll(); err != nil {
+			log.Println(err)
+		}
+	},
 }
 
 // Execute starts CLI
@@ -28,9 +37,15 @@ func initConfig() (*pkg.Config, error) {
 }
 
 // initDatabase initializes database connection.
+// The sensitive data are redacted from the logged queries.
 func initDatabase(config *pkg.Config) (db.DB, error) {
+	redactor, err := logger.NewRedactor(config.Log.Redaction)
+	if err != nil {
+		return nil, err
+	}
+
 	// return db.NewSqlxMySQL(config)
-	return db.NewGormMySQL(config)
+	return db.NewGormMySQL(config, redactor.Option())
 }
 
 func displayLogLevel(l string) aurora.Value {
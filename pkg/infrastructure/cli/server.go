@@ -1,9 +1,13 @@
 package cli
 
 import (
+	"context"
 	"go-clean-api/internal/app"
 	"go-clean-api/pkg/infrastructure/chi_router"
+	"go-clean-api/pkg/infrastructure/idempotency"
 	"go-clean-api/pkg/infrastructure/logger"
+	"go-clean-api/pkg/infrastructure/outbox"
+	"go-clean-api/pkg/logfile"
 	"log"
 	"runtime"
 
@@ -34,7 +38,7 @@ func startServer() {
 		log.Fatalln(err)
 	}
 
-	l, err := logger.NewZapLogger(*config)
+	l, err := logger.New(*config)
 	if err != nil {
 		log.Fatalln(err)
 	}
@@ -47,8 +51,34 @@ func startServer() {
 		log.Fatalln(err)
 	}
 
-	server := chi_router.NewChiServer(deps.Config, deps.Logger, deps.UserUseCase)
-	if err = server.Start(); err != nil {
+	// Domain events dispatcher
+	if deps.Config.Outbox.Enable {
+		worker := outbox.NewWorker(deps.OutboxUseCase, deps.Config.Outbox.Interval, deps.Config.Outbox.BatchSize, deps.Logger)
+		go worker.Start(context.Background())
+	}
+
+	// Expired idempotency keys cleaner
+	if deps.Config.Idempotency.Enable {
+		cleaner := idempotency.NewCleaner(deps.IdempotencyUseCase, deps.Config.Idempotency.CleanupInterval, deps.Logger)
+		go cleaner.Start(context.Background())
+	}
+
+	// Runtime log level (GORM follows the application level)
+	if follower, ok := db.(logger.LevelFollower); ok {
+		l.Level().Follow(follower)
+	}
+	stopLevelSignals := logger.NotifyLevelSignals(l.Level(), deps.Config.Log.LevelRevertAfter)
+	defer stopLevelSignals()
+
+	server := chi_router.NewChiServer(deps.Config, deps.Logger, deps.UserUseCase, deps.AuditLogUseCase, deps.WebhookUseCase, deps.APIKeyUseCase, deps.IdempotencyUseCase, l.Level())
+	err = server.Start()
+
+	// Flush the buffered logs
+	if errClose := logfile.CloseAll(); errClose != nil {
+		log.Println(errClose)
+	}
+
+	if err != nil {
 		log.Fatalln(err)
 	}
 }
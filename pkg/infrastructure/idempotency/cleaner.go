@@ -0,0 +1,63 @@
+package idempotency
+
+import (
+	"context"
+	"go-clean-api/pkg/domain/usecases"
+	"go-clean-api/pkg/infrastructure/logger"
+	"time"
+)
+
+// DefaultInterval is the default interval between two cleanups
+const DefaultInterval = time.Hour
+
+// Cleaner deletes the expired idempotency keys in background
+type Cleaner struct {
+	useCase  usecases.Idempotency
+	interval time.Duration
+	logger   logger.CustomLogger
+}
+
+// NewCleaner creates a new Cleaner
+func NewCleaner(useCase usecases.Idempotency, interval time.Duration, l logger.CustomLogger) *Cleaner {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	return &Cleaner{
+		useCase:  useCase,
+		interval: interval,
+		logger:   l,
+	}
+}
+
+// Start deletes the expired keys at each interval until the context is canceled.
+func (c *Cleaner) Start(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.clean()
+		}
+	}
+}
+
+// clean deletes the expired keys
+func (c *Cleaner) clean() {
+	res, err := c.useCase.DeleteExpired(usecases.DeleteExpiredIdempotencyKeysRequest{})
+	if err != nil {
+		c.logger.Error("error when deleting expired idempotency keys", logger.Fields{
+			logger.Err(err),
+		})
+		return
+	}
+
+	if res.Deleted > 0 {
+		c.logger.Info("expired idempotency keys deleted", logger.Fields{
+			logger.Int64("deleted", res.Deleted),
+		})
+	}
+}
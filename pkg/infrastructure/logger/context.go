@@ -0,0 +1,31 @@
+package logger
+
+import "context"
+
+// contextKey is the key used to store the logger in a context
+type contextKey struct{}
+
+// NewContext returns a copy of the context carrying the logger
+func NewContext(ctx context.Context, l CustomLogger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the logger carried by the context, or fallback if there is none.
+// In a HTTP request, the logger is populated with the request ID, the trace ID,
+// the authenticated user ID and the route pattern.
+func FromContext(ctx context.Context, fallback CustomLogger) CustomLogger {
+	if l, ok := ctx.Value(contextKey{}).(CustomLogger); ok {
+		return l
+	}
+	return fallback
+}
+
+// WithContextFields returns a copy of the context whose logger has the fields in addition.
+// The context is returned as is if it has no logger.
+func WithContextFields(ctx context.Context, fields Fields) context.Context {
+	l := FromContext(ctx, nil)
+	if l == nil {
+		return ctx
+	}
+	return NewContext(ctx, l.With(fields))
+}
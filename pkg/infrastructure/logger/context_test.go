@@ -0,0 +1,28 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLoggerFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "message"}), zapcore.AddSync(&buf), zapcore.DebugLevel)
+	fallback := &ZapLogger{inner: zap.NewNop()}
+	l := &ZapLogger{inner: zap.New(core)}
+
+	ctx := context.Background()
+	assert.Equal(t, fallback, FromContext(ctx, fallback))
+	assert.Equal(t, ctx, WithContextFields(ctx, Fields{String("user_id", "1")}))
+
+	ctx = NewContext(ctx, l.With(Fields{String("request_id", "abc")}))
+	ctx = WithContextFields(ctx, Fields{String("user_id", "1")})
+	FromContext(ctx, fallback).Info("request")
+
+	assert.JSONEq(t, `{"message":"request","request_id":"abc","user_id":"1"}`, buf.String())
+}
@@ -0,0 +1,175 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// LevelFollower is a logger whose level follows the runtime level of the application logger
+// (the GORM logger for example).
+type LevelFollower interface {
+	// SetLogLevel changes the level of the logger from the application log level
+	SetLogLevel(level zapcore.Level)
+
+	// ResetLogLevel restores the configured level of the logger
+	ResetLogLevel()
+}
+
+// LevelStatus represents the current log level of the application
+type LevelStatus struct {
+	// Current level
+	Level zapcore.Level
+
+	// Configured level (LOG_LEVEL)
+	Configured zapcore.Level
+
+	// Time of the automatic revert to the configured level (zero if none)
+	RevertAt time.Time
+}
+
+// Level controls the log level of the application at runtime.
+// A change can be automatically reverted after a delay,
+// so that debug logs cannot be left on by accident.
+type Level struct {
+	mu         sync.Mutex
+	atomic     zap.AtomicLevel
+	configured zapcore.Level
+	followers  []LevelFollower
+	logger     CustomLogger
+	timer      *time.Timer
+	revertAt   time.Time
+	generation uint64
+}
+
+// NewLevel creates a new Level set to the configured level
+func NewLevel(configured zapcore.Level) *Level {
+	return &Level{
+		atomic:     zap.NewAtomicLevelAt(configured),
+		configured: configured,
+	}
+}
+
+// ParseLevel parses a log level (debug | info | warn | error | fatal | panic)
+func ParseLevel(l string) (zapcore.Level, error) {
+	switch l {
+	case "debug":
+		return zapcore.DebugLevel, nil
+	case "info":
+		return zapcore.InfoLevel, nil
+	case "warn":
+		return zapcore.WarnLevel, nil
+	case "error":
+		return zapcore.ErrorLevel, nil
+	case "fatal":
+		return zapcore.FatalLevel, nil
+	case "panic":
+		return zapcore.PanicLevel, nil
+	default:
+		return zapcore.InfoLevel, fmt.Errorf("invalid log level: %q", l)
+	}
+}
+
+// Follow registers a logger following the level changes
+func (l *Level) Follow(f LevelFollower) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.followers = append(l.followers, f)
+	if level := l.atomic.Level(); level != l.configured {
+		f.SetLogLevel(level)
+	}
+}
+
+// Set changes the log level.
+// If revertAfter is positive, the configured level is restored after this delay.
+func (l *Level) Set(level zapcore.Level, revertAfter time.Duration) LevelStatus {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.stopTimer()
+	l.atomic.SetLevel(level)
+	for _, f := range l.followers {
+		f.SetLogLevel(level)
+	}
+
+	if revertAfter > 0 {
+		generation := l.generation
+		l.revertAt = time.Now().Add(revertAfter)
+		l.timer = time.AfterFunc(revertAfter, func() {
+			l.revert(generation)
+		})
+	}
+
+	l.log("log level changed", String("level", level.String()), Duration("revert_after", revertAfter))
+
+	return l.status()
+}
+
+// Reset restores the configured log level
+func (l *Level) Reset() LevelStatus {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.reset()
+	l.log("log level reset", String("level", l.configured.String()))
+
+	return l.status()
+}
+
+// Status returns the current log level
+func (l *Level) Status() LevelStatus {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.status()
+}
+
+// revert restores the configured level if the level has not been changed since the timer start
+func (l *Level) revert(generation uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if generation != l.generation {
+		return
+	}
+
+	l.reset()
+	l.log("log level reverted", String("level", l.configured.String()))
+}
+
+func (l *Level) reset() {
+	l.stopTimer()
+	l.atomic.SetLevel(l.configured)
+	for _, f := range l.followers {
+		f.ResetLogLevel()
+	}
+}
+
+// stopTimer cancels the automatic revert in progress
+func (l *Level) stopTimer() {
+	l.generation++
+	l.revertAt = time.Time{}
+	if l.timer != nil {
+		l.timer.Stop()
+		l.timer = nil
+	}
+}
+
+func (l *Level) status() LevelStatus {
+	return LevelStatus{
+		Level:      l.atomic.Level(),
+		Configured: l.configured,
+		RevertAt:   l.revertAt,
+	}
+}
+
+// log logs a level change as a warning
+func (l *Level) log(msg string, fields ...Field) {
+	if l.logger != nil {
+		l.logger.Warn(msg, fields)
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package logger
+
+import "time"
+
+// NotifyLevelSignals does nothing, SIGUSR1, SIGUSR2 and SIGHUP are only available on Unix systems.
+func NotifyLevelSignals(_ *Level, _ time.Duration) (stop func()) {
+	return func() {}
+}
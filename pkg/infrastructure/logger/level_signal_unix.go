@@ -0,0 +1,43 @@
+//go:build unix
+
+package logger
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// NotifyLevelSignals changes the log level on signals:
+//   - SIGUSR1 sets the debug level, reverted after revertAfter (if positive)
+//   - SIGUSR2 and SIGHUP restore the configured level
+//
+// The returned function stops the handling of the signals.
+func NotifyLevelSignals(level *Level, revertAfter time.Duration) (stop func()) {
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, syscall.SIGUSR1, syscall.SIGUSR2, syscall.SIGHUP)
+
+	go func() {
+		for {
+			select {
+			case sig := <-signals:
+				if sig == syscall.SIGUSR1 {
+					level.Set(zapcore.DebugLevel, revertAfter)
+				} else {
+					level.Reset()
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
@@ -0,0 +1,29 @@
+//go:build unix
+
+package logger
+
+import (
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestNotifyLevelSignals(t *testing.T) {
+	level := NewLevel(zapcore.WarnLevel)
+	stop := NotifyLevelSignals(level, time.Hour)
+	defer stop()
+
+	assert.Nil(t, syscall.Kill(syscall.Getpid(), syscall.SIGUSR1))
+	assert.Eventually(t, func() bool {
+		status := level.Status()
+		return status.Level == zapcore.DebugLevel && !status.RevertAt.IsZero()
+	}, time.Second, 5*time.Millisecond)
+
+	assert.Nil(t, syscall.Kill(syscall.Getpid(), syscall.SIGHUP))
+	assert.Eventually(t, func() bool {
+		return level.Status().Level == zapcore.WarnLevel
+	}, time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,79 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+)
+
+// fakeFollower records the level changes
+type fakeFollower struct {
+	level zapcore.Level
+	reset bool
+}
+
+func (f *fakeFollower) SetLogLevel(level zapcore.Level) {
+	f.level = level
+	f.reset = false
+}
+
+func (f *fakeFollower) ResetLogLevel() {
+	f.reset = true
+}
+
+func TestParseLevel(t *testing.T) {
+	level, err := ParseLevel("debug")
+	assert.Nil(t, err)
+	assert.Equal(t, zapcore.DebugLevel, level)
+
+	_, err = ParseLevel("verbose")
+	assert.NotNil(t, err)
+}
+
+func TestLevelSetAndReset(t *testing.T) {
+	level := NewLevel(zapcore.WarnLevel)
+	follower := &fakeFollower{}
+	level.Follow(follower)
+
+	status := level.Set(zapcore.DebugLevel, 0)
+	assert.Equal(t, LevelStatus{Level: zapcore.DebugLevel, Configured: zapcore.WarnLevel}, status)
+	assert.True(t, level.atomic.Enabled(zapcore.DebugLevel))
+	assert.Equal(t, zapcore.DebugLevel, follower.level)
+
+	status = level.Reset()
+	assert.Equal(t, LevelStatus{Level: zapcore.WarnLevel, Configured: zapcore.WarnLevel}, status)
+	assert.False(t, level.atomic.Enabled(zapcore.InfoLevel))
+	assert.True(t, follower.reset)
+}
+
+func TestLevelFollowAfterChange(t *testing.T) {
+	level := NewLevel(zapcore.WarnLevel)
+	level.Set(zapcore.InfoLevel, 0)
+
+	follower := &fakeFollower{}
+	level.Follow(follower)
+	assert.Equal(t, zapcore.InfoLevel, follower.level)
+}
+
+func TestLevelAutoRevert(t *testing.T) {
+	level := NewLevel(zapcore.WarnLevel)
+
+	status := level.Set(zapcore.DebugLevel, 20*time.Millisecond)
+	assert.False(t, status.RevertAt.IsZero())
+
+	assert.Eventually(t, func() bool {
+		return level.Status() == LevelStatus{Level: zapcore.WarnLevel, Configured: zapcore.WarnLevel}
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestLevelChangeCancelsAutoRevert(t *testing.T) {
+	level := NewLevel(zapcore.WarnLevel)
+
+	level.Set(zapcore.DebugLevel, 20*time.Millisecond)
+	level.Set(zapcore.InfoLevel, 0)
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, LevelStatus{Level: zapcore.InfoLevel, Configured: zapcore.WarnLevel}, level.Status())
+}
@@ -3,8 +3,10 @@ package logger
 import (
 	"errors"
 	"fmt"
+	"go-clean-api/pkg"
 	"os"
 	"path"
+	"time"
 
 	"github.com/fabienbellanger/goutils"
 )
@@ -19,8 +21,22 @@ type Field struct {
 	Type  string
 }
 
+// Field types
+const (
+	TypeString   = "string"
+	TypeInt      = "int"
+	TypeInt64    = "int64"
+	TypeBool     = "bool"
+	TypeDuration = "duration"
+	TypeTime     = "time"
+	TypeError    = "error"
+	TypeAny      = "any"
+)
+
 // NewField creates a new field.
-// Type must be one of the following: int, string, error.
+// Type should be one of the field types (int, string, error, ...).
+// The value is encoded according to its actual type, a mismatch with t does not panic.
+// Prefer the typed constructors (String, Int, Err, ...).
 func NewField(k string, t string, v any) Field {
 	return Field{
 		Key:   k,
@@ -29,9 +45,51 @@ func NewField(k string, t string, v any) Field {
 	}
 }
 
+// String creates a string field.
+func String(k string, v string) Field {
+	return Field{Key: k, Type: TypeString, Value: v}
+}
+
+// Int creates an int field.
+func Int(k string, v int) Field {
+	return Field{Key: k, Type: TypeInt, Value: v}
+}
+
+// Int64 creates an int64 field.
+func Int64(k string, v int64) Field {
+	return Field{Key: k, Type: TypeInt64, Value: v}
+}
+
+// Bool creates a bool field.
+func Bool(k string, v bool) Field {
+	return Field{Key: k, Type: TypeBool, Value: v}
+}
+
+// Duration creates a duration field.
+func Duration(k string, v time.Duration) Field {
+	return Field{Key: k, Type: TypeDuration, Value: v}
+}
+
+// Time creates a time field.
+func Time(k string, v time.Time) Field {
+	return Field{Key: k, Type: TypeTime, Value: v}
+}
+
+// Err creates an error field with the "error" key.
+// A nil error is not logged.
+func Err(err error) Field {
+	return Field{Key: "error", Type: TypeError, Value: err}
+}
+
+// Any creates a field of any type.
+func Any(k string, v any) Field {
+	return Field{Key: k, Type: TypeAny, Value: v}
+}
+
 // CustomLogger is the interface that a logger must implement.
 type CustomLogger interface {
 	FromFields(fields Fields) any
+	With(fields Fields) CustomLogger
 	Debug(msg string, fields ...Fields)
 	Info(msg string, fields ...Fields)
 	Warn(msg string, fields ...Fields)
@@ -40,6 +98,29 @@ type CustomLogger interface {
 	Panic(msg string, fields ...Fields)
 }
 
+// LevelLogger is a CustomLogger whose level can be changed at runtime.
+type LevelLogger interface {
+	CustomLogger
+	Level() *Level
+}
+
+// New creates the application logger with the backend of the configuration (zap or slog).
+func New(config pkg.Config) (LevelLogger, error) {
+	if config.Log.Backend == "slog" {
+		l, err := NewSlogLogger(config)
+		if err != nil {
+			return nil, err
+		}
+		return l, nil
+	}
+
+	l, err := NewZapLogger(config)
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
 // getLoggerOutputs returns an array with the log outputs.
 // Outputs can be stdout and/or file.
 func getLoggerOutputs(logOutputs []string, appName, filePath string) (outputs []string, err error) {
@@ -0,0 +1,131 @@
+package logger
+
+import (
+	"maps"
+	"slices"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Entry is a log recorded by MemoryLogger
+type Entry struct {
+	Level   zapcore.Level
+	Message string
+
+	// Fields of the log and of its logger (added with With), by key
+	Fields map[string]any
+}
+
+// MemoryLogger is a CustomLogger recording the logs in memory, to assert them in tests.
+// The child loggers created with With record their logs in the same store.
+// Fatal does not exit, so that it can be asserted too.
+type MemoryLogger struct {
+	store  *memoryStore
+	fields map[string]any
+}
+
+// memoryStore is the store of the logs, shared by a logger and its children
+type memoryStore struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// NewMemoryLogger creates a new logger recording the logs in memory.
+func NewMemoryLogger() *MemoryLogger {
+	return &MemoryLogger{store: &memoryStore{}}
+}
+
+// Entries returns the recorded logs.
+func (l *MemoryLogger) Entries() []Entry {
+	l.store.mu.Lock()
+	defer l.store.mu.Unlock()
+
+	return slices.Clone(l.store.entries)
+}
+
+// Messages returns the messages of the recorded logs of a level.
+func (l *MemoryLogger) Messages(level zapcore.Level) []string {
+	var messages []string
+	for _, e := range l.Entries() {
+		if e.Level == level {
+			messages = append(messages, e.Message)
+		}
+	}
+	return messages
+}
+
+// Reset deletes the recorded logs.
+func (l *MemoryLogger) Reset() {
+	l.store.mu.Lock()
+	defer l.store.mu.Unlock()
+
+	l.store.entries = nil
+}
+
+// FromFields converts fields to a map of values by key.
+func (l *MemoryLogger) FromFields(fields Fields) any {
+	values := make(map[string]any, len(fields))
+	for _, f := range fields {
+		if f.Type == TypeError {
+			if f.Value != nil {
+				values["error"] = f.Value
+			}
+			continue
+		}
+		values[f.Key] = f.Value
+	}
+	return values
+}
+
+// With returns a child logger adding the fields to each log.
+func (l *MemoryLogger) With(fields Fields) CustomLogger {
+	values := maps.Clone(l.fields)
+	if values == nil {
+		values = make(map[string]any, len(fields))
+	}
+	maps.Copy(values, l.FromFields(fields).(map[string]any))
+
+	return &MemoryLogger{store: l.store, fields: values}
+}
+
+func (l *MemoryLogger) Debug(msg string, fields ...Fields) {
+	l.record(zapcore.DebugLevel, msg, fields)
+}
+
+func (l *MemoryLogger) Info(msg string, fields ...Fields) {
+	l.record(zapcore.InfoLevel, msg, fields)
+}
+
+func (l *MemoryLogger) Warn(msg string, fields ...Fields) {
+	l.record(zapcore.WarnLevel, msg, fields)
+}
+
+func (l *MemoryLogger) Error(msg string, fields ...Fields) {
+	l.record(zapcore.ErrorLevel, msg, fields)
+}
+
+func (l *MemoryLogger) Fatal(msg string, fields ...Fields) {
+	l.record(zapcore.FatalLevel, msg, fields)
+}
+
+// Panic records the log and panics.
+func (l *MemoryLogger) Panic(msg string, fields ...Fields) {
+	l.record(zapcore.PanicLevel, msg, fields)
+	panic(msg)
+}
+
+func (l *MemoryLogger) record(level zapcore.Level, msg string, fields []Fields) {
+	values := maps.Clone(l.fields)
+	if values == nil {
+		values = make(map[string]any)
+	}
+	if len(fields) == 1 {
+		maps.Copy(values, l.FromFields(fields[0]).(map[string]any))
+	}
+
+	l.store.mu.Lock()
+	defer l.store.mu.Unlock()
+
+	l.store.entries = append(l.store.entries, Entry{Level: level, Message: msg, Fields: values})
+}
@@ -0,0 +1,39 @@
+package logger
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestMemoryLogger(t *testing.T) {
+	l := NewMemoryLogger()
+	child := l.With(Fields{String("request_id", "123")})
+
+	l.Info("started")
+	child.Error("failed", Fields{Int("code", 500), Err(errors.New("timeout")), Err(nil)})
+	l.Fatal("stopped")
+	assert.Panics(t, func() { child.Panic("panic") })
+
+	assert.Equal(t, []Entry{
+		{Level: zapcore.InfoLevel, Message: "started", Fields: map[string]any{}},
+		{Level: zapcore.ErrorLevel, Message: "failed", Fields: map[string]any{"request_id": "123", "code": 500, "error": errors.New("timeout")}},
+		{Level: zapcore.FatalLevel, Message: "stopped", Fields: map[string]any{}},
+		{Level: zapcore.PanicLevel, Message: "panic", Fields: map[string]any{"request_id": "123"}},
+	}, l.Entries())
+	assert.Equal(t, []string{"failed"}, l.Messages(zapcore.ErrorLevel))
+
+	l.Reset()
+	assert.Empty(t, child.(*MemoryLogger).Entries())
+}
+
+func TestNopLogger(t *testing.T) {
+	l := NewNopLogger()
+
+	assert.NotPanics(t, func() {
+		l.With(Fields{String("request_id", "123")}).Error("failed", Fields{Err(errors.New("timeout"))})
+	})
+	assert.Panics(t, func() { l.Panic("panic") })
+}
@@ -0,0 +1,38 @@
+package logger
+
+import "os"
+
+// NopLogger is a CustomLogger discarding all the logs.
+// Like the other loggers, Panic still panics and Fatal still exits.
+type NopLogger struct{}
+
+// NewNopLogger creates a new logger discarding all the logs.
+func NewNopLogger() *NopLogger {
+	return &NopLogger{}
+}
+
+// FromFields returns nil, the fields are discarded.
+func (l *NopLogger) FromFields(_ Fields) any {
+	return nil
+}
+
+// With returns the logger itself.
+func (l *NopLogger) With(_ Fields) CustomLogger {
+	return l
+}
+
+func (l *NopLogger) Debug(_ string, _ ...Fields) {}
+
+func (l *NopLogger) Info(_ string, _ ...Fields) {}
+
+func (l *NopLogger) Warn(_ string, _ ...Fields) {}
+
+func (l *NopLogger) Error(_ string, _ ...Fields) {}
+
+func (l *NopLogger) Fatal(_ string, _ ...Fields) {
+	os.Exit(1)
+}
+
+func (l *NopLogger) Panic(msg string, _ ...Fields) {
+	panic(msg)
+}
@@ -0,0 +1,286 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"go-clean-api/pkg"
+	"log/slog"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Redacted replaces the sensitive data in the logs
+const Redacted = "[REDACTED]"
+
+// defaultRedactedFields are the names of the fields whose values are always redacted
+var defaultRedactedFields = []string{
+	"password",
+	"new_password",
+	"token",
+	"access_token",
+	"refresh_token",
+	"authorization",
+	"cookie",
+	"set-cookie",
+	"secret",
+	"api_key",
+	"x-api-key",
+}
+
+// defaultRedactionPatterns are the patterns of the sensitive data redacted in the messages and the field values
+var defaultRedactionPatterns = []*regexp.Regexp{
+	// Bearer tokens (before JWTs which they can contain)
+	regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9\-._~+/]+=*`),
+	// JWTs
+	regexp.MustCompile(`\beyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+	// bcrypt hashes
+	regexp.MustCompile(`\$2[abxy]?\$\d{2}\$[./A-Za-z0-9]{53}`),
+	// argon2 hashes
+	regexp.MustCompile(`\$argon2(?:id|i|d)\$[A-Za-z0-9$=,+/.]+`),
+	// Emails
+	regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`),
+}
+
+// Redactor masks the sensitive data of the logs (emails, JWTs, bearer tokens, password hashes
+// and the values of the sensitive fields) before their encoding.
+// A nil Redactor does not mask anything.
+type Redactor struct {
+	fields   map[string]struct{}
+	patterns []*regexp.Regexp
+}
+
+// NewRedactor creates a new Redactor with the default fields and patterns
+// and those of the configuration.
+// It returns nil if the redaction is disabled.
+func NewRedactor(config pkg.ConfigLogRedaction) (*Redactor, error) {
+	if !config.Enable {
+		return nil, nil
+	}
+
+	r := Redactor{
+		fields:   make(map[string]struct{}, len(defaultRedactedFields)+len(config.Fields)),
+		patterns: append([]*regexp.Regexp{}, defaultRedactionPatterns...),
+	}
+	for _, f := range slices.Concat(defaultRedactedFields, config.Fields) {
+		r.fields[strings.ToLower(f)] = struct{}{}
+	}
+	for _, p := range config.Patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, err
+		}
+		r.patterns = append(r.patterns, re)
+	}
+
+	return &r, nil
+}
+
+// String masks the sensitive data of a string
+func (r *Redactor) String(s string) string {
+	if r == nil {
+		return s
+	}
+
+	for _, re := range r.patterns {
+		s = re.ReplaceAllString(s, Redacted)
+	}
+	return s
+}
+
+// WrapCore returns a core masking the sensitive data of the entries before writing them in core
+func (r *Redactor) WrapCore(core zapcore.Core) zapcore.Core {
+	if r == nil {
+		return core
+	}
+
+	return &redactCore{Core: core, redactor: r}
+}
+
+// WrapHandler returns a slog handler masking the sensitive data of the records before passing them to handler
+func (r *Redactor) WrapHandler(handler slog.Handler) slog.Handler {
+	if r == nil {
+		return handler
+	}
+
+	return &redactHandler{Handler: handler, redactor: r}
+}
+
+// Option returns the zap option masking the sensitive data of a logger
+func (r *Redactor) Option() zap.Option {
+	return zap.WrapCore(r.WrapCore)
+}
+
+// isSensitive returns true if the value of the field must be redacted
+func (r *Redactor) isSensitive(key string) bool {
+	_, ok := r.fields[strings.ToLower(key)]
+	return ok
+}
+
+// redactFields masks the sensitive data of fields
+func (r *Redactor) redactFields(fields []zapcore.Field) []zapcore.Field {
+	redacted := make([]zapcore.Field, 0, len(fields))
+	for _, f := range fields {
+		switch {
+		case f.Type == zapcore.SkipType:
+			continue
+		case r.isSensitive(f.Key):
+			redacted = append(redacted, zap.String(f.Key, Redacted))
+		case f.Type == zapcore.StringType:
+			redacted = append(redacted, zap.String(f.Key, r.String(f.String)))
+		case f.Type == zapcore.ErrorType:
+			if err, ok := f.Interface.(error); ok && err != nil {
+				redacted = append(redacted, zap.String(f.Key, r.String(err.Error())))
+			}
+		case f.Type == zapcore.StringerType,
+			f.Type == zapcore.ByteStringType,
+			f.Type == zapcore.ReflectType,
+			f.Type == zapcore.ObjectMarshalerType,
+			f.Type == zapcore.ArrayMarshalerType,
+			f.Type == zapcore.InlineMarshalerType:
+			// Complex values are encoded to be redacted recursively
+			enc := zapcore.NewMapObjectEncoder()
+			f.AddTo(enc)
+
+			keys := make([]string, 0, len(enc.Fields))
+			for k := range enc.Fields {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+
+			for _, k := range keys {
+				redacted = append(redacted, zap.Any(k, r.redactValue(k, enc.Fields[k])))
+			}
+		default:
+			redacted = append(redacted, f)
+		}
+	}
+	return redacted
+}
+
+// redactValue masks the sensitive data of an encoded value
+func (r *Redactor) redactValue(key string, v any) any {
+	if r.isSensitive(key) {
+		return Redacted
+	}
+
+	switch value := v.(type) {
+	case string:
+		return r.String(value)
+	case []byte:
+		return r.String(string(value))
+	case map[string]any:
+		redacted := make(map[string]any, len(value))
+		for k, item := range value {
+			redacted[k] = r.redactValue(k, item)
+		}
+		return redacted
+	case []any:
+		redacted := make([]any, len(value))
+		for i, item := range value {
+			redacted[i] = r.redactValue("", item)
+		}
+		return redacted
+	case nil, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return value
+	default:
+		// Other values (structs for example) are converted from their JSON encoding, as encoded by zap
+		b, err := json.Marshal(value)
+		if err != nil {
+			return value
+		}
+		var generic any
+		if err := json.Unmarshal(b, &generic); err != nil {
+			return value
+		}
+		switch generic.(type) {
+		case string, map[string]any, []any:
+			return r.redactValue("", generic)
+		default:
+			return value
+		}
+	}
+}
+
+// redactCore is a zapcore.Core masking the sensitive data of the entries
+type redactCore struct {
+	zapcore.Core
+	redactor *Redactor
+}
+
+func (c *redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactCore{Core: c.Core.With(c.redactor.redactFields(fields)), redactor: c.redactor}
+}
+
+func (c *redactCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(e.Level) {
+		return ce.AddCore(e, c)
+	}
+	return ce
+}
+
+func (c *redactCore) Write(e zapcore.Entry, fields []zapcore.Field) error {
+	e.Message = c.redactor.String(e.Message)
+	e.Stack = c.redactor.String(e.Stack)
+
+	return c.Core.Write(e, c.redactor.redactFields(fields))
+}
+
+// redactAttrs masks the sensitive data of slog attributes
+func (r *Redactor) redactAttrs(attrs []slog.Attr) []slog.Attr {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = r.redactAttr(a)
+	}
+	return redacted
+}
+
+// redactAttr masks the sensitive data of a slog attribute
+func (r *Redactor) redactAttr(a slog.Attr) slog.Attr {
+	a.Value = a.Value.Resolve()
+	if r.isSensitive(a.Key) {
+		return slog.String(a.Key, Redacted)
+	}
+
+	switch a.Value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, r.String(a.Value.String()))
+	case slog.KindGroup:
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(r.redactAttrs(a.Value.Group())...)}
+	case slog.KindAny:
+		if err, ok := a.Value.Any().(error); ok {
+			return slog.String(a.Key, r.String(err.Error()))
+		}
+		return slog.Any(a.Key, r.redactValue(a.Key, a.Value.Any()))
+	default:
+		return a
+	}
+}
+
+// redactHandler is a slog.Handler masking the sensitive data of the records
+type redactHandler struct {
+	slog.Handler
+	redactor *Redactor
+}
+
+func (h *redactHandler) Handle(ctx context.Context, record slog.Record) error {
+	redacted := slog.NewRecord(record.Time, record.Level, h.redactor.String(record.Message), record.PC)
+	record.Attrs(func(a slog.Attr) bool {
+		redacted.AddAttrs(h.redactor.redactAttr(a))
+		return true
+	})
+
+	return h.Handler.Handle(ctx, redacted)
+}
+
+func (h *redactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &redactHandler{Handler: h.Handler.WithAttrs(h.redactor.redactAttrs(attrs)), redactor: h.redactor}
+}
+
+func (h *redactHandler) WithGroup(name string) slog.Handler {
+	return &redactHandler{Handler: h.Handler.WithGroup(name), redactor: h.redactor}
+}
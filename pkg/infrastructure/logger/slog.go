@@ -0,0 +1,289 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"go-clean-api/pkg"
+	"go-clean-api/pkg/logfile"
+	"io"
+	"log/slog"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// slog levels of the panics and the fatal errors, which have no slog equivalent
+const (
+	slogLevelPanic = slog.Level(12)
+	slogLevelFatal = slog.Level(16)
+)
+
+// SlogLogger is a CustomLogger backed by a log/slog handler.
+type SlogLogger struct {
+	handler slog.Handler
+	level   *Level
+}
+
+// NewSlogLogger creates a new custom slog logger.
+// The logs have the same JSON format as those of ZapLogger (message, level, time and caller keys),
+// so that they can be read by the logs command.
+// The sensitive data are redacted from the messages and the attributes (see Redactor).
+// The log files are rotated and their writes are buffered according to the configuration,
+// they must be flushed at shutdown with logfile.CloseAll.
+func NewSlogLogger(config pkg.Config) (*SlogLogger, error) {
+	// Logs outputs
+	outputs, err := getLoggerOutputs(config.Log.Outputs, config.AppName, config.Log.Path)
+	if err != nil {
+		return nil, err
+	}
+	writers := make([]io.Writer, 0, len(outputs))
+	for _, output := range outputs {
+		if output == "stdout" {
+			writers = append(writers, os.Stdout)
+			continue
+		}
+
+		file, err := logfile.Open(output, config.Log)
+		if err != nil {
+			return nil, err
+		}
+		writers = append(writers, file)
+	}
+
+	// Redaction of the sensitive data
+	redactor, err := NewRedactor(config.Log.Redaction)
+	if err != nil {
+		return nil, err
+	}
+
+	// Level
+	level := NewLevel(getZapLoggerLevel(config.Log.Level, config.AppEnv))
+
+	handler := slog.NewJSONHandler(io.MultiWriter(writers...), &slog.HandlerOptions{
+		AddSource:   true,
+		Level:       slogLeveler{level: level},
+		ReplaceAttr: replaceSlogAttr,
+	})
+
+	l := &SlogLogger{handler: redactor.WrapHandler(handler), level: level}
+	level.logger = l
+
+	return l, nil
+}
+
+// NewSlogLoggerWithHandler creates a custom logger writing to a slog handler (a third-party one for example).
+// The logs are filtered by level before being passed to the handler.
+// If level is nil, all the logs are passed to the handler which filters them itself.
+// The sensitive data are not redacted unless the handler is wrapped with Redactor.WrapHandler.
+func NewSlogLoggerWithHandler(handler slog.Handler, level *Level) *SlogLogger {
+	if level == nil {
+		level = NewLevel(zapcore.DebugLevel)
+	}
+
+	l := &SlogLogger{
+		handler: &slogLevelHandler{Handler: handler, leveler: slogLeveler{level: level}},
+		level:   level,
+	}
+	if level.logger == nil {
+		level.logger = l
+	}
+
+	return l
+}
+
+// Level returns the controller of the log level, used to change it at runtime.
+func (l *SlogLogger) Level() *Level {
+	return l.level
+}
+
+// FromFields converts fields to slog.Attr.
+func (l *SlogLogger) FromFields(fields Fields) any {
+	return slogAttrs(fields)
+}
+
+// With returns a child logger adding the fields to each log.
+func (l *SlogLogger) With(fields Fields) CustomLogger {
+	return &SlogLogger{
+		handler: l.handler.WithAttrs(slogAttrs(fields)),
+		level:   l.level,
+	}
+}
+
+func (l *SlogLogger) Debug(msg string, fields ...Fields) {
+	l.log(slog.LevelDebug, msg, fields)
+}
+
+func (l *SlogLogger) Info(msg string, fields ...Fields) {
+	l.log(slog.LevelInfo, msg, fields)
+}
+
+func (l *SlogLogger) Warn(msg string, fields ...Fields) {
+	l.log(slog.LevelWarn, msg, fields)
+}
+
+func (l *SlogLogger) Error(msg string, fields ...Fields) {
+	l.log(slog.LevelError, msg, fields)
+}
+
+// Fatal logs the message, flushes the log files and exits.
+func (l *SlogLogger) Fatal(msg string, fields ...Fields) {
+	l.log(slogLevelFatal, msg, fields)
+	_ = logfile.CloseAll()
+	os.Exit(1)
+}
+
+// Panic logs the message and panics.
+func (l *SlogLogger) Panic(msg string, fields ...Fields) {
+	l.log(slogLevelPanic, msg, fields)
+	panic(msg)
+}
+
+// log writes a record with the caller of the exported method as source
+func (l *SlogLogger) log(level slog.Level, msg string, fields []Fields) {
+	ctx := context.Background()
+	if !l.handler.Enabled(ctx, level) {
+		return
+	}
+
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:]) // Skip runtime.Callers, log and the exported method
+
+	r := slog.NewRecord(time.Now(), level, msg, pcs[0])
+	if len(fields) == 1 {
+		r.AddAttrs(slogAttrs(fields[0])...)
+	}
+	_ = l.handler.Handle(ctx, r)
+}
+
+// slogAttrs converts fields to slog.Attr
+func slogAttrs(fields Fields) []slog.Attr {
+	attrs := make([]slog.Attr, 0, len(fields))
+	for _, f := range fields {
+		if f.Type == TypeError && f.Value == nil {
+			continue
+		}
+		attrs = append(attrs, slogAttr(f))
+	}
+	return attrs
+}
+
+// slogAttr converts a field to slog.Attr.
+// The field is encoded according to the type of its value, so a wrong Field.Type cannot panic.
+func slogAttr(f Field) slog.Attr {
+	switch v := f.Value.(type) {
+	case error:
+		if f.Type == TypeError {
+			return slog.String("error", v.Error())
+		}
+		return slog.String(f.Key, v.Error())
+	case string:
+		return slog.String(f.Key, v)
+	case int:
+		return slog.Int(f.Key, v)
+	case int64:
+		return slog.Int64(f.Key, v)
+	case bool:
+		return slog.Bool(f.Key, v)
+	case time.Duration:
+		return slog.Duration(f.Key, v)
+	case time.Time:
+		return slog.Time(f.Key, v)
+	default:
+		return slog.Any(f.Key, v)
+	}
+}
+
+// toSlogLevel converts a zap level to a slog level
+func toSlogLevel(level zapcore.Level) slog.Level {
+	switch level {
+	case zapcore.DebugLevel:
+		return slog.LevelDebug
+	case zapcore.InfoLevel:
+		return slog.LevelInfo
+	case zapcore.WarnLevel:
+		return slog.LevelWarn
+	case zapcore.ErrorLevel:
+		return slog.LevelError
+	case zapcore.DPanicLevel, zapcore.PanicLevel:
+		return slogLevelPanic
+	default:
+		return slogLevelFatal
+	}
+}
+
+// slogLevelName returns the name of a slog level as encoded by zap (DEBUG, INFO, WARN, ERROR, PANIC, FATAL)
+func slogLevelName(level slog.Level) string {
+	switch {
+	case level >= slogLevelFatal:
+		return "FATAL"
+	case level >= slogLevelPanic:
+		return "PANIC"
+	default:
+		return level.String()
+	}
+}
+
+// replaceSlogAttr renames and formats the built-in attributes like the zap encoder does
+func replaceSlogAttr(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) > 0 {
+		return a
+	}
+
+	switch a.Key {
+	case slog.MessageKey:
+		a.Key = "message"
+	case slog.LevelKey:
+		if level, ok := a.Value.Any().(slog.Level); ok {
+			a.Value = slog.StringValue(slogLevelName(level))
+		}
+	case slog.TimeKey:
+		if a.Value.Kind() == slog.KindTime {
+			a.Value = slog.StringValue(a.Value.Time().Format(time.RFC3339))
+		}
+	case slog.SourceKey:
+		if source, ok := a.Value.Any().(*slog.Source); ok {
+			a = slog.String("caller", shortCaller(source.File, source.Line))
+		}
+	}
+	return a
+}
+
+// shortCaller returns the caller as package/file:line, like zapcore.ShortCallerEncoder
+func shortCaller(file string, line int) string {
+	if i := strings.LastIndexByte(file, '/'); i >= 0 {
+		if j := strings.LastIndexByte(file[:i], '/'); j >= 0 {
+			file = file[j+1:]
+		}
+	}
+	return fmt.Sprintf("%s:%d", file, line)
+}
+
+// slogLeveler is a slog.Leveler following the runtime log level
+type slogLeveler struct {
+	level *Level
+}
+
+func (l slogLeveler) Level() slog.Level {
+	return toSlogLevel(l.level.atomic.Level())
+}
+
+// slogLevelHandler is a slog.Handler filtering the records by the runtime log level
+type slogLevelHandler struct {
+	slog.Handler
+	leveler slog.Leveler
+}
+
+func (h *slogLevelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.leveler.Level() && h.Handler.Enabled(ctx, level)
+}
+
+func (h *slogLevelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &slogLevelHandler{Handler: h.Handler.WithAttrs(attrs), leveler: h.leveler}
+}
+
+func (h *slogLevelHandler) WithGroup(name string) slog.Handler {
+	return &slogLevelHandler{Handler: h.Handler.WithGroup(name), leveler: h.leveler}
+}
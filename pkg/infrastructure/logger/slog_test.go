@@ -0,0 +1,168 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"go-clean-api/pkg"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+)
+
+// newTestSlogLogger returns a slog logger with the JSON format of NewSlogLogger writing in a buffer
+func newTestSlogLogger(t *testing.T, configured zapcore.Level, config pkg.ConfigLogRedaction) (*SlogLogger, *bytes.Buffer) {
+	redactor, err := NewRedactor(config)
+	assert.Nil(t, err)
+
+	var buf bytes.Buffer
+	level := NewLevel(configured)
+	handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		AddSource:   true,
+		Level:       slogLeveler{level: level},
+		ReplaceAttr: replaceSlogAttr,
+	})
+
+	l := &SlogLogger{handler: redactor.WrapHandler(handler), level: level}
+	level.logger = l
+
+	return l, &buf
+}
+
+// decodeLogs decodes the JSON logs of a buffer
+func decodeLogs(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var logs []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var log map[string]any
+		assert.Nil(t, json.Unmarshal([]byte(line), &log))
+		logs = append(logs, log)
+	}
+	return logs
+}
+
+func TestSlogLoggerFormat(t *testing.T) {
+	l, buf := newTestSlogLogger(t, zapcore.DebugLevel, pkg.ConfigLogRedaction{})
+
+	l.With(Fields{String("request_id", "123")}).Error("internal error", Fields{
+		Int("code", 500),
+		Err(errors.New("connection refused")),
+		Duration("latency", time.Second),
+		Bool("retry", false),
+	})
+
+	logs := decodeLogs(t, buf)
+	assert.Equal(t, 1, len(logs))
+	assert.Equal(t, "internal error", logs[0]["message"])
+	assert.Equal(t, "ERROR", logs[0]["level"])
+	assert.Equal(t, "123", logs[0]["request_id"])
+	assert.Equal(t, float64(500), logs[0]["code"])
+	assert.Equal(t, "connection refused", logs[0]["error"])
+	assert.Equal(t, float64(time.Second), logs[0]["latency"])
+	assert.Equal(t, false, logs[0]["retry"])
+	assert.True(t, strings.HasPrefix(logs[0]["caller"].(string), "logger/slog_test.go:"))
+
+	_, err := time.Parse(time.RFC3339, logs[0]["time"].(string))
+	assert.Nil(t, err)
+}
+
+func TestSlogLoggerLevels(t *testing.T) {
+	l, buf := newTestSlogLogger(t, zapcore.WarnLevel, pkg.ConfigLogRedaction{})
+
+	l.Debug("debug")
+	l.Info("info")
+	l.Warn("warn")
+	assert.Panics(t, func() { l.Panic("panic") })
+
+	logs := decodeLogs(t, buf)
+	assert.Equal(t, 2, len(logs))
+	assert.Equal(t, "WARN", logs[0]["level"])
+	assert.Equal(t, "PANIC", logs[1]["level"])
+
+	// Runtime level
+	buf.Reset()
+	l.Level().Set(zapcore.DebugLevel, 0)
+	l.Debug("debug")
+
+	logs = decodeLogs(t, buf)
+	assert.Equal(t, 2, len(logs))
+	assert.Equal(t, "log level changed", logs[0]["message"])
+	assert.Equal(t, "debug", logs[1]["message"])
+}
+
+func TestSlogLoggerRedaction(t *testing.T) {
+	l, buf := newTestSlogLogger(t, zapcore.DebugLevel, pkg.ConfigLogRedaction{Enable: true})
+
+	l.With(Fields{String("token", testJWT)}).Warn("user "+testEmail, Fields{
+		String("header", testBearer),
+		String("password", "correct horse"),
+		Err(errors.New("invalid hash " + testBcrypt)),
+		Any("credentials", testCredentials{Email: testEmail, Password: "correct horse", Lastname: "Doe"}),
+		Any("hashes", []string{testArgon2}),
+	})
+
+	logs := buf.String()
+	assertNoSecrets(t, logs)
+	assert.Contains(t, logs, `"lastname":"Doe"`)
+	assert.Contains(t, logs, `"password":"[REDACTED]"`)
+}
+
+func TestSlogLoggerWithHandler(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+
+	// Level filtered by the logger
+	level := NewLevel(zapcore.InfoLevel)
+	l := NewSlogLoggerWithHandler(handler, level)
+	l.Debug("debug")
+	l.Info("info", Fields{String("user_id", "1")})
+
+	assert.NotContains(t, buf.String(), "msg=debug")
+	assert.Contains(t, buf.String(), "msg=info user_id=1")
+
+	// Level filtered by the handler
+	buf.Reset()
+	l = NewSlogLoggerWithHandler(slog.NewTextHandler(&buf, nil), nil)
+	l.Debug("debug")
+	l.Info("info")
+
+	assert.NotContains(t, buf.String(), "msg=debug")
+	assert.Contains(t, buf.String(), "msg=info")
+}
+
+func TestSlogFromFields(t *testing.T) {
+	fields := Fields{
+		NewField("code", "int", "500"),
+		NewField("err", "error", errors.New("internal server error")),
+		Err(nil),
+	}
+
+	l := &SlogLogger{}
+	attrs := l.FromFields(fields).([]slog.Attr)
+
+	assert.Equal(t, []slog.Attr{
+		slog.String("code", "500"),
+		slog.String("error", "internal server error"),
+	}, attrs)
+}
+
+func TestToSlogLevel(t *testing.T) {
+	cases := map[zapcore.Level]string{
+		zapcore.DebugLevel: "DEBUG",
+		zapcore.InfoLevel:  "INFO",
+		zapcore.WarnLevel:  "WARN",
+		zapcore.ErrorLevel: "ERROR",
+		zapcore.PanicLevel: "PANIC",
+		zapcore.FatalLevel: "FATAL",
+	}
+
+	for level, expected := range cases {
+		assert.Equal(t, expected, slogLevelName(toSlogLevel(level)))
+	}
+}
@@ -2,6 +2,9 @@ package logger
 
 import (
 	"go-clean-api/pkg"
+	"go-clean-api/pkg/logfile"
+	"os"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -9,68 +12,118 @@ import (
 
 type ZapLogger struct {
 	inner *zap.Logger
+	level *Level
 }
 
 // NewZapLogger creates a new custom Zap logger.
+// The sensitive data are redacted from the messages and the fields before their encoding (see Redactor).
+// The log files are rotated and their writes are buffered according to the configuration,
+// they must be flushed at shutdown with logfile.CloseAll.
 func NewZapLogger(config pkg.Config) (*ZapLogger, error) {
 	// Logs outputs
 	outputs, err := getLoggerOutputs(config.Log.Outputs, config.AppName, config.Log.Path)
 	if err != nil {
 		return nil, err
 	}
+	syncers := make([]zapcore.WriteSyncer, 0, len(outputs))
+	for _, output := range outputs {
+		if output == "stdout" {
+			syncers = append(syncers, zapcore.Lock(os.Stdout))
+			continue
+		}
 
-	// Level
-	level := getZapLoggerLevel(config.Log.Level, config.AppEnv)
-
-	cfg := zap.Config{
-		Encoding:         "json",
-		Level:            zap.NewAtomicLevelAt(level),
-		OutputPaths:      outputs,
-		ErrorOutputPaths: outputs,
-		EncoderConfig: zapcore.EncoderConfig{
-			MessageKey:   "message",
-			LevelKey:     "level",
-			EncodeLevel:  zapcore.CapitalLevelEncoder,
-			TimeKey:      "time",
-			EncodeTime:   zapcore.RFC3339TimeEncoder,
-			CallerKey:    "caller",
-			EncodeCaller: zapcore.ShortCallerEncoder,
-		},
+		file, err := logfile.Open(output, config.Log)
+		if err != nil {
+			return nil, err
+		}
+		syncers = append(syncers, file)
 	}
+	out := zapcore.NewMultiWriteSyncer(syncers...)
 
-	defaultLogger, err := zap.NewProduction()
+	// Redaction of the sensitive data
+	redactor, err := NewRedactor(config.Log.Redaction)
 	if err != nil {
 		return nil, err
 	}
 
-	logger, err := cfg.Build(
+	// Level
+	level := NewLevel(getZapLoggerLevel(config.Log.Level, config.AppEnv))
+
+	encoder := zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+		MessageKey:   "message",
+		LevelKey:     "level",
+		EncodeLevel:  zapcore.CapitalLevelEncoder,
+		TimeKey:      "time",
+		EncodeTime:   zapcore.RFC3339TimeEncoder,
+		CallerKey:    "caller",
+		EncodeCaller: zapcore.ShortCallerEncoder,
+	})
+
+	logger := zap.New(
+		redactor.WrapCore(zapcore.NewCore(encoder, out, level.atomic)),
+		zap.ErrorOutput(out),
 		zap.AddCaller(),
 		zap.AddCallerSkip(1))
-	if err != nil {
-		return &ZapLogger{defaultLogger}, nil
-	}
 
-	return &ZapLogger{logger}, nil
+	l := &ZapLogger{inner: logger, level: level}
+	level.logger = l
+
+	return l, nil
+}
+
+// Level returns the controller of the log level, used to change it at runtime.
+func (l *ZapLogger) Level() *Level {
+	return l.level
 }
 
 // FromFields converts fields to zap.Field.
+// The fields are encoded according to the type of their value, so a wrong Field.Type cannot panic.
 func (l *ZapLogger) FromFields(fields Fields) any {
 	zapFields := make([]zap.Field, len(fields))
 	for i, f := range fields {
-		switch f.Type {
-		case "int":
-			zapFields[i] = zap.Int(f.Key, f.Value.(int))
-		case "string":
-			zapFields[i] = zap.String(f.Key, f.Value.(string))
-		case "error":
-			zapFields[i] = zap.Error(f.Value.(error))
-		default:
-			zapFields[i] = zap.Any(f.Key, f.Value)
-		}
+		zapFields[i] = zapField(f)
 	}
 	return zapFields
 }
 
+// zapField converts a field to zap.Field
+func zapField(f Field) zap.Field {
+	switch v := f.Value.(type) {
+	case error:
+		if f.Type == TypeError {
+			return zap.Error(v)
+		}
+		return zap.NamedError(f.Key, v)
+	case nil:
+		if f.Type == TypeError {
+			return zap.Skip()
+		}
+		return zap.Any(f.Key, nil)
+	case string:
+		return zap.String(f.Key, v)
+	case int:
+		return zap.Int(f.Key, v)
+	case int64:
+		return zap.Int64(f.Key, v)
+	case bool:
+		return zap.Bool(f.Key, v)
+	case time.Duration:
+		return zap.Duration(f.Key, v)
+	case time.Time:
+		return zap.Time(f.Key, v)
+	default:
+		return zap.Any(f.Key, v)
+	}
+}
+
+// With returns a child logger adding the fields to each log.
+func (l *ZapLogger) With(fields Fields) CustomLogger {
+	return &ZapLogger{
+		inner: l.inner.With(l.FromFields(fields).([]zap.Field)...),
+		level: l.level,
+	}
+}
+
 func (l *ZapLogger) Debug(msg string, fields ...Fields) {
 	var zapFields []zap.Field
 	if len(fields) == 1 {
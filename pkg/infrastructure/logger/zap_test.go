@@ -3,6 +3,7 @@ package logger
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap/zapcore"
@@ -49,3 +50,27 @@ func TestZapFromFields(t *testing.T) {
 	assert.Equal(t, "error", zapFields[2].Key)
 	assert.Equal(t, errors.New("internal server error"), zapFields[2].Interface.(error))
 }
+
+func TestZapFromFieldsWithWrongType(t *testing.T) {
+	fields := Fields{
+		NewField("code", "int", "500"),
+		NewField("message", "string", 42),
+		NewField("err", "error", "not an error"),
+		Err(nil),
+		Duration("latency", time.Second),
+	}
+
+	logger := &ZapLogger{}
+	var zapFields []zapcore.Field
+	assert.NotPanics(t, func() {
+		zapFields = logger.FromFields(fields).([]zapcore.Field)
+	})
+
+	assert.Equal(t, 5, len(zapFields))
+	assert.Equal(t, zapcore.StringType, zapFields[0].Type)
+	assert.Equal(t, "500", zapFields[0].String)
+	assert.Equal(t, zapcore.Int64Type, zapFields[1].Type)
+	assert.Equal(t, zapcore.StringType, zapFields[2].Type)
+	assert.Equal(t, zapcore.SkipType, zapFields[3].Type)
+	assert.Equal(t, zapcore.DurationType, zapFields[4].Type)
+}
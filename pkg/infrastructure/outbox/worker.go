@@ -0,0 +1,80 @@
+package outbox
+
+import (
+	"context"
+	"go-clean-api/pkg/domain/usecases"
+	"go-clean-api/pkg/infrastructure/logger"
+	"time"
+)
+
+const (
+	// DefaultInterval is the default interval between two dispatches
+	DefaultInterval = 5 * time.Second
+
+	// DefaultBatchSize is the default number of events dispatched at once
+	DefaultBatchSize = 100
+)
+
+// Worker dispatches the outbox events in background
+type Worker struct {
+	useCase   usecases.Outbox
+	interval  time.Duration
+	batchSize int
+	logger    logger.CustomLogger
+}
+
+// NewWorker creates a new Worker
+func NewWorker(useCase usecases.Outbox, interval time.Duration, batchSize int, l logger.CustomLogger) *Worker {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	return &Worker{
+		useCase:   useCase,
+		interval:  interval,
+		batchSize: batchSize,
+		logger:    l,
+	}
+}
+
+// Start dispatches the pending events at each interval until the context is canceled.
+func (w *Worker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.dispatch()
+		}
+	}
+}
+
+// dispatch sends the pending events batch by batch while full batches are processed.
+func (w *Worker) dispatch() {
+	for {
+		res, err := w.useCase.Dispatch(usecases.DispatchOutboxRequest{Limit: w.batchSize})
+		if err != nil {
+			w.logger.Error("error when dispatching outbox events", logger.Fields{
+				logger.Err(err),
+			})
+			return
+		}
+
+		if res.Failed > 0 {
+			w.logger.Warn("outbox events not delivered", logger.Fields{
+				logger.Int("dispatched", res.Dispatched),
+				logger.Int("failed", res.Failed),
+			})
+		}
+
+		if res.Dispatched+res.Failed < w.batchSize {
+			return
+		}
+	}
+}
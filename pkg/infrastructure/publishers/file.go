@@ -0,0 +1,43 @@
+package publishers
+
+import (
+	"encoding/json"
+	"go-clean-api/pkg/domain/entities"
+	"os"
+	"path"
+	"sync"
+)
+
+// FilePublisher publishes events in a file (one JSON message per line)
+type FilePublisher struct {
+	filePath string
+	mu       sync.Mutex
+}
+
+// NewFilePublisher creates a new FilePublisher
+func NewFilePublisher(filePath string) *FilePublisher {
+	return &FilePublisher{filePath: path.Clean(filePath)}
+}
+
+// Publish appends the event to the file
+func (p *FilePublisher) Publish(event entities.OutboxEvent) error {
+	line, err := json.Marshal(NewMessage(event))
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	f, err := os.OpenFile(p.filePath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0666)
+	if err != nil {
+		return err
+	}
+
+	if _, err = f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
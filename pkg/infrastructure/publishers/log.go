@@ -0,0 +1,29 @@
+package publishers
+
+import (
+	"go-clean-api/pkg/domain/entities"
+	"go-clean-api/pkg/infrastructure/logger"
+)
+
+// LogPublisher publishes events in the application logs
+type LogPublisher struct {
+	logger logger.CustomLogger
+}
+
+// NewLogPublisher creates a new LogPublisher
+func NewLogPublisher(l logger.CustomLogger) *LogPublisher {
+	return &LogPublisher{logger: l}
+}
+
+// Publish logs the event at info level
+func (p *LogPublisher) Publish(event entities.OutboxEvent) error {
+	p.logger.Info("event published", logger.Fields{
+		logger.String("event_id", event.ID.String()),
+		logger.String("event_name", event.Name),
+		logger.String("aggregate_id", event.AggregateID),
+		logger.String("request_id", event.RequestID),
+		logger.String("payload", string(event.Payload)),
+	})
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package publishers
+
+import (
+	"encoding/json"
+	"go-clean-api/pkg/domain/entities"
+)
+
+// Message is the JSON representation of an event sent by the publishers
+type Message struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	AggregateID string          `json:"aggregate_id"`
+	OccurredAt  string          `json:"occurred_at"`
+	RequestID   string          `json:"request_id,omitempty"`
+	Payload     json.RawMessage `json:"payload"`
+}
+
+// NewMessage creates a new Message from an outbox event
+func NewMessage(event entities.OutboxEvent) Message {
+	return Message{
+		ID:          event.ID.String(),
+		Name:        event.Name,
+		AggregateID: event.AggregateID,
+		OccurredAt:  event.OccurredAt.RFC3339(),
+		RequestID:   event.RequestID,
+		Payload:     json.RawMessage(event.Payload),
+	}
+}
@@ -0,0 +1,31 @@
+package publishers
+
+import (
+	"errors"
+	"go-clean-api/pkg/domain/entities"
+	"go-clean-api/pkg/domain/services"
+)
+
+// MultiPublisher publishes events to several publishers.
+// If one publisher fails, the event is retried on all publishers,
+// so the others may receive it more than once (at-least-once delivery).
+type MultiPublisher struct {
+	publishers []services.EventPublisher
+}
+
+// NewMultiPublisher creates a new MultiPublisher
+func NewMultiPublisher(publishers ...services.EventPublisher) *MultiPublisher {
+	return &MultiPublisher{publishers: publishers}
+}
+
+// Publish sends the event to all publishers and returns all the errors
+func (p *MultiPublisher) Publish(event entities.OutboxEvent) error {
+	var errs []error
+	for _, publisher := range p.publishers {
+		if err := publisher.Publish(event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
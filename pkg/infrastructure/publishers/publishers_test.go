@@ -0,0 +1,102 @@
+package publishers
+
+import (
+	"encoding/json"
+	"errors"
+	"go-clean-api/pkg/domain/entities"
+	vo "go-clean-api/pkg/domain/value_objects"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestEvent() entities.OutboxEvent {
+	return entities.OutboxEvent{
+		ID:          vo.NewID(),
+		Name:        "user.created",
+		AggregateID: "f47ac10b-58cc-0372-8562-0b8e853961a1",
+		Payload:     []byte(`{"user_id":"f47ac10b-58cc-0372-8562-0b8e853961a1"}`),
+		OccurredAt:  vo.NewTime(time.Now(), nil),
+	}
+}
+
+func TestFilePublisher(t *testing.T) {
+	filePath := path.Join(t.TempDir(), "events.log")
+	p := NewFilePublisher(filePath)
+
+	event := newTestEvent()
+	assert.Nil(t, p.Publish(event))
+	assert.Nil(t, p.Publish(event))
+
+	content, err := os.ReadFile(filePath)
+	assert.Nil(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Equal(t, 2, len(lines))
+
+	var msg Message
+	assert.Nil(t, json.Unmarshal([]byte(lines[0]), &msg))
+	assert.Equal(t, event.ID.String(), msg.ID)
+	assert.Equal(t, "user.created", msg.Name)
+	assert.JSONEq(t, string(event.Payload), string(msg.Payload))
+}
+
+func TestWebhookPublisher(t *testing.T) {
+	event := newTestEvent()
+
+	var received Message
+	var eventID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		eventID = r.Header.Get("X-Event-Id")
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &received)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	p := NewWebhookPublisher(server.URL, time.Second)
+	assert.Nil(t, p.Publish(event))
+	assert.Equal(t, event.ID.String(), eventID)
+	assert.Equal(t, event.AggregateID, received.AggregateID)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	p = NewWebhookPublisher(failing.URL, time.Second)
+	assert.NotNil(t, p.Publish(event))
+}
+
+type publisherFunc func(entities.OutboxEvent) error
+
+func (f publisherFunc) Publish(event entities.OutboxEvent) error {
+	return f(event)
+}
+
+func TestMultiPublisher(t *testing.T) {
+	calls := 0
+	ok := publisherFunc(func(entities.OutboxEvent) error {
+		calls++
+		return nil
+	})
+	errPublish := errors.New("publish error")
+	ko := publisherFunc(func(entities.OutboxEvent) error {
+		calls++
+		return errPublish
+	})
+
+	assert.Nil(t, NewMultiPublisher(ok, ok).Publish(newTestEvent()))
+	assert.Equal(t, 2, calls)
+
+	calls = 0
+	assert.ErrorIs(t, NewMultiPublisher(ok, ko, ok).Publish(newTestEvent()), errPublish)
+	assert.Equal(t, 3, calls)
+}
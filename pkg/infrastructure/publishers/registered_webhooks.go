@@ -0,0 +1,37 @@
+package publishers
+
+import (
+	"encoding/json"
+	"go-clean-api/pkg/domain/entities"
+	"go-clean-api/pkg/domain/usecases"
+)
+
+// RegisteredWebhooksPublisher publishes events to the webhooks registered through the API
+// which subscribed to them.
+//
+// Delivery log, retry schedule and disabling of failing webhooks are handled by the webhook use case.
+// The publication fails while a delivery to a webhook is pending, so that the outbox publishes it again later:
+// only the webhooks which did not receive it are retried, once their next attempt is due.
+type RegisteredWebhooksPublisher struct {
+	webhookUseCase usecases.Webhook
+}
+
+// NewRegisteredWebhooksPublisher creates a new RegisteredWebhooksPublisher
+func NewRegisteredWebhooksPublisher(webhookUseCase usecases.Webhook) *RegisteredWebhooksPublisher {
+	return &RegisteredWebhooksPublisher{webhookUseCase: webhookUseCase}
+}
+
+// Publish delivers the event to the subscribed webhooks
+func (p *RegisteredWebhooksPublisher) Publish(event entities.OutboxEvent) error {
+	body, err := json.Marshal(NewMessage(event))
+	if err != nil {
+		return err
+	}
+
+	_, err = p.webhookUseCase.Deliver(usecases.DeliverWebhookEventRequest{
+		Event: event,
+		Body:  body,
+	})
+
+	return err
+}
@@ -0,0 +1,60 @@
+package publishers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go-clean-api/pkg/domain/entities"
+	"net/http"
+	"time"
+)
+
+// DefaultWebhookTimeout is the default timeout of a webhook request
+const DefaultWebhookTimeout = 10 * time.Second
+
+// WebhookPublisher publishes events with an HTTP POST request to a URL.
+// Any response with a status code other than 2xx is considered as a failure.
+type WebhookPublisher struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookPublisher creates a new WebhookPublisher
+func NewWebhookPublisher(url string, timeout time.Duration) *WebhookPublisher {
+	if timeout <= 0 {
+		timeout = DefaultWebhookTimeout
+	}
+
+	return &WebhookPublisher{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Publish sends the event to the URL
+func (p *WebhookPublisher) Publish(event entities.OutboxEvent) error {
+	body, err := json.Marshal(NewMessage(event))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-Id", event.ID.String())
+	req.Header.Set("X-Event-Name", event.Name)
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s responded with status code %d", p.url, res.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package webhooks
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go-clean-api/pkg/domain/services"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultTimeout is the default timeout of a webhook request
+	DefaultTimeout = 10 * time.Second
+
+	// SignatureHeader is the header containing the HMAC-SHA256 signature of the request
+	SignatureHeader = "X-Webhook-Signature"
+
+	// TimestampHeader is the header containing the Unix timestamp used in the signature
+	TimestampHeader = "X-Webhook-Timestamp"
+
+	// DeliveryHeader is the header containing the delivery ID
+	DeliveryHeader = "X-Webhook-Delivery"
+
+	// EventIDHeader is the header containing the event ID
+	EventIDHeader = "X-Event-Id"
+
+	// EventNameHeader is the header containing the event name
+	EventNameHeader = "X-Event-Name"
+
+	// DefaultRequestIDHeader is the default header containing the ID of the request at the origin of the event
+	DefaultRequestIDHeader = "X-Request-Id"
+
+	// signaturePrefix is the prefix of the signature header value
+	signaturePrefix = "sha256="
+)
+
+var (
+	// ErrInvalidSignature is returned when a signature does not match the request
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+
+	// ErrExpiredSignature is returned when the signature timestamp is too old
+	ErrExpiredSignature = errors.New("expired webhook signature")
+)
+
+// Sign returns the value of the signature header: the hex encoded HMAC-SHA256
+// of "<timestamp>.<body>" with the webhook secret.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of a webhook request received by a partner system.
+// A tolerance of 0 disables the timestamp check.
+func Verify(secret, signature, timestamp string, body []byte, tolerance time.Duration) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid timestamp", ErrInvalidSignature)
+	}
+
+	if tolerance > 0 {
+		if age := time.Since(time.Unix(ts, 0)); age > tolerance || age < -tolerance {
+			return ErrExpiredSignature
+		}
+	}
+
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(Sign(secret, ts, body))) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// HTTPSender sends signed JSON requests to webhooks.
+// Any response with a status code other than 2xx is considered as a failure.
+type HTTPSender struct {
+	client          *http.Client
+	requestIDHeader string
+}
+
+// NewHTTPSender creates a new HTTPSender.
+// The request ID is sent in the requestIDHeader header (X-Request-Id by default).
+func NewHTTPSender(timeout time.Duration, requestIDHeader string) *HTTPSender {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	if requestIDHeader == "" {
+		requestIDHeader = DefaultRequestIDHeader
+	}
+
+	return &HTTPSender{
+		client:          &http.Client{Timeout: timeout},
+		requestIDHeader: requestIDHeader,
+	}
+}
+
+// Send sends a signed POST request to the webhook URL
+func (s *HTTPSender) Send(r services.WebhookRequest) (res services.WebhookResponse, err error) {
+	req, err := http.NewRequest(http.MethodPost, r.URL, bytes.NewReader(r.Body))
+	if err != nil {
+		return
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(DeliveryHeader, r.DeliveryID)
+	req.Header.Set(EventIDHeader, r.EventID)
+	req.Header.Set(EventNameHeader, r.EventName)
+	if r.RequestID != "" {
+		req.Header.Set(s.requestIDHeader, r.RequestID)
+	}
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(r.Secret, timestamp, r.Body))
+
+	start := time.Now()
+	resp, err := s.client.Do(req)
+	res.Duration = time.Since(start)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	// Drain the body to reuse the connection
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	res.StatusCode = resp.StatusCode
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		err = fmt.Errorf("webhook %s responded with status code %d", r.URL, resp.StatusCode)
+	}
+
+	return
+}
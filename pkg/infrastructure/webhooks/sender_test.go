@@ -0,0 +1,75 @@
+package webhooks
+
+import (
+	"go-clean-api/pkg/domain/services"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	timestamp := time.Now().Unix()
+	signature := Sign("secret", timestamp, body)
+	ts := strconv.FormatInt(timestamp, 10)
+
+	assert.Nil(t, Verify("secret", signature, ts, body, time.Minute))
+	assert.ErrorIs(t, Verify("other", signature, ts, body, time.Minute), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify("secret", signature, ts, []byte(`{"id":"2"}`), time.Minute), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify("secret", signature, "invalid", body, time.Minute), ErrInvalidSignature)
+
+	old := time.Now().Add(-time.Hour).Unix()
+	assert.ErrorIs(t, Verify("secret", Sign("secret", old, body), strconv.FormatInt(old, 10), body, time.Minute), ErrExpiredSignature)
+	assert.Nil(t, Verify("secret", Sign("secret", old, body), strconv.FormatInt(old, 10), body, 0))
+}
+
+func TestHTTPSender(t *testing.T) {
+	var verifyErr error
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header
+		body, _ := io.ReadAll(r.Body)
+		verifyErr = Verify("secret", r.Header.Get(SignatureHeader), r.Header.Get(TimestampHeader), body, time.Minute)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sender := NewHTTPSender(time.Second, "X-Correlation-Id")
+	res, err := sender.Send(services.WebhookRequest{
+		URL:        server.URL,
+		Secret:     "secret",
+		DeliveryID: "delivery-id",
+		EventID:    "event-id",
+		EventName:  "user.created",
+		RequestID:  "request-id",
+		Body:       []byte(`{"id":"event-id"}`),
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, verifyErr)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	assert.Equal(t, "application/json", headers.Get("Content-Type"))
+	assert.Equal(t, "delivery-id", headers.Get(DeliveryHeader))
+	assert.Equal(t, "event-id", headers.Get(EventIDHeader))
+	assert.Equal(t, "user.created", headers.Get(EventNameHeader))
+	assert.Equal(t, "request-id", headers.Get("X-Correlation-Id"))
+}
+
+func TestHTTPSenderFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	res, err := NewHTTPSender(time.Second, "").Send(services.WebhookRequest{URL: server.URL, Secret: "secret"})
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+
+	server.Close()
+	_, err = NewHTTPSender(time.Second, "").Send(services.WebhookRequest{URL: server.URL, Secret: "secret"})
+	assert.NotNil(t, err)
+}
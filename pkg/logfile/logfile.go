@@ -0,0 +1,175 @@
+// Package logfile provides the log files of the application:
+// rotated by size and time, compressed, purged and with buffered writes.
+package logfile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go-clean-api/pkg"
+
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// files contains the opened log files by path, a file must be written by a single rotator
+var (
+	filesMu sync.Mutex
+	files   = make(map[string]*File)
+)
+
+// File is a log file implementing zapcore.WriteSyncer.
+// It is rotated when it reaches its maximum size and at each rotation interval,
+// the rotated files are compressed and purged according to the retention.
+type File struct {
+	path      string
+	rotator   *lumberjack.Logger
+	buffer    *zapcore.BufferedWriteSyncer
+	out       zapcore.WriteSyncer
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// Open opens a log file or returns the already opened one with the same path
+func Open(path string, cfg pkg.ConfigLog) (*File, error) {
+	path, err := filepath.Abs(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+
+	filesMu.Lock()
+	defer filesMu.Unlock()
+
+	if f, ok := files[path]; ok {
+		return f, nil
+	}
+
+	// lumberjack would move an existing path which cannot be opened as a file
+	if info, err := os.Stat(path); err == nil && !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("%s is not a regular file", path)
+	}
+
+	rotator := &lumberjack.Logger{
+		Filename:   path,
+		MaxSize:    cfg.Rotation.MaxSize,
+		MaxBackups: cfg.Rotation.MaxBackups,
+		MaxAge:     cfg.Rotation.MaxAge,
+		Compress:   cfg.Rotation.Compress,
+		LocalTime:  true,
+	}
+	if cfg.Rotation.MaxSize <= 0 {
+		// No size rotation
+		rotator.MaxSize = 1 << 30
+	}
+
+	// The file is opened now to report the errors at startup
+	if _, err := rotator.Write(nil); err != nil {
+		return nil, err
+	}
+
+	f := &File{
+		path:    path,
+		rotator: rotator,
+		out:     zapcore.AddSync(rotator),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	if cfg.BufferSize > 0 {
+		f.buffer = &zapcore.BufferedWriteSyncer{
+			WS:            f.out,
+			Size:          cfg.BufferSize,
+			FlushInterval: cfg.FlushInterval,
+		}
+		f.out = f.buffer
+	}
+
+	go f.rotateEvery(cfg.Rotation.Interval)
+
+	files[path] = f
+
+	return f, nil
+}
+
+// Write writes in the buffer or in the file if the writes are not buffered
+func (f *File) Write(p []byte) (int, error) {
+	return f.out.Write(p)
+}
+
+// Sync flushes the buffer
+func (f *File) Sync() error {
+	return f.out.Sync()
+}
+
+// Rotate flushes the buffer and rotates the file
+func (f *File) Rotate() error {
+	return errors.Join(f.out.Sync(), f.rotator.Rotate())
+}
+
+// Close flushes the buffer, stops the rotations and closes the file
+func (f *File) Close() (err error) {
+	f.closeOnce.Do(func() {
+		close(f.stop)
+		<-f.done
+
+		if f.buffer != nil {
+			err = f.buffer.Stop()
+		}
+		err = errors.Join(err, f.rotator.Close())
+
+		filesMu.Lock()
+		delete(files, f.path)
+		filesMu.Unlock()
+	})
+	return
+}
+
+// rotateEvery rotates the file at each multiple of the interval (no rotation if it is not positive)
+func (f *File) rotateEvery(interval time.Duration) {
+	defer close(f.done)
+
+	if interval <= 0 {
+		<-f.stop
+		return
+	}
+
+	for {
+		now := time.Now()
+		timer := time.NewTimer(nextRotation(now, interval).Sub(now))
+		select {
+		case <-f.stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+			f.Rotate()
+		}
+	}
+}
+
+// nextRotation returns the next multiple of the interval in local time
+// (every day at midnight for 24h, every hour at minute 0 for 1h...)
+func nextRotation(now time.Time, interval time.Duration) time.Time {
+	_, offset := now.Zone()
+	shift := time.Duration(offset) * time.Second
+	return now.Add(shift).Truncate(interval).Add(interval).Add(-shift)
+}
+
+// CloseAll flushes and closes all the opened log files, it must be called at shutdown
+func CloseAll() error {
+	filesMu.Lock()
+	opened := make([]*File, 0, len(files))
+	for _, f := range files {
+		opened = append(opened, f)
+	}
+	filesMu.Unlock()
+
+	var err error
+	for _, f := range opened {
+		err = errors.Join(err, f.Close())
+	}
+	return err
+}
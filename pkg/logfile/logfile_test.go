@@ -0,0 +1,89 @@
+package logfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-clean-api/pkg"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenBufferedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	cfg := pkg.ConfigLog{BufferSize: 1024, FlushInterval: time.Hour}
+
+	f, err := Open(path, cfg)
+	assert.Nil(t, err)
+	defer f.Close()
+
+	// The same file is returned for the same path
+	same, err := Open(filepath.Join(filepath.Dir(path), ".", "app.log"), cfg)
+	assert.Nil(t, err)
+	assert.Same(t, f, same)
+
+	_, err = f.Write([]byte("line 1\n"))
+	assert.Nil(t, err)
+	assertFileContent(t, path, "")
+
+	assert.Nil(t, f.Sync())
+	assertFileContent(t, path, "line 1\n")
+
+	// The buffer is flushed on close
+	_, err = f.Write([]byte("line 2\n"))
+	assert.Nil(t, err)
+	assert.Nil(t, CloseAll())
+	assertFileContent(t, path, "line 1\nline 2\n")
+
+	// A closed file is opened again
+	f2, err := Open(path, cfg)
+	assert.Nil(t, err)
+	assert.NotSame(t, f, f2)
+	assert.Nil(t, f2.Close())
+}
+
+func TestRotateCompressedFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	f, err := Open(path, pkg.ConfigLog{Rotation: pkg.ConfigLogRotation{Compress: true}})
+	assert.Nil(t, err)
+	defer f.Close()
+
+	_, err = f.Write([]byte("before rotation\n"))
+	assert.Nil(t, err)
+	assert.Nil(t, f.Rotate())
+	_, err = f.Write([]byte("after rotation\n"))
+	assert.Nil(t, err)
+
+	assertFileContent(t, path, "after rotation\n")
+	assert.Eventually(t, func() bool {
+		matches, _ := filepath.Glob(filepath.Join(dir, "app-*.log.gz"))
+		return len(matches) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestOpenWithInvalidPath(t *testing.T) {
+	_, err := Open(t.TempDir(), pkg.ConfigLog{})
+	assert.NotNil(t, err)
+}
+
+func TestNextRotation(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip("no time zone database")
+	}
+
+	now := time.Date(2025, 4, 20, 15, 42, 10, 0, paris)
+	assert.Equal(t, time.Date(2025, 4, 21, 0, 0, 0, 0, paris), nextRotation(now, 24*time.Hour))
+	assert.Equal(t, time.Date(2025, 4, 20, 16, 0, 0, 0, paris), nextRotation(now, time.Hour))
+	assert.Equal(t, time.Date(2025, 4, 20, 15, 45, 0, 0, paris), nextRotation(now, 15*time.Minute))
+}
+
+func assertFileContent(t *testing.T, path, wanted string) {
+	content, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, wanted, string(content))
+}